@@ -0,0 +1,221 @@
+// Package expression parses and validates the metric expressions of Mackerel,
+// which are used by expression monitors and expression graphs.
+// e.g. avg(group(role(service:role, loadavg5), host(22CXRB3pZmu, loadavg5)))
+package expression
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Node is a node of the expression, which is one of *Call, *Word and *String.
+type Node interface {
+	// Returns the offset of the node in bytes.
+	Pos() int
+	String() string
+}
+
+// Call is a function call such as avg(...).
+type Call struct {
+	Name   string
+	Args   []Node
+	Offset int
+}
+
+// Word is an unquoted argument such as host IDs, metric names and numbers.
+type Word struct {
+	Value  string
+	Offset int
+}
+
+// String is a quoted argument.
+type String struct {
+	Value  string
+	Quote  byte
+	Offset int
+}
+
+func (c *Call) Pos() int   { return c.Offset }
+func (w *Word) Pos() int   { return w.Offset }
+func (s *String) Pos() int { return s.Offset }
+
+func (c *Call) String() string {
+	args := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("%s(%s)", c.Name, strings.Join(args, ", "))
+}
+
+func (w *Word) String() string {
+	return w.Value
+}
+
+func (s *String) String() string {
+	q := string(s.Quote)
+	return q + strings.ReplaceAll(strings.ReplaceAll(s.Value, `\`, `\\`), q, `\`+q) + q
+}
+
+// Error is an error at a position of the expression.
+type Error struct {
+	// The offset in bytes.
+	Offset int
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at column %d", e.Msg, e.Offset+1)
+}
+
+func errorf(offset int, format string, args ...any) *Error {
+	return &Error{Offset: offset, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Parses the expression into the function call at the top level.
+// Parse checks only the syntax; use Validate to check functions and their arguments as well.
+func Parse(expr string) (*Call, error) {
+	p := &parser{src: expr}
+	p.skipSpaces()
+	if p.eof() {
+		return nil, errorf(p.pos, "empty expression")
+	}
+	node, err := p.parseArg()
+	if err != nil {
+		return nil, err
+	}
+	call, ok := node.(*Call)
+	if !ok {
+		return nil, errorf(node.Pos(), "expected a function call, but got %s", node)
+	}
+	p.skipSpaces()
+	if !p.eof() {
+		return nil, errorf(p.pos, "unexpected %q after the expression", p.src[p.pos])
+	}
+	return call, nil
+}
+
+type parser struct {
+	src string
+	pos int
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *parser) skipSpaces() {
+	for !p.eof() && isSpace(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// Reports whether c terminates a word.
+func isDelimiter(c byte) bool {
+	return isSpace(c) || c == '(' || c == ')' || c == ',' || c == '\'' || c == '"'
+}
+
+func (p *parser) parseArg() (Node, error) {
+	p.skipSpaces()
+	if p.eof() {
+		return nil, errorf(p.pos, "unexpected end of the expression")
+	}
+
+	start := p.pos
+	switch c := p.src[p.pos]; c {
+	case '\'', '"':
+		return p.parseString()
+	case '(', ')', ',':
+		return nil, errorf(p.pos, "unexpected %q", c)
+	}
+
+	for !p.eof() && !isDelimiter(p.src[p.pos]) {
+		p.pos++
+	}
+	word := p.src[start:p.pos]
+
+	p.skipSpaces()
+	if p.eof() || p.src[p.pos] != '(' {
+		return &Word{Value: word, Offset: start}, nil
+	}
+	if !isFunctionName(word) {
+		return nil, errorf(start, "invalid function name %q", word)
+	}
+	p.pos++ // (
+	return p.parseCall(word, start)
+}
+
+func (p *parser) parseCall(name string, start int) (*Call, error) {
+	call := &Call{Name: name, Args: []Node{}, Offset: start}
+
+	p.skipSpaces()
+	if !p.eof() && p.src[p.pos] == ')' {
+		p.pos++
+		return call, nil
+	}
+
+	for {
+		p.skipSpaces()
+		if p.eof() {
+			return nil, errorf(start, "unclosed parenthesis of %s", name)
+		}
+		arg, err := p.parseArg()
+		if err != nil {
+			return nil, err
+		}
+		call.Args = append(call.Args, arg)
+
+		p.skipSpaces()
+		if p.eof() {
+			return nil, errorf(start, "unclosed parenthesis of %s", name)
+		}
+		switch c := p.src[p.pos]; c {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return call, nil
+		default:
+			return nil, errorf(p.pos, "unexpected %q in the arguments of %s", c, name)
+		}
+	}
+}
+
+func (p *parser) parseString() (*String, error) {
+	start := p.pos
+	quote := p.src[p.pos]
+	p.pos++
+
+	var b strings.Builder
+	for !p.eof() {
+		c := p.src[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return &String{Value: b.String(), Quote: quote, Offset: start}, nil
+		case c == '\\' && p.pos+1 < len(p.src):
+			b.WriteByte(p.src[p.pos+1])
+			p.pos += 2
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	return nil, errorf(start, "unterminated string")
+}
+
+func isFunctionName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || i > 0 && '0' <= c && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
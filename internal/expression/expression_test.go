@@ -0,0 +1,115 @@
+package expression_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/expression"
+)
+
+func Test_Parse(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		in        string
+		wants     *expression.Call
+		wantErrAt int
+	}{
+		"host": {
+			in: "host(22CXRB3pZmu, memory.*)",
+			wants: &expression.Call{Name: "host", Offset: 0, Args: []expression.Node{
+				&expression.Word{Value: "22CXRB3pZmu", Offset: 5},
+				&expression.Word{Value: "memory.*", Offset: 18},
+			}},
+		},
+		"nested": {
+			in: " alias( avg(role(my-service:db, loadavg5)), 'load \\'avg\\'' ) ",
+			wants: &expression.Call{Name: "alias", Offset: 1, Args: []expression.Node{
+				&expression.Call{Name: "avg", Offset: 8, Args: []expression.Node{
+					&expression.Call{Name: "role", Offset: 12, Args: []expression.Node{
+						&expression.Word{Value: "my-service:db", Offset: 17},
+						&expression.Word{Value: "loadavg5", Offset: 32},
+					}},
+				}},
+				&expression.String{Value: "load 'avg'", Quote: '\'', Offset: 44},
+			}},
+		},
+		"no arguments": {
+			in:    "group()",
+			wants: &expression.Call{Name: "group", Args: []expression.Node{}},
+		},
+		"empty": {
+			in:        "  ",
+			wantErrAt: 2,
+		},
+		"not a call": {
+			in:        "loadavg5",
+			wantErrAt: 0,
+		},
+		"unclosed": {
+			in:        "avg(role(foo:bar, loadavg5)",
+			wantErrAt: 0,
+		},
+		"extra parenthesis": {
+			in:        "avg(role(foo:bar, loadavg5)))",
+			wantErrAt: 28,
+		},
+		"trailing comma": {
+			in:        "group(host(a, b),)",
+			wantErrAt: 17,
+		},
+		"unterminated string": {
+			in:        "alias(host(a, b), 'name)",
+			wantErrAt: 18,
+		},
+		"missing comma": {
+			in:        "scale(host(a, b) 2)",
+			wantErrAt: 17,
+		},
+		"invalid function name": {
+			in:        "avg.x(host(a, b))",
+			wantErrAt: 0,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			call, err := expression.Parse(tt.in)
+			if tt.wants == nil {
+				var exprErr *expression.Error
+				if !errors.As(err, &exprErr) {
+					t.Fatalf("expected an expression error, but got: %v", err)
+				}
+				if exprErr.Offset != tt.wantErrAt {
+					t.Errorf("expected the error at %d, but got: %v (%d)", tt.wantErrAt, err, exprErr.Offset)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.wants, call); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_Call_String(t *testing.T) {
+	t.Parallel()
+
+	call, err := expression.Parse(`alias(scale(host(a,b),0.5),"it's \"half\"")`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `alias(scale(host(a, b), 0.5), "it's \"half\"")`
+	if got := call.String(); got != want {
+		t.Errorf("expected %s, but got %s", want, got)
+	}
+	if _, err := expression.Parse(call.String()); err != nil {
+		t.Errorf("failed to parse the formatted expression: %v", err)
+	}
+}
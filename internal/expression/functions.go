@@ -0,0 +1,159 @@
+package expression
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/textutil"
+)
+
+type argKind int
+
+const (
+	// A function call which returns metrics.
+	metricsArg argKind = iota
+	// A word or a quoted string, such as a host ID, a metric name or a number.
+	valueArg
+)
+
+type signature struct {
+	params []argKind
+	// The last parameter can be repeated.
+	variadic bool
+	// The number of the trailing parameters which can be omitted.
+	optional int
+}
+
+func fn(params ...argKind) signature {
+	return signature{params: params}
+}
+
+// https://mackerel.io/docs/entry/advanced/advanced-graph
+// Calls of other functions are only warned about since Mackerel may add new functions.
+// The arities are also only warned about since Mackerel may add optional parameters.
+var functions = map[string]signature{
+	"host":             fn(valueArg, valueArg),
+	"service":          fn(valueArg, valueArg),
+	"role":             fn(valueArg, valueArg),
+	"roleSlots":        fn(valueArg, valueArg),
+	"group":            {params: []argKind{metricsArg}, variadic: true},
+	"avg":              fn(metricsArg),
+	"max":              fn(metricsArg),
+	"min":              fn(metricsArg),
+	"sum":              fn(metricsArg),
+	"product":          fn(metricsArg),
+	"stack":            fn(metricsArg),
+	"diff":             {params: []argKind{metricsArg, metricsArg}, optional: 1},
+	"divide":           fn(metricsArg, metricsArg),
+	"scale":            fn(metricsArg, valueArg),
+	"offset":           fn(metricsArg, valueArg),
+	"percentile":       fn(metricsArg, valueArg),
+	"alias":            fn(metricsArg, valueArg),
+	"timeShift":        fn(metricsArg, valueArg),
+	"movingAverage":    fn(metricsArg, valueArg),
+	"linearRegression": {params: []argKind{metricsArg, valueArg, valueArg}, optional: 1},
+	"timeLeftForecast": fn(metricsArg, valueArg, valueArg),
+}
+
+// Returns the names of the known functions in order.
+func FunctionNames() []string {
+	names := make([]string, 0, len(functions))
+	for name := range functions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Parses the expression and checks the kinds of the arguments.
+// Calls of unknown functions and unexpected numbers of arguments are not errors but warnings,
+// whose arguments are checked as far as possible.
+func Validate(expr string) (warnings []error, err error) {
+	call, err := Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	v := &validator{}
+	if err := v.validateCall(call); err != nil {
+		return nil, err
+	}
+	return v.warnings, nil
+}
+
+type validator struct {
+	warnings []error
+}
+
+func (v *validator) validateCall(call *Call) error {
+	sig, ok := functions[call.Name]
+	if !ok {
+		if name := textutil.Suggest(call.Name, FunctionNames()); name != "" {
+			v.warnings = append(v.warnings, errorf(call.Offset, "unknown function %s, did you mean %s?", call.Name, name))
+		} else {
+			v.warnings = append(v.warnings, errorf(call.Offset, "unknown function %s", call.Name))
+		}
+		return v.validateNestedCalls(call)
+	}
+
+	if n := len(call.Args); !sig.accepts(n) {
+		v.warnings = append(v.warnings, errorf(call.Offset, "%s takes %s, but got %d", call.Name, sig.describeArity(), n))
+		return v.validateNestedCalls(call)
+	}
+
+	for i, arg := range call.Args {
+		kind := sig.params[min(i, len(sig.params)-1)]
+		switch arg := arg.(type) {
+		case *Call:
+			if kind != metricsArg {
+				return errorf(arg.Offset, "argument %d of %s must be a name, a number or a quoted string, but got %s", i+1, call.Name, arg.Name+"(...)")
+			}
+			if err := v.validateCall(arg); err != nil {
+				return err
+			}
+		default:
+			if kind != valueArg {
+				return errorf(arg.Pos(), "argument %d of %s must be a function call, but got %s", i+1, call.Name, arg)
+			}
+			if w, ok := arg.(*Word); ok && w.Value == "" {
+				return errorf(arg.Pos(), "argument %d of %s is empty", i+1, call.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// Checks the arguments which are function calls, without knowing the kinds of the parameters.
+func (v *validator) validateNestedCalls(call *Call) error {
+	for _, arg := range call.Args {
+		if arg, ok := arg.(*Call); ok {
+			if err := v.validateCall(arg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s signature) accepts(n int) bool {
+	if s.variadic {
+		return n >= len(s.params)
+	}
+	return len(s.params)-s.optional <= n && n <= len(s.params)
+}
+
+func (s signature) describeArity() string {
+	plural := func(n int) string {
+		if n == 1 {
+			return "1 argument"
+		}
+		return fmt.Sprintf("%d arguments", n)
+	}
+	switch {
+	case s.variadic:
+		return "at least " + plural(len(s.params))
+	case s.optional > 0:
+		return fmt.Sprintf("%d to %s", len(s.params)-s.optional, plural(len(s.params)))
+	default:
+		return plural(len(s.params))
+	}
+}
@@ -0,0 +1,111 @@
+package expression_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/expression"
+)
+
+func Test_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		in          string
+		wantWarning string
+		wantErr     string
+	}{
+		"role": {
+			in: "role(my-service:db, loadavg5)",
+		},
+		"grouped": {
+			in: "avg(group(host(22CXRB3pZmu, cpu.user.percentage), service(my-service, custom.foo.*)))",
+		},
+		"values": {
+			in: "alias(timeShift(scale(host(a, b), -0.5), 1d), 'yesterday')",
+		},
+		"optional argument": {
+			in: "linearRegression(role(foo:bar, filesystem.*), 1d, 7d)",
+		},
+		"syntax error": {
+			in:      "avg(role(foo:bar, loadavg5)",
+			wantErr: "unclosed parenthesis of avg at column 1",
+		},
+		"diff of two metrics": {
+			in: "diff(host(a, memory.total), host(a, memory.used))",
+		},
+		"diff of a group": {
+			in: "diff(group(host(a, memory.total), host(a, memory.used)))",
+		},
+		"unknown function": {
+			in:          "average(role(foo:bar, loadavg5))",
+			wantWarning: "unknown function average at column 1",
+		},
+		"typo": {
+			in:          "avg(rol(foo:bar, loadavg5))",
+			wantWarning: "unknown function rol, did you mean role? at column 5",
+		},
+		"case": {
+			in:          "timeshift(role(foo:bar, loadavg5), 1d)",
+			wantWarning: "did you mean timeShift?",
+		},
+		"unknown function with values": {
+			in:          "newFunction(role(foo:bar, loadavg5), 1d, 'label')",
+			wantWarning: "unknown function newFunction at column 1",
+		},
+		"invalid arguments of unknown function": {
+			in:      "newFunction(scale(role(foo:bar, loadavg5), role(foo:bar, loadavg5)))",
+			wantErr: "argument 2 of scale must be a name, a number or a quoted string",
+		},
+		"too few arguments": {
+			in:          "scale(role(foo:bar, loadavg5))",
+			wantWarning: "scale takes 2 arguments, but got 1 at column 1",
+		},
+		"too many arguments": {
+			in:          "avg(role(foo:bar, loadavg5), role(foo:baz, loadavg5))",
+			wantWarning: "avg takes 1 argument, but got 2",
+		},
+		"too many arguments with invalid arguments": {
+			in:      "avg(role(foo:bar, loadavg5), scale(role(foo:baz, loadavg5), host(a, b)))",
+			wantErr: "argument 2 of scale must be a name, a number or a quoted string",
+		},
+		"optional argument omitted": {
+			in: "linearRegression(role(foo:bar, filesystem.*), 1d)",
+		},
+		"empty group": {
+			in:          "group()",
+			wantWarning: "group takes at least 1 argument, but got 0",
+		},
+		"value for metrics": {
+			in:      "avg(loadavg5)",
+			wantErr: "argument 1 of avg must be a function call, but got loadavg5",
+		},
+		"metrics for value": {
+			in:      "host(host(a, b), loadavg5)",
+			wantErr: "argument 1 of host must be a name, a number or a quoted string",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			warnings, err := expression.Validate(tt.in)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, but got: %v", tt.wantErr, err)
+			}
+
+			if tt.wantWarning == "" {
+				if len(warnings) > 0 {
+					t.Errorf("unexpected warnings: %v", warnings)
+				}
+			} else if len(warnings) != 1 || !strings.Contains(warnings[0].Error(), tt.wantWarning) {
+				t.Errorf("expected a warning containing %q, but got: %v", tt.wantWarning, warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package mackerel
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio/mackerel-client-go"
+)
+
+type AlertGroupSettingModel struct {
+	ID                   types.String   `tfsdk:"id"`
+	Name                 types.String   `tfsdk:"name"`
+	Memo                 types.String   `tfsdk:"memo"`
+	ServiceScopes        []types.String `tfsdk:"service_scopes"`
+	RoleScopes           []types.String `tfsdk:"role_scopes"`
+	MonitorScopes        []types.String `tfsdk:"monitor_scopes"`
+	NotificationInterval types.Int64    `tfsdk:"notification_interval"`
+}
+
+// Reads an alert group setting by `id`
+func ReadAlertGroupSetting(ctx context.Context, client *Client, id string) (AlertGroupSettingModel, error) {
+	return readAlertGroupSettingInner(ctx, WithContext(ctx, client), id)
+}
+
+type alertGroupSettingGetter interface {
+	GetAlertGroupSetting(string) (*mackerel.AlertGroupSetting, error)
+}
+
+func readAlertGroupSettingInner(_ context.Context, client alertGroupSettingGetter, id string) (AlertGroupSettingModel, error) {
+	setting, err := client.GetAlertGroupSetting(id)
+	if err != nil {
+		return AlertGroupSettingModel{}, err
+	}
+	return newAlertGroupSettingModel(*setting), nil
+}
+
+// Creates an alert group setting
+func (m *AlertGroupSettingModel) Create(ctx context.Context, client *Client) error {
+	return m.createInner(ctx, WithContext(ctx, client))
+}
+
+type alertGroupSettingCreator interface {
+	CreateAlertGroupSetting(*mackerel.AlertGroupSetting) (*mackerel.AlertGroupSetting, error)
+}
+
+func (m *AlertGroupSettingModel) createInner(_ context.Context, client alertGroupSettingCreator) error {
+	param := m.mackerelAlertGroupSetting()
+	setting, err := client.CreateAlertGroupSetting(&param)
+	if err != nil {
+		return err
+	}
+
+	m.ID = types.StringValue(setting.ID)
+	return nil
+}
+
+// Reads the alert group setting
+func (m *AlertGroupSettingModel) Read(ctx context.Context, client *Client) error {
+	data, err := ReadAlertGroupSetting(ctx, client, m.ID.ValueString())
+	if err != nil {
+		return err
+	}
+	*m = data
+	return nil
+}
+
+// Updates the alert group setting
+func (m *AlertGroupSettingModel) Update(ctx context.Context, client *Client) error {
+	param := m.mackerelAlertGroupSetting()
+	if _, err := WithContext(ctx, client).UpdateAlertGroupSetting(m.ID.ValueString(), &param); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Deletes the alert group setting
+func (m *AlertGroupSettingModel) Delete(ctx context.Context, client *Client) error {
+	if _, err := WithContext(ctx, client).DeleteAlertGroupSetting(m.ID.ValueString()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// API -> Model
+func newAlertGroupSettingModel(setting mackerel.AlertGroupSetting) AlertGroupSettingModel {
+	return AlertGroupSettingModel{
+		ID:                   types.StringValue(setting.ID),
+		Name:                 types.StringValue(setting.Name),
+		Memo:                 types.StringValue(setting.Memo),
+		ServiceScopes:        normalizeScopes(setting.ServiceScopes),
+		RoleScopes:           normalizeScopes(setting.RoleScopes),
+		MonitorScopes:        normalizeScopes(setting.MonitorScopes),
+		NotificationInterval: types.Int64Value(int64(setting.NotificationInterval)),
+	}
+}
+
+// Model -> API
+func (m AlertGroupSettingModel) mackerelAlertGroupSetting() mackerel.AlertGroupSetting {
+	return mackerel.AlertGroupSetting{
+		ID:                   m.ID.ValueString(),
+		Name:                 m.Name.ValueString(),
+		Memo:                 m.Memo.ValueString(),
+		ServiceScopes:        stringsFromValues(m.ServiceScopes),
+		RoleScopes:           stringsFromValues(m.RoleScopes),
+		MonitorScopes:        stringsFromValues(m.MonitorScopes),
+		NotificationInterval: uint64(m.NotificationInterval.ValueInt64()),
+	}
+}
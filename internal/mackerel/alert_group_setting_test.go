@@ -0,0 +1,125 @@
+package mackerel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio/mackerel-client-go"
+)
+
+func Test_AlertGroupSetting_Read(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		inID     string
+		inClient alertGroupSettingGetterFunc
+
+		wantErr bool
+		wants   AlertGroupSettingModel
+	}{
+		"valid": {
+			inID: "ags0",
+			inClient: func(id string) (*mackerel.AlertGroupSetting, error) {
+				return &mackerel.AlertGroupSetting{
+					ID:                   id,
+					Name:                 "alert group",
+					Memo:                 "memo",
+					ServiceScopes:        []string{"service"},
+					RoleScopes:           []string{"service: role"},
+					NotificationInterval: 60,
+				}, nil
+			},
+
+			wants: AlertGroupSettingModel{
+				ID:                   types.StringValue("ags0"),
+				Name:                 types.StringValue("alert group"),
+				Memo:                 types.StringValue("memo"),
+				ServiceScopes:        []types.String{types.StringValue("service")},
+				RoleScopes:           []types.String{types.StringValue("service:role")},
+				MonitorScopes:        []types.String{},
+				NotificationInterval: types.Int64Value(60),
+			},
+		},
+		"not found": {
+			inID: "ags1",
+			inClient: func(id string) (*mackerel.AlertGroupSetting, error) {
+				return nil, &APIError{StatusCode: 404}
+			},
+
+			wantErr: true,
+		},
+	}
+
+	ctx := context.Background()
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s, err := readAlertGroupSettingInner(ctx, tt.inClient, tt.inID)
+			if (err != nil) != tt.wantErr {
+				if tt.wantErr {
+					t.Errorf("expect error, but got no error")
+				} else {
+					t.Errorf("unexpected error: %+v", err)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(s, tt.wants); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+type alertGroupSettingGetterFunc func(string) (*mackerel.AlertGroupSetting, error)
+
+func (f alertGroupSettingGetterFunc) GetAlertGroupSetting(id string) (*mackerel.AlertGroupSetting, error) {
+	return f(id)
+}
+
+func Test_AlertGroupSetting_Create(t *testing.T) {
+	t.Parallel()
+
+	in := AlertGroupSettingModel{
+		ID:                   types.StringUnknown(),
+		Name:                 types.StringValue("alert group"),
+		Memo:                 types.StringValue(""),
+		ServiceScopes:        []types.String{},
+		RoleScopes:           []types.String{types.StringValue("service:role")},
+		MonitorScopes:        []types.String{types.StringValue("m0")},
+		NotificationInterval: types.Int64Value(0),
+	}
+	wantReq := mackerel.AlertGroupSetting{
+		Name:          "alert group",
+		ServiceScopes: []string{},
+		RoleScopes:    []string{"service:role"},
+		MonitorScopes: []string{"m0"},
+	}
+
+	client := &alertGroupSettingCreatorTester{ID: "ags0"}
+	if err := in.createInner(context.Background(), client); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if diff := cmp.Diff(client.Request, wantReq); diff != "" {
+		t.Errorf("invalid request:\n%s", diff)
+	}
+	if in.ID.ValueString() != "ags0" {
+		t.Errorf("expected ID to be ags0, but got %s", in.ID)
+	}
+}
+
+type alertGroupSettingCreatorTester struct {
+	ID string
+
+	Request mackerel.AlertGroupSetting
+}
+
+func (ct *alertGroupSettingCreatorTester) CreateAlertGroupSetting(param *mackerel.AlertGroupSetting) (*mackerel.AlertGroupSetting, error) {
+	ct.Request = *param
+	data := *param
+	data.ID = ct.ID
+	return &data, nil
+}
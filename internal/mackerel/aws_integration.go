@@ -0,0 +1,357 @@
+package mackerel
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio/mackerel-client-go"
+)
+
+// AWSIntegrationServiceKeys maps the names of AWS services in the configuration to the keys used in the API.
+var AWSIntegrationServiceKeys = map[string]string{
+	"ec2":         "EC2",
+	"elb":         "ELB",
+	"alb":         "ALB",
+	"nlb":         "NLB",
+	"rds":         "RDS",
+	"redshift":    "Redshift",
+	"elasticache": "ElastiCache",
+	"sqs":         "SQS",
+	"lambda":      "Lambda",
+	"dynamodb":    "DynamoDB",
+	"cloudfront":  "CloudFront",
+	"api_gateway": "APIGateway",
+	"kinesis":     "Kinesis",
+	"s3":          "S3",
+	"es":          "ES",
+	"ecs_cluster": "ECSCluster",
+	"ses":         "SES",
+	"states":      "States",
+	"efs":         "EFS",
+	"firehose":    "Firehose",
+	"batch":       "Batch",
+	"waf":         "WAF",
+	"billing":     "Billing",
+	"route53":     "Route53",
+	"connect":     "Connect",
+	"docdb":       "DocDB",
+	"codebuild":   "CodeBuild",
+}
+
+var awsIntegrationRetireAutomaticallySupported = map[string]bool{
+	"ec2":         true,
+	"rds":         true,
+	"elasticache": true,
+}
+
+type AWSIntegrationModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Memo         types.String `tfsdk:"memo"`
+	Key          types.String `tfsdk:"key"`
+	SecretKey    types.String `tfsdk:"secret_key"`
+	RoleArn      types.String `tfsdk:"role_arn"`
+	ExternalID   types.String `tfsdk:"external_id"`
+	Region       types.String `tfsdk:"region"`
+	IncludedTags types.String `tfsdk:"included_tags"`
+	ExcludedTags types.String `tfsdk:"excluded_tags"`
+
+	// The service settings keyed by the names in AWSIntegrationServiceKeys.
+	Services map[string]AWSIntegrationServiceModel `tfsdk:"services"`
+}
+
+// RetireAutomatically is always false for the services which do not support it.
+type AWSIntegrationServiceModel struct {
+	Enable              types.Bool     `tfsdk:"enable"`
+	Role                types.String   `tfsdk:"role"`
+	ExcludedMetrics     []types.String `tfsdk:"excluded_metrics"`
+	RetireAutomatically types.Bool     `tfsdk:"retire_automatically"`
+}
+
+// AWSIntegrationSupportsRetireAutomatically reports whether the service can retire hosts automatically.
+func AWSIntegrationSupportsRetireAutomatically(service string) bool {
+	return awsIntegrationRetireAutomaticallySupported[service]
+}
+
+// AWSIntegrationServiceNames returns the sorted names of AWS services in the configuration.
+func AWSIntegrationServiceNames() []string {
+	names := make([]string, 0, len(AWSIntegrationServiceKeys))
+	for name := range AWSIntegrationServiceKeys {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Reads an AWS integration by `id`
+func ReadAWSIntegration(ctx context.Context, client *Client, id string) (AWSIntegrationModel, error) {
+	return readAWSIntegrationInner(ctx, WithContext(ctx, client), id)
+}
+
+type awsIntegrationFinder interface {
+	FindAWSIntegration(string) (*mackerel.AWSIntegration, error)
+}
+
+func readAWSIntegrationInner(_ context.Context, client awsIntegrationFinder, id string) (AWSIntegrationModel, error) {
+	aws, err := client.FindAWSIntegration(id)
+	if err != nil {
+		return AWSIntegrationModel{}, err
+	}
+	return newAWSIntegrationModel(*aws), nil
+}
+
+// Creates an AWS integration
+func (m *AWSIntegrationModel) Create(ctx context.Context, client *Client) error {
+	return m.createInner(ctx, WithContext(ctx, client))
+}
+
+type awsIntegrationCreator interface {
+	CreateAWSIntegration(*mackerel.CreateAWSIntegrationParam) (*mackerel.AWSIntegration, error)
+}
+
+func (m *AWSIntegrationModel) createInner(_ context.Context, client awsIntegrationCreator) error {
+	param := m.mackerelAWSIntegrationParam()
+	aws, err := client.CreateAWSIntegration(&param)
+	if err != nil {
+		return err
+	}
+
+	m.ID = types.StringValue(aws.ID)
+	return nil
+}
+
+// Reads the AWS integration
+func (m *AWSIntegrationModel) Read(ctx context.Context, client *Client) error {
+	data, err := ReadAWSIntegration(ctx, client, m.ID.ValueString())
+	if err != nil {
+		return err
+	}
+	m.merge(data)
+	return nil
+}
+
+// Merges the AWS integration read from the API into the state.
+func (m *AWSIntegrationModel) merge(data AWSIntegrationModel) {
+	// the API never returns the secret key
+	data.SecretKey = m.SecretKey
+
+	for name, prior := range m.Services {
+		service, ok := data.Services[name]
+		if !ok {
+			// disabled services are not sent to the API
+			if !prior.Enable.ValueBool() {
+				data.Services[name] = prior
+			}
+			continue
+		}
+		service.merge(prior)
+		data.Services[name] = service
+	}
+
+	*m = data
+}
+
+func (s *AWSIntegrationServiceModel) merge(prior AWSIntegrationServiceModel) {
+	// keep the role as written if it differs only in spaces, e.g. "service: role"
+	if strings.ReplaceAll(prior.Role.ValueString(), " ", "") == strings.ReplaceAll(s.Role.ValueString(), " ", "") &&
+		prior.Role.IsNull() == s.Role.IsNull() {
+		s.Role = prior.Role
+	}
+
+	// keep the order of excluded metrics as written
+	if sameStringValues(prior.ExcludedMetrics, s.ExcludedMetrics) {
+		s.ExcludedMetrics = prior.ExcludedMetrics
+	}
+}
+
+// Updates the AWS integration
+func (m *AWSIntegrationModel) Update(ctx context.Context, client *Client) error {
+	param := mackerel.UpdateAWSIntegrationParam(m.mackerelAWSIntegrationParam())
+	if _, err := WithContext(ctx, client).UpdateAWSIntegration(m.ID.ValueString(), &param); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Deletes the AWS integration
+func (m *AWSIntegrationModel) Delete(ctx context.Context, client *Client) error {
+	if _, err := WithContext(ctx, client).DeleteAWSIntegration(m.ID.ValueString()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// API -> Model
+// Disabled services are dropped as the SDK resource does.
+func newAWSIntegrationModel(aws mackerel.AWSIntegration) AWSIntegrationModel {
+	data := AWSIntegrationModel{
+		ID:           types.StringValue(aws.ID),
+		Name:         types.StringValue(aws.Name),
+		Memo:         types.StringValue(aws.Memo),
+		Key:          types.StringValue(aws.Key),
+		SecretKey:    types.StringValue(""),
+		RoleArn:      types.StringValue(aws.RoleArn),
+		ExternalID:   types.StringValue(aws.ExternalID),
+		Region:       types.StringValue(aws.Region),
+		IncludedTags: types.StringValue(aws.IncludedTags),
+		ExcludedTags: types.StringValue(aws.ExcludedTags),
+		Services:     make(map[string]AWSIntegrationServiceModel),
+	}
+
+	for name, key := range AWSIntegrationServiceKeys {
+		s, ok := aws.Services[key]
+		if !ok || s == nil || !s.Enable {
+			continue
+		}
+
+		role := types.StringNull()
+		if s.Role != nil && *s.Role != "" {
+			role = types.StringValue(*s.Role)
+		}
+		excludedMetrics := make([]types.String, 0, len(s.ExcludedMetrics))
+		for _, metric := range s.ExcludedMetrics {
+			excludedMetrics = append(excludedMetrics, types.StringValue(metric))
+		}
+		data.Services[name] = AWSIntegrationServiceModel{
+			Enable:              types.BoolValue(s.Enable),
+			Role:                role,
+			ExcludedMetrics:     excludedMetrics,
+			RetireAutomatically: types.BoolValue(AWSIntegrationSupportsRetireAutomatically(name) && s.RetireAutomatically),
+		}
+	}
+
+	return data
+}
+
+// Model -> API
+func (m AWSIntegrationModel) mackerelAWSIntegrationParam() mackerel.CreateAWSIntegrationParam {
+	param := mackerel.CreateAWSIntegrationParam{
+		Name:         m.Name.ValueString(),
+		Memo:         m.Memo.ValueString(),
+		Key:          m.Key.ValueString(),
+		SecretKey:    m.SecretKey.ValueString(),
+		RoleArn:      m.RoleArn.ValueString(),
+		ExternalID:   m.ExternalID.ValueString(),
+		Region:       m.Region.ValueString(),
+		IncludedTags: m.IncludedTags.ValueString(),
+		ExcludedTags: m.ExcludedTags.ValueString(),
+		Services:     make(map[string]*mackerel.AWSIntegrationService),
+	}
+
+	for name, s := range m.Services {
+		if !s.Enable.ValueBool() {
+			continue
+		}
+		param.Services[AWSIntegrationServiceKeys[name]] = &mackerel.AWSIntegrationService{
+			Enable:              true,
+			Role:                s.Role.ValueStringPointer(),
+			ExcludedMetrics:     stringsFromValues(s.ExcludedMetrics),
+			RetireAutomatically: AWSIntegrationSupportsRetireAutomatically(name) && s.RetireAutomatically.ValueBool(),
+		}
+	}
+
+	return param
+}
+
+// AWSIntegrationModelV0 is the state of the AWS integration resource before nested attributes,
+// where each service is a top-level block of at most one element to be compatible with the SDK resource.
+// The blocks cannot be mapped into a map, so the services are read by their paths.
+type AWSIntegrationModelV0 struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Memo         types.String `tfsdk:"memo"`
+	Key          types.String `tfsdk:"key"`
+	SecretKey    types.String `tfsdk:"secret_key"`
+	RoleArn      types.String `tfsdk:"role_arn"`
+	ExternalID   types.String `tfsdk:"external_id"`
+	Region       types.String `tfsdk:"region"`
+	IncludedTags types.String `tfsdk:"included_tags"`
+	ExcludedTags types.String `tfsdk:"excluded_tags"`
+
+	Services map[string][]AWSIntegrationServiceModel `tfsdk:"-"`
+}
+
+// Upgrade converts the state of version 0.
+func (m AWSIntegrationModelV0) Upgrade() AWSIntegrationModel {
+	data := AWSIntegrationModel{
+		ID:           m.ID,
+		Name:         m.Name,
+		Memo:         m.Memo,
+		Key:          m.Key,
+		SecretKey:    m.SecretKey,
+		RoleArn:      m.RoleArn,
+		ExternalID:   m.ExternalID,
+		Region:       m.Region,
+		IncludedTags: m.IncludedTags,
+		ExcludedTags: m.ExcludedTags,
+		Services:     make(map[string]AWSIntegrationServiceModel),
+	}
+	for name, services := range m.Services {
+		s := first(services)
+		if s == nil {
+			continue
+		}
+		service := *s
+		// the SDK resource stores an empty role when no role is set
+		if service.Role.ValueString() == "" {
+			service.Role = types.StringNull()
+		}
+		if service.ExcludedMetrics == nil {
+			service.ExcludedMetrics = []types.String{}
+		}
+		// the SDK resource has no retire_automatically for the unsupported services
+		if service.RetireAutomatically.IsNull() {
+			service.RetireAutomatically = types.BoolValue(false)
+		}
+		data.Services[name] = service
+	}
+	return data
+}
+
+func sameStringValues(a, b []types.String) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	as, bs := stringsFromValues(a), stringsFromValues(b)
+	slices.Sort(as)
+	slices.Sort(bs)
+	return slices.Equal(as, bs)
+}
+
+// Rejects retire_automatically = true of the services which do not support retiring hosts automatically.
+// The service is the key of the services map in the path.
+func AWSIntegrationRetireAutomaticallyValidator() validator.Bool {
+	return awsIntegrationRetireAutomaticallyValidator{}
+}
+
+type awsIntegrationRetireAutomaticallyValidator struct{}
+
+func (v awsIntegrationRetireAutomaticallyValidator) Description(context.Context) string {
+	return "retire_automatically is only supported by ec2, rds and elasticache"
+}
+
+func (v awsIntegrationRetireAutomaticallyValidator) MarkdownDescription(ctx context.Context) string {
+	return "`retire_automatically` is only supported by `ec2`, `rds` and `elasticache`"
+}
+
+func (v awsIntegrationRetireAutomaticallyValidator) ValidateBool(ctx context.Context, req validator.BoolRequest, resp *validator.BoolResponse) {
+	// false is harmless, e.g. when it comes from a variable shared by the services
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || !req.ConfigValue.ValueBool() {
+		return
+	}
+	step, _ := req.Path.ParentPath().Steps().LastStep()
+	service, ok := step.(path.PathStepElementKeyString)
+	if !ok || AWSIntegrationSupportsRetireAutomatically(string(service)) {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Unsupported Attribute",
+		fmt.Sprintf("%s cannot retire hosts automatically: %s.", AWSIntegrationServiceKeys[string(service)], v.Description(ctx)),
+	)
+}
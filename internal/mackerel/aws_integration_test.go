@@ -0,0 +1,334 @@
+package mackerel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio/mackerel-client-go"
+)
+
+// Returns a model without any services
+func emptyAWSIntegrationModel(id, name string) AWSIntegrationModel {
+	m := AWSIntegrationModel{
+		ID:           types.StringValue(id),
+		Name:         types.StringValue(name),
+		Memo:         types.StringValue(""),
+		Key:          types.StringValue(""),
+		SecretKey:    types.StringValue(""),
+		RoleArn:      types.StringValue("arn:aws:iam::123456789012:role/mackerel"),
+		ExternalID:   types.StringValue("external-id"),
+		Region:       types.StringValue("ap-northeast-1"),
+		IncludedTags: types.StringValue(""),
+		ExcludedTags: types.StringValue(""),
+		Services:     make(map[string]AWSIntegrationServiceModel),
+	}
+	return m
+}
+
+func Test_AWSIntegration_Read(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		inID     string
+		inClient awsIntegrationFinderFunc
+
+		wantErr bool
+		wants   AWSIntegrationModel
+	}{
+		"services": {
+			inID: "aws0",
+			inClient: func(id string) (*mackerel.AWSIntegration, error) {
+				return &mackerel.AWSIntegration{
+					ID:         id,
+					Name:       "aws",
+					RoleArn:    "arn:aws:iam::123456789012:role/mackerel",
+					ExternalID: "external-id",
+					Region:     "ap-northeast-1",
+					Services: map[string]*mackerel.AWSIntegrationService{
+						"EC2": {
+							Enable:              true,
+							Role:                ptr("service:role"),
+							ExcludedMetrics:     []string{},
+							RetireAutomatically: true,
+						},
+						"ALB": {
+							Enable:          true,
+							ExcludedMetrics: []string{"alb.request.count"},
+						},
+						"NLB": {
+							Enable: false,
+						},
+					},
+				}, nil
+			},
+
+			wants: func() AWSIntegrationModel {
+				m := emptyAWSIntegrationModel("aws0", "aws")
+				m.Services["ec2"] = AWSIntegrationServiceModel{
+					Enable:              types.BoolValue(true),
+					Role:                types.StringValue("service:role"),
+					ExcludedMetrics:     []types.String{},
+					RetireAutomatically: types.BoolValue(true),
+				}
+				m.Services["alb"] = AWSIntegrationServiceModel{
+					Enable:              types.BoolValue(true),
+					Role:                types.StringNull(),
+					ExcludedMetrics:     []types.String{types.StringValue("alb.request.count")},
+					RetireAutomatically: types.BoolValue(false),
+				}
+				return m
+			}(),
+		},
+		"not found": {
+			inID: "aws1",
+			inClient: func(string) (*mackerel.AWSIntegration, error) {
+				return nil, &APIError{StatusCode: 404}
+			},
+
+			wantErr: true,
+		},
+	}
+
+	ctx := context.Background()
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := readAWSIntegrationInner(ctx, tt.inClient, tt.inID)
+			if (err != nil) != tt.wantErr {
+				if tt.wantErr {
+					t.Errorf("expect error, but got no error")
+				} else {
+					t.Errorf("unexpected error: %+v", err)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(m, tt.wants); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+type awsIntegrationFinderFunc func(string) (*mackerel.AWSIntegration, error)
+
+func (f awsIntegrationFinderFunc) FindAWSIntegration(id string) (*mackerel.AWSIntegration, error) {
+	return f(id)
+}
+
+func Test_AWSIntegration_merge(t *testing.T) {
+	t.Parallel()
+
+	state := emptyAWSIntegrationModel("aws0", "aws")
+	state.SecretKey = types.StringValue("secret")
+	state.Services["alb"] = AWSIntegrationServiceModel{
+		Enable:              types.BoolValue(true),
+		Role:                types.StringValue("service: role"),
+		ExcludedMetrics:     []types.String{types.StringValue("b"), types.StringValue("a")},
+		RetireAutomatically: types.BoolValue(false),
+	}
+	state.Services["rds"] = AWSIntegrationServiceModel{
+		Enable:              types.BoolValue(true),
+		Role:                types.StringNull(),
+		ExcludedMetrics:     []types.String{types.StringValue("x")},
+		RetireAutomatically: types.BoolValue(false),
+	}
+	state.Services["nlb"] = AWSIntegrationServiceModel{
+		Enable:              types.BoolValue(false),
+		Role:                types.StringNull(),
+		ExcludedMetrics:     []types.String{},
+		RetireAutomatically: types.BoolValue(false),
+	}
+
+	data := emptyAWSIntegrationModel("aws0", "aws")
+	data.Services["alb"] = AWSIntegrationServiceModel{
+		Enable:              types.BoolValue(true),
+		Role:                types.StringValue("service:role"),
+		ExcludedMetrics:     []types.String{types.StringValue("a"), types.StringValue("b")},
+		RetireAutomatically: types.BoolValue(false),
+	}
+	data.Services["rds"] = AWSIntegrationServiceModel{
+		Enable:              types.BoolValue(true),
+		Role:                types.StringNull(),
+		ExcludedMetrics:     []types.String{types.StringValue("y")},
+		RetireAutomatically: types.BoolValue(false),
+	}
+
+	wants := emptyAWSIntegrationModel("aws0", "aws")
+	wants.SecretKey = types.StringValue("secret")
+	wants.Services["alb"] = state.Services["alb"]
+	wants.Services["rds"] = data.Services["rds"]
+	wants.Services["nlb"] = state.Services["nlb"]
+
+	state.merge(data)
+	if diff := cmp.Diff(state, wants); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func Test_AWSIntegration_Create(t *testing.T) {
+	t.Parallel()
+
+	in := emptyAWSIntegrationModel("", "aws")
+	in.ID = types.StringUnknown()
+	in.Services["ec2"] = AWSIntegrationServiceModel{
+		Enable:              types.BoolValue(true),
+		Role:                types.StringNull(),
+		ExcludedMetrics:     []types.String{types.StringValue("ec2.cpu.used")},
+		RetireAutomatically: types.BoolValue(true),
+	}
+	in.Services["sqs"] = AWSIntegrationServiceModel{
+		Enable:              types.BoolValue(true),
+		Role:                types.StringValue("service:role"),
+		ExcludedMetrics:     []types.String{},
+		RetireAutomatically: types.BoolValue(false),
+	}
+	in.Services["nlb"] = AWSIntegrationServiceModel{
+		Enable:              types.BoolValue(false),
+		Role:                types.StringNull(),
+		ExcludedMetrics:     []types.String{},
+		RetireAutomatically: types.BoolValue(false),
+	}
+	wantReq := mackerel.CreateAWSIntegrationParam{
+		Name:       "aws",
+		RoleArn:    "arn:aws:iam::123456789012:role/mackerel",
+		ExternalID: "external-id",
+		Region:     "ap-northeast-1",
+		Services: map[string]*mackerel.AWSIntegrationService{
+			"EC2": {
+				Enable:              true,
+				ExcludedMetrics:     []string{"ec2.cpu.used"},
+				RetireAutomatically: true,
+			},
+			"SQS": {
+				Enable:          true,
+				Role:            ptr("service:role"),
+				ExcludedMetrics: []string{},
+			},
+		},
+	}
+
+	client := &awsIntegrationCreatorTester{ID: "aws0"}
+	if err := in.createInner(context.Background(), client); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if diff := cmp.Diff(client.Request, wantReq); diff != "" {
+		t.Errorf("invalid request:\n%s", diff)
+	}
+	if in.ID.ValueString() != "aws0" {
+		t.Errorf("expected ID to be aws0, but got %s", in.ID)
+	}
+}
+
+type awsIntegrationCreatorTester struct {
+	ID string
+
+	Request mackerel.CreateAWSIntegrationParam
+}
+
+func (ct *awsIntegrationCreatorTester) CreateAWSIntegration(param *mackerel.CreateAWSIntegrationParam) (*mackerel.AWSIntegration, error) {
+	ct.Request = *param
+	return &mackerel.AWSIntegration{ID: ct.ID}, nil
+}
+
+func Test_AWSIntegrationModelV0_Upgrade(t *testing.T) {
+	t.Parallel()
+
+	in := AWSIntegrationModelV0{
+		ID:           types.StringValue("aws0"),
+		Name:         types.StringValue("aws"),
+		Memo:         types.StringValue(""),
+		Key:          types.StringValue(""),
+		SecretKey:    types.StringValue(""),
+		RoleArn:      types.StringValue("arn:aws:iam::123456789012:role/mackerel"),
+		ExternalID:   types.StringValue("external-id"),
+		Region:       types.StringValue("ap-northeast-1"),
+		IncludedTags: types.StringValue(""),
+		ExcludedTags: types.StringValue(""),
+		Services: map[string][]AWSIntegrationServiceModel{
+			"ec2": {{
+				Enable:              types.BoolValue(true),
+				Role:                types.StringValue("service: role"),
+				ExcludedMetrics:     []types.String{},
+				RetireAutomatically: types.BoolValue(true),
+			}},
+			// the SDK resource has no retire_automatically for alb
+			"alb": {{
+				Enable:              types.BoolValue(true),
+				Role:                types.StringValue(""),
+				ExcludedMetrics:     nil,
+				RetireAutomatically: types.BoolNull(),
+			}},
+			"nlb": {},
+			"rds": nil,
+		},
+	}
+
+	wants := emptyAWSIntegrationModel("aws0", "aws")
+	wants.Services["ec2"] = in.Services["ec2"][0]
+	wants.Services["alb"] = AWSIntegrationServiceModel{
+		Enable:              types.BoolValue(true),
+		Role:                types.StringNull(),
+		ExcludedMetrics:     []types.String{},
+		RetireAutomatically: types.BoolValue(false),
+	}
+
+	if diff := cmp.Diff(in.Upgrade(), wants); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func Test_AWSIntegrationRetireAutomaticallyValidator(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		inService string
+		inVal     types.Bool
+		wantErr   bool
+	}{
+		"supported": {
+			inService: "ec2",
+			inVal:     types.BoolValue(true),
+		},
+		"unsupported": {
+			inService: "alb",
+			inVal:     types.BoolValue(true),
+			wantErr:   true,
+		},
+		"unsupported but false": {
+			inService: "alb",
+			inVal:     types.BoolValue(false),
+		},
+		"unsupported but unknown": {
+			inService: "alb",
+			inVal:     types.BoolUnknown(),
+		},
+		"unsupported but null": {
+			inService: "alb",
+			inVal:     types.BoolNull(),
+		},
+	}
+
+	ctx := context.Background()
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.BoolRequest{
+				Path:           path.Root("services").AtMapKey(tt.inService).AtName("retire_automatically"),
+				PathExpression: path.MatchRoot("services").AtAnyMapKey().AtName("retire_automatically"),
+				ConfigValue:    tt.inVal,
+			}
+			resp := validator.BoolResponse{}
+			AWSIntegrationRetireAutomaticallyValidator().ValidateBool(ctx, req, &resp)
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Errorf("unexpected diagnostics: %+v", resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package mackerel
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio/mackerel-client-go"
+)
+
+// ChannelTypes are the names of the attributes of channel kinds, exactly one of which is set.
+var ChannelTypes = []string{
+	"email",
+	"slack",
+	"webhook",
+}
+
+// At most one of the channel kinds is non-nil.
+type ChannelModel struct {
+	ID      types.String         `tfsdk:"id"`
+	Name    types.String         `tfsdk:"name"`
+	Email   *ChannelEmailModel   `tfsdk:"email"`
+	Slack   *ChannelSlackModel   `tfsdk:"slack"`
+	Webhook *ChannelWebhookModel `tfsdk:"webhook"`
+}
+
+type ChannelEmailModel struct {
+	Emails  []types.String `tfsdk:"emails"`
+	UserIDs []types.String `tfsdk:"user_ids"`
+	Events  []types.String `tfsdk:"events"`
+}
+
+type ChannelSlackModel struct {
+	URL               types.String            `tfsdk:"url"`
+	Mentions          map[string]types.String `tfsdk:"mentions"`
+	EnabledGraphImage types.Bool              `tfsdk:"enabled_graph_image"`
+	Events            []types.String          `tfsdk:"events"`
+}
+
+type ChannelWebhookModel struct {
+	URL    types.String   `tfsdk:"url"`
+	Events []types.String `tfsdk:"events"`
+}
+
+func ChannelEmailEventValidator() validator.String {
+	return stringvalidator.OneOf("alert", "alertGroup")
+}
+
+func ChannelEventValidator() validator.String {
+	return stringvalidator.OneOf("alert", "alertGroup", "hostStatus", "hostRegister", "hostRetire", "monitor")
+}
+
+func ChannelMentionValidator() validator.String {
+	return stringvalidator.OneOf("ok", "warning", "critical")
+}
+
+// Reads a channel by `id`
+func ReadChannel(ctx context.Context, client *Client, id string) (ChannelModel, error) {
+	return readChannelInner(ctx, WithContext(ctx, client), id)
+}
+
+type channelFinder interface {
+	FindChannels() ([]*mackerel.Channel, error)
+}
+
+func readChannelInner(_ context.Context, client channelFinder, id string) (ChannelModel, error) {
+	channels, err := client.FindChannels()
+	if err != nil {
+		return ChannelModel{}, err
+	}
+
+	channelIdx := slices.IndexFunc(channels, func(c *mackerel.Channel) bool {
+		return c.ID == id
+	})
+	if channelIdx < 0 {
+		return ChannelModel{}, newNotFoundError("the ID '%s' does not match any channel in mackerel.io", id)
+	}
+
+	return newChannelModel(*channels[channelIdx]), nil
+}
+
+// Creates a channel
+func (m *ChannelModel) Create(ctx context.Context, client *Client) error {
+	return m.createInner(ctx, WithContext(ctx, client))
+}
+
+type channelCreator interface {
+	CreateChannel(*mackerel.Channel) (*mackerel.Channel, error)
+}
+
+func (m *ChannelModel) createInner(_ context.Context, client channelCreator) error {
+	param, err := m.mackerelChannel()
+	if err != nil {
+		return err
+	}
+	channel, err := client.CreateChannel(&param)
+	if err != nil {
+		return err
+	}
+
+	m.ID = types.StringValue(channel.ID)
+	return nil
+}
+
+// Reads the channel
+func (m *ChannelModel) Read(ctx context.Context, client *Client) error {
+	data, err := ReadChannel(ctx, client, m.ID.ValueString())
+	if err != nil {
+		return err
+	}
+	*m = data
+	return nil
+}
+
+// Deletes the channel
+// There is no Update since the API cannot update channels.
+func (m *ChannelModel) Delete(ctx context.Context, client *Client) error {
+	if _, err := WithContext(ctx, client).DeleteChannel(m.ID.ValueString()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// API -> Model
+// Channels of the other types (e.g. line, chatwork) have none of the channel kinds.
+func newChannelModel(channel mackerel.Channel) ChannelModel {
+	data := ChannelModel{
+		ID:   types.StringValue(channel.ID),
+		Name: types.StringValue(channel.Name),
+	}
+
+	switch channel.Type {
+	case "email":
+		data.Email = &ChannelEmailModel{
+			Emails:  valuesFromStringsPointer(channel.Emails),
+			UserIDs: valuesFromStringsPointer(channel.UserIDs),
+			Events:  valuesFromStringsPointer(channel.Events),
+		}
+	case "slack":
+		mentions := make(map[string]types.String, 3)
+		for k, v := range map[string]string{
+			"ok":       channel.Mentions.OK,
+			"warning":  channel.Mentions.Warning,
+			"critical": channel.Mentions.Critical,
+		} {
+			if v != "" {
+				mentions[k] = types.StringValue(v)
+			}
+		}
+		enabledGraphImage := false
+		if channel.EnabledGraphImage != nil {
+			enabledGraphImage = *channel.EnabledGraphImage
+		}
+		data.Slack = &ChannelSlackModel{
+			URL:               types.StringValue(channel.URL),
+			Mentions:          mentions,
+			EnabledGraphImage: types.BoolValue(enabledGraphImage),
+			Events:            valuesFromStringsPointer(channel.Events),
+		}
+	case "webhook":
+		data.Webhook = &ChannelWebhookModel{
+			URL:    types.StringValue(channel.URL),
+			Events: valuesFromStringsPointer(channel.Events),
+		}
+	}
+
+	return data
+}
+
+// Model -> API
+func (m ChannelModel) mackerelChannel() (mackerel.Channel, error) {
+	channel := mackerel.Channel{
+		ID:   m.ID.ValueString(),
+		Name: m.Name.ValueString(),
+	}
+
+	switch {
+	case m.Email != nil:
+		email := m.Email
+		emails := stringsFromValues(email.Emails)
+		userIDs := stringsFromValues(email.UserIDs)
+		events := stringsFromValues(email.Events)
+
+		channel.Type = "email"
+		channel.Emails = &emails
+		channel.UserIDs = &userIDs
+		channel.Events = &events
+	case m.Slack != nil:
+		slack := m.Slack
+		enabledGraphImage := slack.EnabledGraphImage.ValueBool()
+		events := stringsFromValues(slack.Events)
+
+		channel.Type = "slack"
+		channel.URL = slack.URL.ValueString()
+		channel.Mentions = mackerel.Mentions{
+			OK:       slack.Mentions["ok"].ValueString(),
+			Warning:  slack.Mentions["warning"].ValueString(),
+			Critical: slack.Mentions["critical"].ValueString(),
+		}
+		channel.EnabledGraphImage = &enabledGraphImage
+		channel.Events = &events
+	case m.Webhook != nil:
+		webhook := m.Webhook
+		events := stringsFromValues(webhook.Events)
+
+		channel.Type = "webhook"
+		channel.URL = webhook.URL.ValueString()
+		channel.Events = &events
+	default:
+		return mackerel.Channel{}, fmt.Errorf("exactly one of %s must be specified", strings.Join(ChannelTypes, ", "))
+	}
+
+	return channel, nil
+}
+
+// ChannelModelV0 is the state of the channel resource before nested attributes,
+// where each channel kind is a list of at most one element to be compatible with the SDK resource.
+type ChannelModelV0 struct {
+	ID      types.String          `tfsdk:"id"`
+	Name    types.String          `tfsdk:"name"`
+	Email   []ChannelEmailModel   `tfsdk:"email"`
+	Slack   []ChannelSlackModel   `tfsdk:"slack"`
+	Webhook []ChannelWebhookModel `tfsdk:"webhook"`
+}
+
+// Upgrade converts the state of version 0.
+func (m ChannelModelV0) Upgrade() ChannelModel {
+	return ChannelModel{
+		ID:      m.ID,
+		Name:    m.Name,
+		Email:   first(m.Email),
+		Slack:   first(m.Slack),
+		Webhook: first(m.Webhook),
+	}
+}
+
+func valuesFromStringsPointer(ss *[]string) []types.String {
+	if ss == nil {
+		return []types.String{}
+	}
+	values := make([]types.String, 0, len(*ss))
+	for _, s := range *ss {
+		values = append(values, types.StringValue(s))
+	}
+	return values
+}
@@ -0,0 +1,275 @@
+package mackerel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio/mackerel-client-go"
+)
+
+func Test_Channel_Read(t *testing.T) {
+	t.Parallel()
+
+	channels := []*mackerel.Channel{
+		{
+			ID:      "c0",
+			Name:    "email",
+			Type:    "email",
+			Emails:  &[]string{"alice@example.com"},
+			UserIDs: &[]string{"u0"},
+			Events:  &[]string{"alert"},
+		},
+		{
+			ID:   "c1",
+			Name: "slack",
+			Type: "slack",
+			URL:  "https://hooks.slack.com/services/xxx",
+			Mentions: mackerel.Mentions{
+				Critical: "<!channel>",
+			},
+			EnabledGraphImage: ptr(true),
+			Events:            &[]string{"alert", "hostStatus"},
+		},
+		{
+			ID:     "c2",
+			Name:   "webhook",
+			Type:   "webhook",
+			URL:    "https://example.com/webhook",
+			Events: &[]string{},
+		},
+		{
+			ID:   "c3",
+			Name: "line",
+			Type: "line",
+		},
+	}
+
+	cases := map[string]struct {
+		inID string
+
+		wantErr bool
+		wants   ChannelModel
+	}{
+		"email": {
+			inID: "c0",
+
+			wants: ChannelModel{
+				ID:   types.StringValue("c0"),
+				Name: types.StringValue("email"),
+				Email: &ChannelEmailModel{
+					Emails:  []types.String{types.StringValue("alice@example.com")},
+					UserIDs: []types.String{types.StringValue("u0")},
+					Events:  []types.String{types.StringValue("alert")},
+				},
+			},
+		},
+		"slack": {
+			inID: "c1",
+
+			wants: ChannelModel{
+				ID:   types.StringValue("c1"),
+				Name: types.StringValue("slack"),
+				Slack: &ChannelSlackModel{
+					URL:               types.StringValue("https://hooks.slack.com/services/xxx"),
+					Mentions:          map[string]types.String{"critical": types.StringValue("<!channel>")},
+					EnabledGraphImage: types.BoolValue(true),
+					Events:            []types.String{types.StringValue("alert"), types.StringValue("hostStatus")},
+				},
+			},
+		},
+		"webhook": {
+			inID: "c2",
+
+			wants: ChannelModel{
+				ID:   types.StringValue("c2"),
+				Name: types.StringValue("webhook"),
+				Webhook: &ChannelWebhookModel{
+					URL:    types.StringValue("https://example.com/webhook"),
+					Events: []types.String{},
+				},
+			},
+		},
+		"unsupported type": {
+			inID: "c3",
+
+			wants: ChannelModel{
+				ID:   types.StringValue("c3"),
+				Name: types.StringValue("line"),
+			},
+		},
+		"missing": {
+			inID: "c4",
+
+			wantErr: true,
+		},
+	}
+
+	ctx := context.Background()
+	client := channelFinderFunc(func() ([]*mackerel.Channel, error) {
+		return channels, nil
+	})
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c, err := readChannelInner(ctx, client, tt.inID)
+			if (err != nil) != tt.wantErr {
+				if tt.wantErr {
+					t.Errorf("expect error, but got no error")
+				} else {
+					t.Errorf("unexpected error: %+v", err)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(c, tt.wants); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+type channelFinderFunc func() ([]*mackerel.Channel, error)
+
+func (f channelFinderFunc) FindChannels() ([]*mackerel.Channel, error) {
+	return f()
+}
+
+func Test_Channel_Create(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		in   ChannelModel
+		inID string
+
+		wantErr bool
+		wantReq mackerel.Channel
+	}{
+		"slack": {
+			in: ChannelModel{
+				ID:   types.StringUnknown(),
+				Name: types.StringValue("slack"),
+				Slack: &ChannelSlackModel{
+					URL: types.StringValue("https://hooks.slack.com/services/xxx"),
+					Mentions: map[string]types.String{
+						"ok":      types.StringValue("ok"),
+						"warning": types.StringValue("warning"),
+					},
+					EnabledGraphImage: types.BoolValue(false),
+					Events:            []types.String{types.StringValue("alert")},
+				},
+			},
+			inID: "c0",
+
+			wantReq: mackerel.Channel{
+				Name: "slack",
+				Type: "slack",
+				URL:  "https://hooks.slack.com/services/xxx",
+				Mentions: mackerel.Mentions{
+					OK:      "ok",
+					Warning: "warning",
+				},
+				EnabledGraphImage: ptr(false),
+				Events:            &[]string{"alert"},
+			},
+		},
+		"email": {
+			in: ChannelModel{
+				ID:   types.StringUnknown(),
+				Name: types.StringValue("email"),
+				Email: &ChannelEmailModel{
+					Emails:  []types.String{types.StringValue("alice@example.com")},
+					UserIDs: []types.String{},
+					Events:  []types.String{},
+				},
+			},
+			inID: "c1",
+
+			wantReq: mackerel.Channel{
+				Name:    "email",
+				Type:    "email",
+				Emails:  &[]string{"alice@example.com"},
+				UserIDs: &[]string{},
+				Events:  &[]string{},
+			},
+		},
+		"no channel type": {
+			in: ChannelModel{
+				ID:   types.StringUnknown(),
+				Name: types.StringValue("empty"),
+			},
+
+			wantErr: true,
+		},
+	}
+
+	ctx := context.Background()
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := tt.in
+			client := &channelCreatorTester{ID: tt.inID}
+			err := c.createInner(ctx, client)
+			if (err != nil) != tt.wantErr {
+				if tt.wantErr {
+					t.Errorf("expect error, but got no error")
+				} else {
+					t.Errorf("unexpected error: %+v", err)
+				}
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if diff := cmp.Diff(client.Request, tt.wantReq); diff != "" {
+				t.Errorf("invalid request:\n%s", diff)
+			}
+			if c.ID.ValueString() != tt.inID {
+				t.Errorf("expected ID to be %s, but got %s", tt.inID, c.ID)
+			}
+		})
+	}
+}
+
+type channelCreatorTester struct {
+	ID string
+
+	Request mackerel.Channel
+}
+
+func (ct *channelCreatorTester) CreateChannel(param *mackerel.Channel) (*mackerel.Channel, error) {
+	ct.Request = *param
+	data := *param
+	data.ID = ct.ID
+	return &data, nil
+}
+
+func Test_ChannelModelV0_Upgrade(t *testing.T) {
+	t.Parallel()
+
+	webhook := ChannelWebhookModel{
+		URL:    types.StringValue("https://example.com/webhook"),
+		Events: []types.String{types.StringValue("alert")},
+	}
+	in := ChannelModelV0{
+		ID:      types.StringValue("c0"),
+		Name:    types.StringValue("webhook"),
+		Email:   []ChannelEmailModel{},
+		Slack:   []ChannelSlackModel{},
+		Webhook: []ChannelWebhookModel{webhook},
+	}
+
+	wants := ChannelModel{
+		ID:      types.StringValue("c0"),
+		Name:    types.StringValue("webhook"),
+		Webhook: &webhook,
+	}
+
+	if diff := cmp.Diff(in.Upgrade(), wants); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -1,26 +1,59 @@
 package mackerel
 
 import (
+	"context"
 	"errors"
-	"net/http"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/mackerelio/mackerel-client-go"
 )
 
 type Client = mackerel.Client
 
+// Default timeout of each create, read, update and delete operation of resources.
+const DefaultTimeout = 20 * time.Minute
+
 type ClientConfigModel struct {
-	APIKey  types.String `tfsdk:"api_key"`
-	APIBase types.String `tfsdk:"api_base"`
+	APIKey       types.String `tfsdk:"api_key"`
+	APIBase      types.String `tfsdk:"api_base"`
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryMaxWait types.Int64  `tfsdk:"retry_max_wait"`
+
+	RequestsPerMinute types.Int64 `tfsdk:"requests_per_minute"`
+
+	APIKeyFile types.String `tfsdk:"api_key_file"`
+	ConfigFile types.String `tfsdk:"config_file"`
+
+	CredentialProcess types.String `tfsdk:"credential_process"`
+
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	ClientCertFile     types.String `tfsdk:"client_cert_file"`
+	ClientKeyFile      types.String `tfsdk:"client_key_file"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+
+	ReadOnly types.Bool `tfsdk:"read_only"`
+
+	// Not a part of ClientConfig since it does not affect the client.
+	SkipCredentialsValidation types.Bool `tfsdk:"skip_credentials_validation"`
 }
 
 var (
 	ErrNoAPIKey = errors.New("API Key for Mackerel is not found.")
 )
 
+// ClientConfig is the provider configuration after resolution.
+type ClientConfig struct {
+	APIKey  string
+	APIBase string
+	// The command to obtain the API key, used only if APIKey is empty.
+	CredentialProcess string
+	Transport         TransportOptions
+}
+
 func NewClientConfigFromEnv() ClientConfigModel {
 	var data ClientConfigModel
 
@@ -42,25 +75,124 @@ func NewClientConfigFromEnv() ClientConfigModel {
 	return data
 }
 
+// Resolve fills the settings which are not set in the provider configuration.
+// Both the SDK and the framework providers use this, so that they always talk to the same organization.
+// Each setting is taken from the first source which sets it:
+//
+//  1. the provider configuration, where the API key is taken from api_key,
+//     credential_process, api_key_file and then config_file
+//  2. the environment variables (MACKEREL_APIKEY, MACKEREL_API_KEY, API_BASE and MACKEREL_READ_ONLY)
+//  3. the defaults
+//
+// Every source set in the provider configuration comes before the environment variables,
+// so that a stale environment variable never overrides what the configuration asks for.
+func (m ClientConfigModel) Resolve() (ClientConfig, error) {
+	env := NewClientConfigFromEnv()
+	files, err := m.clientConfigFromFiles()
+	if err != nil {
+		return ClientConfig{}, err
+	}
+
+	readOnly, err := m.readOnly()
+	if err != nil {
+		return ClientConfig{}, err
+	}
+
+	config := ClientConfig{
+		APIBase:   firstString(m.APIBase, files.APIBase, env.APIBase),
+		Transport: m.transportOptions(),
+	}
+	config.Transport.ReadOnly = readOnly
+	if apiKey := m.APIKey.ValueString(); apiKey != "" {
+		config.APIKey = apiKey
+	} else if command := m.CredentialProcess.ValueString(); command != "" {
+		config.CredentialProcess = command
+	} else if apiKey := firstString(files.APIKey, env.APIKey); apiKey != "" {
+		config.APIKey = apiKey
+	} else {
+		return ClientConfig{}, ErrNoAPIKey
+	}
+	return config, nil
+}
+
+// Returns the first non-empty value, treating null and unknown values as empty.
+func firstString(values ...types.String) string {
+	for _, v := range values {
+		if s := v.ValueString(); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// Creates a client with the resolved configuration.
 func (m *ClientConfigModel) NewClient() (*Client, error) {
-	apiKey := m.APIKey.ValueString()
-	if apiKey == "" {
+	config, err := m.Resolve()
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(config)
+}
+
+var clients sync.Map // map[ClientConfig]*Client
+
+// NewClient returns the client for the configuration.
+// The same instance is returned for the same configuration,
+// so the SDK and the framework providers share one client.
+func NewClient(config ClientConfig) (*Client, error) {
+	if config.APIKey == "" && config.CredentialProcess == "" {
 		return nil, ErrNoAPIKey
 	}
+	if c, ok := clients.Load(config); ok {
+		return c.(*Client), nil
+	}
 
-	apiBase := m.APIBase.ValueString()
+	apiKey := config.APIKey
+	opts := config.Transport
+	if apiKey == "" {
+		p := sharedCredentialProcess(config.CredentialProcess)
+		key, err := p.APIKey(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		apiKey = key
+		opts.credentials = p
+	}
 
 	var client *mackerel.Client
-	if apiBase == "" {
+	if config.APIBase == "" {
 		client = mackerel.NewClient(apiKey)
 	} else {
-		// TODO: use logging transport with tflog (FYI: https://github.com/hashicorp/terraform-plugin-log/issues/91)
-		c, err := mackerel.NewClientWithOptions(apiKey, apiBase, false)
+		c, err := mackerel.NewClientWithOptions(apiKey, config.APIBase, false)
 		if err != nil {
 			return nil, err
 		}
 		client = c
 	}
-	client.HTTPClient.Transport = logging.NewSubsystemLoggingHTTPTransport("Mackerel", http.DefaultTransport)
-	return client, nil
+	if err := SetupTransport(client, opts); err != nil {
+		return nil, err
+	}
+
+	c, _ := clients.LoadOrStore(config, client)
+	return c.(*Client), nil
+}
+
+func (m *ClientConfigModel) transportOptions() TransportOptions {
+	opts := TransportOptions{
+		MaxRetries:   DefaultMaxRetries,
+		RetryMaxWait: DefaultRetryMaxWait,
+	}
+	if !m.MaxRetries.IsNull() && !m.MaxRetries.IsUnknown() {
+		opts.MaxRetries = int(m.MaxRetries.ValueInt64())
+	}
+	if !m.RetryMaxWait.IsNull() && !m.RetryMaxWait.IsUnknown() {
+		opts.RetryMaxWait = time.Duration(m.RetryMaxWait.ValueInt64()) * time.Second
+	}
+	opts.RequestsPerMinute = int(m.RequestsPerMinute.ValueInt64())
+	opts.CACertFile = m.CACertFile.ValueString()
+	opts.ClientCertFile = m.ClientCertFile.ValueString()
+	opts.ClientKeyFile = m.ClientKeyFile.ValueString()
+	opts.ProxyURL = m.ProxyURL.ValueString()
+	opts.InsecureSkipVerify = m.InsecureSkipVerify.ValueBool()
+	return opts
 }
@@ -0,0 +1,64 @@
+package mackerel
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The subset of mackerel-agent.conf, which mkr also reads.
+type agentConfig struct {
+	APIKey  string `toml:"apikey"`
+	APIBase string `toml:"apibase"`
+}
+
+func (m ClientConfigModel) clientConfigFromFiles() (ClientConfigModel, error) {
+	var data ClientConfigModel
+
+	if path := m.APIKeyFile.ValueString(); path != "" {
+		apiKey, err := readAPIKeyFile(path)
+		if err != nil {
+			return data, err
+		}
+		data.APIKey = types.StringValue(apiKey)
+	}
+
+	if path := m.ConfigFile.ValueString(); path != "" {
+		conf, err := readAgentConfig(path)
+		if err != nil {
+			return data, err
+		}
+		if data.APIKey.ValueString() == "" && conf.APIKey != "" {
+			data.APIKey = types.StringValue(conf.APIKey)
+		}
+		if conf.APIBase != "" {
+			data.APIBase = types.StringValue(conf.APIBase)
+		}
+	}
+
+	return data, nil
+}
+
+// Reads the file which contains only the API key.
+func readAPIKeyFile(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read api_key_file: %w", err)
+	}
+	apiKey := strings.TrimSpace(string(b))
+	if apiKey == "" {
+		return "", fmt.Errorf("api_key_file '%s' is empty", path)
+	}
+	return apiKey, nil
+}
+
+func readAgentConfig(path string) (agentConfig, error) {
+	var conf agentConfig
+	if _, err := toml.DecodeFile(path, &conf); err != nil {
+		return conf, fmt.Errorf("failed to read config_file: %w", err)
+	}
+	return conf, nil
+}
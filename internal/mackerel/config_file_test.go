@@ -0,0 +1,134 @@
+package mackerel
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func Test_ClientConfigModel_Resolve_files(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	agentConf := write("mackerel-agent.conf", `
+apikey = "agent_key"
+apibase = "https://agent.example.com/"
+roles = ["service:role"]
+
+[plugin.metrics.sample]
+command = "echo"
+`)
+	keyFile := write("apikey", "file_key\n")
+	emptyFile := write("empty", "\n")
+	brokenConf := write("broken.conf", `apikey = `)
+	baseOnlyConf := write("base-only.conf", `apibase = "https://agent.example.com/"`)
+
+	cases := map[string]struct {
+		config ClientConfigModel
+		env    map[string]string
+
+		wantAPIKey            string
+		wantAPIBase           string
+		wantCredentialProcess string
+		wantErr               bool
+	}{
+		"config_file": {
+			config:      ClientConfigModel{ConfigFile: types.StringValue(agentConf)},
+			wantAPIKey:  "agent_key",
+			wantAPIBase: "https://agent.example.com/",
+		},
+		"api_key_file precedes config_file": {
+			config: ClientConfigModel{
+				APIKeyFile: types.StringValue(keyFile),
+				ConfigFile: types.StringValue(agentConf),
+			},
+			wantAPIKey:  "file_key",
+			wantAPIBase: "https://agent.example.com/",
+		},
+		"config_file precedes env": {
+			config: ClientConfigModel{ConfigFile: types.StringValue(agentConf)},
+			env: map[string]string{
+				"MACKEREL_APIKEY": "env_key",
+				"API_BASE":        "https://env.example.com/",
+			},
+			wantAPIKey:  "agent_key",
+			wantAPIBase: "https://agent.example.com/",
+		},
+		"api_key_file precedes env": {
+			config: ClientConfigModel{APIKeyFile: types.StringValue(keyFile)},
+			env: map[string]string{
+				"MACKEREL_APIKEY": "env_key",
+			},
+			wantAPIKey: "file_key",
+		},
+		"credential_process precedes env": {
+			config: ClientConfigModel{CredentialProcess: types.StringValue("echo")},
+			env: map[string]string{
+				"MACKEREL_APIKEY": "env_key",
+			},
+			wantCredentialProcess: "echo",
+		},
+		"env without key in files": {
+			config: ClientConfigModel{ConfigFile: types.StringValue(baseOnlyConf)},
+			env: map[string]string{
+				"MACKEREL_APIKEY": "env_key",
+				"API_BASE":        "https://env.example.com/",
+			},
+			wantAPIKey:  "env_key",
+			wantAPIBase: "https://agent.example.com/",
+		},
+		"config precedes files": {
+			config: ClientConfigModel{
+				APIKey:     types.StringValue("config_key"),
+				APIBase:    types.StringValue("https://config.example.com/"),
+				ConfigFile: types.StringValue(agentConf),
+			},
+			wantAPIKey:  "config_key",
+			wantAPIBase: "https://config.example.com/",
+		},
+		"missing file": {
+			config:  ClientConfigModel{ConfigFile: types.StringValue(filepath.Join(dir, "missing.conf"))},
+			wantErr: true,
+		},
+		"empty api_key_file": {
+			config:  ClientConfigModel{APIKeyFile: types.StringValue(emptyFile)},
+			wantErr: true,
+		},
+		"broken config_file": {
+			config:  ClientConfigModel{ConfigFile: types.StringValue(brokenConf)},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			for _, env := range []string{"MACKEREL_APIKEY", "MACKEREL_API_KEY", "API_BASE"} {
+				t.Setenv(env, tt.env[env])
+			}
+
+			got, err := tt.config.Resolve()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, but got: %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			if got.APIKey != tt.wantAPIKey || got.APIBase != tt.wantAPIBase {
+				t.Errorf("expected to be (%s, %s), but got (%s, %s)", tt.wantAPIKey, tt.wantAPIBase, got.APIKey, got.APIBase)
+			}
+			if got.CredentialProcess != tt.wantCredentialProcess {
+				t.Errorf("expected credential process to be '%s', but got '%s'", tt.wantCredentialProcess, got.CredentialProcess)
+			}
+		})
+	}
+}
@@ -3,7 +3,9 @@ package mackerel
 import (
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -48,10 +50,154 @@ func Test_ClientConfig_apiKeyCompat(t *testing.T) {
 }
 
 func Test_ClientConfig_noApiKey(t *testing.T) {
-	t.Parallel()
+	t.Setenv("MACKEREL_APIKEY", "")
+	t.Setenv("MACKEREL_API_KEY", "")
 
 	var config ClientConfigModel
 	if _, err := config.NewClient(); !errors.Is(err, ErrNoAPIKey) {
 		t.Errorf("expected to ErrNoAPIKey, but got: %v", err)
 	}
 }
+
+func Test_ClientConfigModel_Resolve(t *testing.T) {
+	cases := map[string]struct {
+		config  ClientConfigModel
+		env     map[string]string
+		want    ClientConfig
+		wantErr error
+	}{
+		"config": {
+			config: ClientConfigModel{
+				APIKey:       types.StringValue("config_key"),
+				APIBase:      types.StringValue("https://config.example.com/"),
+				MaxRetries:   types.Int64Value(0),
+				RetryMaxWait: types.Int64Value(5),
+
+				RequestsPerMinute: types.Int64Value(60),
+			},
+			env: map[string]string{
+				"MACKEREL_APIKEY": "env_key",
+				"API_BASE":        "https://env.example.com/",
+			},
+			want: ClientConfig{
+				APIKey:  "config_key",
+				APIBase: "https://config.example.com/",
+				Transport: TransportOptions{
+					MaxRetries:        0,
+					RetryMaxWait:      5 * time.Second,
+					RequestsPerMinute: 60,
+				},
+			},
+		},
+		"env": {
+			config: ClientConfigModel{
+				APIKey:  types.StringNull(),
+				APIBase: types.StringUnknown(),
+			},
+			env: map[string]string{
+				"MACKEREL_API_KEY": "env_key",
+				"API_BASE":         "https://env.example.com/",
+			},
+			want: ClientConfig{
+				APIKey:  "env_key",
+				APIBase: "https://env.example.com/",
+				Transport: TransportOptions{
+					MaxRetries:   DefaultMaxRetries,
+					RetryMaxWait: DefaultRetryMaxWait,
+				},
+			},
+		},
+		"empty string in config": {
+			config: ClientConfigModel{
+				APIKey: types.StringValue(""),
+			},
+			env: map[string]string{
+				"MACKEREL_APIKEY": "env_key",
+			},
+			want: ClientConfig{
+				APIKey: "env_key",
+				Transport: TransportOptions{
+					MaxRetries:   DefaultMaxRetries,
+					RetryMaxWait: DefaultRetryMaxWait,
+				},
+			},
+		},
+		"read_only from env": {
+			config: ClientConfigModel{
+				APIKey: types.StringValue("config_key"),
+			},
+			env: map[string]string{
+				"MACKEREL_READ_ONLY": "true",
+			},
+			want: ClientConfig{
+				APIKey: "config_key",
+				Transport: TransportOptions{
+					MaxRetries:   DefaultMaxRetries,
+					RetryMaxWait: DefaultRetryMaxWait,
+					ReadOnly:     true,
+				},
+			},
+		},
+		"read_only in config overrides env": {
+			config: ClientConfigModel{
+				APIKey:   types.StringValue("config_key"),
+				ReadOnly: types.BoolValue(false),
+			},
+			env: map[string]string{
+				"MACKEREL_READ_ONLY": "true",
+			},
+			want: ClientConfig{
+				APIKey: "config_key",
+				Transport: TransportOptions{
+					MaxRetries:   DefaultMaxRetries,
+					RetryMaxWait: DefaultRetryMaxWait,
+				},
+			},
+		},
+		"no key": {
+			wantErr: ErrNoAPIKey,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			for _, env := range []string{"MACKEREL_APIKEY", "MACKEREL_API_KEY", "API_BASE", "MACKEREL_READ_ONLY"} {
+				t.Setenv(env, tt.env[env])
+			}
+
+			got, err := tt.config.Resolve()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error to be %v, but got: %v", tt.wantErr, err)
+			}
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(TransportOptions{})); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_NewClient_shared(t *testing.T) {
+	t.Parallel()
+
+	config := ClientConfig{APIKey: "Test_NewClient_shared"}
+	c1, err := NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2, err := NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c1 != c2 {
+		t.Error("expected to share the client for the same configuration")
+	}
+
+	config.APIBase = "https://example.com/"
+	c3, err := NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c1 == c3 {
+		t.Error("expected not to share the client between configurations")
+	}
+}
@@ -0,0 +1,120 @@
+package mackerel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	credentialProcessTimeout = 1 * time.Minute
+	// The API key is refreshed a bit before its expiration,
+	// so that it does not expire while requests are in flight.
+	credentialRefreshWindow = 1 * time.Minute
+)
+
+// The output of the credential process.
+type credentialProcessOutput struct {
+	APIKey string `json:"api_key"`
+	// Optional. The API key is used until the process exits if omitted.
+	Expiration *time.Time `json:"expiration"`
+}
+
+// Credential processes are shared by the command, so that the SDK and the framework providers run it once.
+var credentialProcesses sync.Map // map[string]*credentialProcess
+
+func sharedCredentialProcess(command string) *credentialProcess {
+	p, _ := credentialProcesses.LoadOrStore(command, newCredentialProcess(command))
+	return p.(*credentialProcess)
+}
+
+// credentialProcess obtains the API key from the external command,
+// which prints `{"api_key": "...", "expiration": "2006-01-02T15:04:05Z"}` to stdout.
+type credentialProcess struct {
+	command string
+	now     func() time.Time
+
+	mu      sync.Mutex
+	apiKey  string
+	expires time.Time
+}
+
+func newCredentialProcess(command string) *credentialProcess {
+	return &credentialProcess{
+		command: command,
+		now:     time.Now,
+	}
+}
+
+// Returns the cached API key, or runs the command if it is not obtained yet or is about to expire.
+func (p *credentialProcess) APIKey(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.apiKey != "" && (p.expires.IsZero() || p.now().Add(credentialRefreshWindow).Before(p.expires)) {
+		return p.apiKey, nil
+	}
+
+	out, err := p.run(ctx)
+	if err != nil {
+		return "", err
+	}
+	p.apiKey = out.APIKey
+	p.expires = time.Time{}
+	if out.Expiration != nil {
+		p.expires = *out.Expiration
+	}
+	return p.apiKey, nil
+}
+
+func (p *credentialProcess) run(ctx context.Context) (credentialProcessOutput, error) {
+	var out credentialProcessOutput
+
+	ctx, cancel := context.WithTimeout(ctx, credentialProcessTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd.exe", "/C", p.command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", p.command)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.Output()
+	if err != nil {
+		return out, fmt.Errorf("credential_process failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	if err := json.Unmarshal(stdout, &out); err != nil {
+		return out, fmt.Errorf("credential_process printed invalid JSON: %w", err)
+	}
+	if out.APIKey == "" {
+		return out, fmt.Errorf("credential_process printed no api_key")
+	}
+	return out, nil
+}
+
+// credentialTransport sets the API key obtained from the credential process,
+// since the key may be rotated after the client is created.
+type credentialTransport struct {
+	base   http.RoundTripper
+	source *credentialProcess
+}
+
+func (t *credentialTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	apiKey, err := t.source.APIKey(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("X-Api-Key", apiKey)
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,139 @@
+package mackerel
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Writes a script which prints the output and counts its invocations.
+func writeCredentialScript(t *testing.T, output string) (command string, count func() int) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the test script requires sh")
+	}
+
+	dir := t.TempDir()
+	counter := filepath.Join(dir, "count")
+	script := filepath.Join(dir, "credential.sh")
+	content := fmt.Sprintf("#!/bin/sh\necho x >> '%s'\ncat <<'EOF'\n%s\nEOF\n", counter, output)
+	if err := os.WriteFile(script, []byte(content), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	return script, func() int {
+		b, _ := os.ReadFile(counter)
+		return strings.Count(string(b), "x")
+	}
+}
+
+func Test_credentialProcess(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("without expiration", func(t *testing.T) {
+		t.Parallel()
+
+		command, count := writeCredentialScript(t, `{"api_key": "key0"}`)
+		p := newCredentialProcess(command)
+		for range 2 {
+			key, err := p.APIKey(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			if key != "key0" {
+				t.Errorf("expected 'key0', but got '%s'", key)
+			}
+		}
+		if n := count(); n != 1 {
+			t.Errorf("expected to run once, but ran %d times", n)
+		}
+	})
+
+	t.Run("with expiration", func(t *testing.T) {
+		t.Parallel()
+
+		command, count := writeCredentialScript(t, `{"api_key": "key0", "expiration": "2026-01-01T00:00:00Z"}`)
+		p := newCredentialProcess(command)
+		now := time.Date(2025, 12, 31, 23, 0, 0, 0, time.UTC)
+		p.now = func() time.Time { return now }
+
+		if _, err := p.APIKey(ctx); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		now = now.Add(30 * time.Minute)
+		if _, err := p.APIKey(ctx); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if n := count(); n != 1 {
+			t.Errorf("expected to use the cached key, but ran %d times", n)
+		}
+
+		now = now.Add(29*time.Minute + 30*time.Second)
+		if _, err := p.APIKey(ctx); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if n := count(); n != 2 {
+			t.Errorf("expected to refresh the key before expiration, but ran %d times", n)
+		}
+	})
+
+	for name, output := range map[string]string{
+		"invalid JSON": `api_key=key0`,
+		"no api_key":   `{"expiration": "2026-01-01T00:00:00Z"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			command, _ := writeCredentialScript(t, output)
+			if _, err := newCredentialProcess(command).APIKey(ctx); err == nil {
+				t.Error("expected error, but got no error")
+			}
+		})
+	}
+
+	t.Run("failure", func(t *testing.T) {
+		t.Parallel()
+
+		if runtime.GOOS == "windows" {
+			t.Skip("the test command requires sh")
+		}
+		_, err := newCredentialProcess("echo 'key vault is sealed' >&2; exit 1").APIKey(ctx)
+		if err == nil || !strings.Contains(err.Error(), "key vault is sealed") {
+			t.Errorf("expected error with stderr, but got: %v", err)
+		}
+	})
+}
+
+func Test_NewClient_credentialProcess(t *testing.T) {
+	t.Parallel()
+
+	command, _ := writeCredentialScript(t, `{"api_key": "process_key"}`)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Api-Key"); got != "process_key" {
+			t.Errorf("expected the API key from the process, but got '%s'", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"services":[]}`))
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(ClientConfig{
+		APIBase:           ts.URL,
+		CredentialProcess: command,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if _, err := client.FindServices(); err != nil {
+		t.Errorf("unexpected error: %+v", err)
+	}
+}
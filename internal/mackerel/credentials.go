@@ -0,0 +1,73 @@
+package mackerel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Credentials describes the organization of an API key.
+type Credentials struct {
+	OrgName string
+}
+
+var credentialValidations sync.Map // map[*Client]*credentialValidation
+
+type credentialValidation struct {
+	once  sync.Once
+	creds Credentials
+	err   error
+}
+
+// ValidateCredentials looks up the organization of the API key of client.
+// It only reads the organization, so it is safe to run on every plan.
+// The API has no endpoint to tell whether the key can modify objects;
+// a read-only key is reported by the first modification with ErrForbidden, whose hint explains it.
+// The result is cached for each client, since the SDK and the framework providers configure the same client.
+func ValidateCredentials(ctx context.Context, client *Client) (Credentials, error) {
+	v, _ := credentialValidations.LoadOrStore(client, &credentialValidation{})
+	validation := v.(*credentialValidation)
+	validation.once.Do(func() {
+		validation.creds, validation.err = validateCredentials(ctx, WithContext(ctx, client))
+	})
+	return validation.creds, validation.err
+}
+
+func validateCredentials(ctx context.Context, client *Client) (Credentials, error) {
+	org, err := client.GetOrg()
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to look up the organization of the API key at %s: %w", client.BaseURL, unwrapAPIError(err))
+	}
+	ctx = tflog.NewSubsystem(ctx, logSubsystem)
+	tflog.SubsystemInfo(ctx, logSubsystem, "Using the API key of the organization", map[string]any{
+		"organization": org.Name,
+	})
+	return Credentials{OrgName: org.Name}, nil
+}
+
+// Strips *url.Error around the API error, whose message repeats the method and the URL.
+func unwrapAPIError(err error) error {
+	if apiErr, ok := AsAPIError(err); ok {
+		return apiErr
+	}
+	return err
+}
+
+// DescribeCredentialsError returns the summary and the detail of the diagnostic for an error of ValidateCredentials.
+func DescribeCredentialsError(err error) (summary, detail string) {
+	summary = "Unable to validate Mackerel credentials"
+	if errors.Is(err, ErrUnauthorized) {
+		summary = "Invalid Mackerel API Key"
+	}
+	detail = err.Error()
+	if apiErr, ok := AsAPIError(err); ok {
+		if hint := apiErr.Hint(); hint != "" {
+			detail += "\n\n" + hint
+		}
+	}
+	detail += "\n\nSet skip_credentials_validation to true to configure the provider without accessing the API."
+	return summary, detail
+}
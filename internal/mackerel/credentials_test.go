@@ -0,0 +1,108 @@
+package mackerel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mackerelio/mackerel-client-go"
+)
+
+func newCredentialsServer(t *testing.T, orgStatus int) (ts *httptest.Server, requests func() int32) {
+	t.Helper()
+
+	var n atomic.Int32
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v0/org" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(orgStatus)
+		if orgStatus == http.StatusOK {
+			_, _ = w.Write([]byte(`{"name":"org0"}`))
+		} else {
+			_, _ = w.Write([]byte(`{"error":{"message":"Authentication failed. Please try with valid Api Key."}}`))
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts, n.Load
+}
+
+func Test_ValidateCredentials(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		orgStatus int
+		want      Credentials
+		wantErr   error
+	}{
+		"valid": {
+			orgStatus: http.StatusOK,
+			want:      Credentials{OrgName: "org0"},
+		},
+		"unauthorized": {
+			orgStatus: http.StatusUnauthorized,
+			wantErr:   ErrUnauthorized,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ts, requests := newCredentialsServer(t, tt.orgStatus)
+			client, err := mackerel.NewClientWithOptions("Test_ValidateCredentials", ts.URL, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := SetupTransport(client, TransportOptions{}); err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			creds, err := ValidateCredentials(ctx, client)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error to be %v, but got: %+v", tt.wantErr, err)
+			}
+			if creds != tt.want {
+				t.Errorf("expected %+v, but got %+v", tt.want, creds)
+			}
+
+			// The result is cached for the client, and only the organization is looked up.
+			creds2, err2 := ValidateCredentials(ctx, client)
+			if creds2 != creds || err2 != err {
+				t.Errorf("expected the cached result, but got: %+v, %+v", creds2, err2)
+			}
+			if n := requests(); n != 1 {
+				t.Errorf("expected 1 request, but got %d", n)
+			}
+		})
+	}
+}
+
+func Test_DescribeCredentialsError(t *testing.T) {
+	t.Parallel()
+
+	err := &APIError{StatusCode: http.StatusUnauthorized, Message: "Authentication failed."}
+	summary, detail := DescribeCredentialsError(err)
+	if summary != "Invalid Mackerel API Key" {
+		t.Errorf("unexpected summary: %s", summary)
+	}
+	for _, want := range []string{"Authentication failed.", "Check that the API key is valid.", "skip_credentials_validation"} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("expected the detail to contain %q, but got: %s", want, detail)
+		}
+	}
+
+	summary, _ = DescribeCredentialsError(errors.New("connection refused"))
+	if summary != "Unable to validate Mackerel credentials" {
+		t.Errorf("unexpected summary: %s", summary)
+	}
+}
@@ -0,0 +1,605 @@
+package mackerel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio/mackerel-client-go"
+)
+
+type DashboardModel struct {
+	ID            types.String                `tfsdk:"id"`
+	Title         types.String                `tfsdk:"title"`
+	Memo          types.String                `tfsdk:"memo"`
+	URLPath       types.String                `tfsdk:"url_path"`
+	Graphs        []DashboardGraphModel       `tfsdk:"graph"`
+	Values        []DashboardValueModel       `tfsdk:"value"`
+	Markdowns     []DashboardMarkdownModel    `tfsdk:"markdown"`
+	AlertStatuses []DashboardAlertStatusModel `tfsdk:"alert_status"`
+}
+
+// Exactly one of Host, Role, Service, Expression and Query is non-nil.
+type DashboardGraphModel struct {
+	Title      types.String                 `tfsdk:"title"`
+	Host       *DashboardHostMetricModel    `tfsdk:"host"`
+	Role       *DashboardRoleMetricModel    `tfsdk:"role"`
+	Service    *DashboardServiceMetricModel `tfsdk:"service"`
+	Expression *DashboardExpressionModel    `tfsdk:"expression"`
+	Query      *DashboardQueryModel         `tfsdk:"query"`
+	Range      *DashboardRangeModel         `tfsdk:"range"`
+	Layout     *DashboardLayoutModel        `tfsdk:"layout"`
+}
+
+type DashboardValueModel struct {
+	Title        types.String          `tfsdk:"title"`
+	Metric       *DashboardMetricModel `tfsdk:"metric"`
+	FractionSize types.Int64           `tfsdk:"fraction_size"`
+	Suffix       types.String          `tfsdk:"suffix"`
+	Layout       *DashboardLayoutModel `tfsdk:"layout"`
+}
+
+// Exactly one of Host, Service, Expression and Query is non-nil.
+type DashboardMetricModel struct {
+	Host       *DashboardHostMetricModel    `tfsdk:"host"`
+	Service    *DashboardServiceMetricModel `tfsdk:"service"`
+	Expression *DashboardExpressionModel    `tfsdk:"expression"`
+	Query      *DashboardQueryModel         `tfsdk:"query"`
+}
+
+type DashboardMarkdownModel struct {
+	Title    types.String          `tfsdk:"title"`
+	Markdown types.String          `tfsdk:"markdown"`
+	Layout   *DashboardLayoutModel `tfsdk:"layout"`
+}
+
+type DashboardAlertStatusModel struct {
+	Title        types.String          `tfsdk:"title"`
+	RoleFullname types.String          `tfsdk:"role_fullname"`
+	Layout       *DashboardLayoutModel `tfsdk:"layout"`
+}
+
+type DashboardHostMetricModel struct {
+	HostID types.String `tfsdk:"host_id"`
+	Name   types.String `tfsdk:"name"`
+}
+
+type DashboardRoleMetricModel struct {
+	RoleFullname types.String `tfsdk:"role_fullname"`
+	Name         types.String `tfsdk:"name"`
+	IsStacked    types.Bool   `tfsdk:"is_stacked"`
+}
+
+type DashboardServiceMetricModel struct {
+	ServiceName types.String `tfsdk:"service_name"`
+	Name        types.String `tfsdk:"name"`
+}
+
+type DashboardExpressionModel struct {
+	Expression types.String `tfsdk:"expression"`
+}
+
+type DashboardQueryModel struct {
+	Query  types.String `tfsdk:"query"`
+	Legend types.String `tfsdk:"legend"`
+}
+
+// At most one of Relative and Absolute is non-nil.
+type DashboardRangeModel struct {
+	Relative *DashboardRelativeRangeModel `tfsdk:"relative"`
+	Absolute *DashboardAbsoluteRangeModel `tfsdk:"absolute"`
+}
+
+type DashboardRelativeRangeModel struct {
+	Period types.Int64 `tfsdk:"period"`
+	Offset types.Int64 `tfsdk:"offset"`
+}
+
+type DashboardAbsoluteRangeModel struct {
+	Start types.Int64 `tfsdk:"start"`
+	End   types.Int64 `tfsdk:"end"`
+}
+
+type DashboardLayoutModel struct {
+	X      types.Int64 `tfsdk:"x"`
+	Y      types.Int64 `tfsdk:"y"`
+	Width  types.Int64 `tfsdk:"width"`
+	Height types.Int64 `tfsdk:"height"`
+}
+
+// Reads a dashboard by `id`
+func ReadDashboard(ctx context.Context, client *Client, id string) (DashboardModel, error) {
+	return readDashboardInner(ctx, WithContext(ctx, client), id)
+}
+
+type dashboardFinder interface {
+	FindDashboard(string) (*mackerel.Dashboard, error)
+}
+
+func readDashboardInner(_ context.Context, client dashboardFinder, id string) (DashboardModel, error) {
+	dashboard, err := client.FindDashboard(id)
+	if err != nil {
+		return DashboardModel{}, err
+	}
+	return newDashboardModel(*dashboard), nil
+}
+
+// Creates a dashboard
+func (m *DashboardModel) Create(ctx context.Context, client *Client) error {
+	return m.createInner(ctx, WithContext(ctx, client))
+}
+
+type dashboardCreator interface {
+	CreateDashboard(*mackerel.Dashboard) (*mackerel.Dashboard, error)
+}
+
+func (m *DashboardModel) createInner(_ context.Context, client dashboardCreator) error {
+	dashboard, err := client.CreateDashboard(m.mackerelDashboard())
+	if err != nil {
+		return err
+	}
+
+	m.ID = types.StringValue(dashboard.ID)
+	m.URLPath = types.StringValue(dashboard.URLPath)
+	return nil
+}
+
+// Reads the dashboard
+func (m *DashboardModel) Read(ctx context.Context, client *Client) error {
+	data, err := ReadDashboard(ctx, client, m.ID.ValueString())
+	if err != nil {
+		return err
+	}
+	*m = data
+	return nil
+}
+
+// Updates the dashboard
+func (m *DashboardModel) Update(ctx context.Context, client *Client) error {
+	dashboard, err := WithContext(ctx, client).UpdateDashboard(m.ID.ValueString(), m.mackerelDashboard())
+	if err != nil {
+		return err
+	}
+
+	m.URLPath = types.StringValue(dashboard.URLPath)
+	return nil
+}
+
+// Deletes the dashboard
+func (m *DashboardModel) Delete(ctx context.Context, client *Client) error {
+	if _, err := WithContext(ctx, client).DeleteDashboard(m.ID.ValueString()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// API -> Model
+// Widgets of unknown types are dropped as the SDK resource does.
+func newDashboardModel(dashboard mackerel.Dashboard) DashboardModel {
+	data := DashboardModel{
+		ID:            types.StringValue(dashboard.ID),
+		Title:         types.StringValue(dashboard.Title),
+		Memo:          types.StringValue(dashboard.Memo),
+		URLPath:       types.StringValue(dashboard.URLPath),
+		Graphs:        []DashboardGraphModel{},
+		Values:        []DashboardValueModel{},
+		Markdowns:     []DashboardMarkdownModel{},
+		AlertStatuses: []DashboardAlertStatusModel{},
+	}
+
+	for _, widget := range dashboard.Widgets {
+		layout := newDashboardLayoutModel(widget.Layout)
+		switch widget.Type {
+		case "graph":
+			graph := DashboardGraphModel{
+				Title:  types.StringValue(widget.Title),
+				Range:  newDashboardRangeModel(widget.Range),
+				Layout: layout,
+			}
+			switch widget.Graph.Type {
+			case "host":
+				graph.Host = &DashboardHostMetricModel{
+					HostID: types.StringValue(widget.Graph.HostID),
+					Name:   types.StringValue(widget.Graph.Name),
+				}
+			case "role":
+				graph.Role = &DashboardRoleMetricModel{
+					RoleFullname: types.StringValue(widget.Graph.RoleFullName),
+					Name:         types.StringValue(widget.Graph.Name),
+					IsStacked:    types.BoolValue(widget.Graph.IsStacked),
+				}
+			case "service":
+				graph.Service = &DashboardServiceMetricModel{
+					ServiceName: types.StringValue(widget.Graph.ServiceName),
+					Name:        types.StringValue(widget.Graph.Name),
+				}
+			case "expression":
+				graph.Expression = &DashboardExpressionModel{
+					Expression: types.StringValue(widget.Graph.Expression),
+				}
+			case "query":
+				graph.Query = &DashboardQueryModel{
+					Query:  types.StringValue(widget.Graph.Query),
+					Legend: types.StringValue(widget.Graph.Legend),
+				}
+			default:
+				continue
+			}
+			data.Graphs = append(data.Graphs, graph)
+		case "value":
+			metric := &DashboardMetricModel{}
+			switch widget.Metric.Type {
+			case "host":
+				metric.Host = &DashboardHostMetricModel{
+					HostID: types.StringValue(widget.Metric.HostID),
+					Name:   types.StringValue(widget.Metric.Name),
+				}
+			case "service":
+				metric.Service = &DashboardServiceMetricModel{
+					ServiceName: types.StringValue(widget.Metric.ServiceName),
+					Name:        types.StringValue(widget.Metric.Name),
+				}
+			case "expression":
+				metric.Expression = &DashboardExpressionModel{
+					Expression: types.StringValue(widget.Metric.Expression),
+				}
+			case "query":
+				metric.Query = &DashboardQueryModel{
+					Query:  types.StringValue(widget.Metric.Query),
+					Legend: types.StringValue(widget.Metric.Legend),
+				}
+			default:
+				continue
+			}
+			// The SDK resource always sends the fraction size, which is 0 unless specified.
+			var fractionSize int64
+			if widget.FractionSize != nil {
+				fractionSize = *widget.FractionSize
+			}
+			data.Values = append(data.Values, DashboardValueModel{
+				Title:        types.StringValue(widget.Title),
+				Metric:       metric,
+				FractionSize: types.Int64Value(fractionSize),
+				Suffix:       types.StringValue(widget.Suffix),
+				Layout:       layout,
+			})
+		case "markdown":
+			data.Markdowns = append(data.Markdowns, DashboardMarkdownModel{
+				Title:    types.StringValue(widget.Title),
+				Markdown: types.StringValue(widget.Markdown),
+				Layout:   layout,
+			})
+		case "alertStatus":
+			data.AlertStatuses = append(data.AlertStatuses, DashboardAlertStatusModel{
+				Title:        types.StringValue(widget.Title),
+				RoleFullname: types.StringValue(widget.RoleFullName),
+				Layout:       layout,
+			})
+		}
+	}
+	return data
+}
+
+func newDashboardRangeModel(r mackerel.Range) *DashboardRangeModel {
+	switch r.Type {
+	case "relative":
+		return &DashboardRangeModel{
+			Relative: &DashboardRelativeRangeModel{
+				Period: types.Int64Value(r.Period),
+				Offset: types.Int64Value(r.Offset),
+			},
+		}
+	case "absolute":
+		return &DashboardRangeModel{
+			Absolute: &DashboardAbsoluteRangeModel{
+				Start: types.Int64Value(r.Start),
+				End:   types.Int64Value(r.End),
+			},
+		}
+	default:
+		return nil
+	}
+}
+
+func newDashboardLayoutModel(l mackerel.Layout) *DashboardLayoutModel {
+	return &DashboardLayoutModel{
+		X:      types.Int64Value(l.X),
+		Y:      types.Int64Value(l.Y),
+		Width:  types.Int64Value(l.Width),
+		Height: types.Int64Value(l.Height),
+	}
+}
+
+// Model -> API
+// Widgets are ordered by their types as the SDK resource does.
+func (m DashboardModel) mackerelDashboard() *mackerel.Dashboard {
+	widgets := make([]mackerel.Widget, 0, len(m.Graphs)+len(m.Values)+len(m.Markdowns)+len(m.AlertStatuses))
+	for _, g := range m.Graphs {
+		widgets = append(widgets, mackerel.Widget{
+			Type:   "graph",
+			Title:  g.Title.ValueString(),
+			Graph:  g.mackerelGraph(),
+			Range:  g.Range.mackerelRange(),
+			Layout: g.Layout.mackerelLayout(),
+		})
+	}
+	for _, v := range m.Values {
+		fractionSize := v.FractionSize.ValueInt64()
+		widgets = append(widgets, mackerel.Widget{
+			Type:         "value",
+			Title:        v.Title.ValueString(),
+			Metric:       v.Metric.mackerelMetric(),
+			FractionSize: &fractionSize,
+			Suffix:       v.Suffix.ValueString(),
+			Layout:       v.Layout.mackerelLayout(),
+		})
+	}
+	for _, md := range m.Markdowns {
+		widgets = append(widgets, mackerel.Widget{
+			Type:     "markdown",
+			Title:    md.Title.ValueString(),
+			Markdown: md.Markdown.ValueString(),
+			Layout:   md.Layout.mackerelLayout(),
+		})
+	}
+	for _, as := range m.AlertStatuses {
+		widgets = append(widgets, mackerel.Widget{
+			Type:         "alertStatus",
+			Title:        as.Title.ValueString(),
+			RoleFullName: as.RoleFullname.ValueString(),
+			Layout:       as.Layout.mackerelLayout(),
+		})
+	}
+
+	return &mackerel.Dashboard{
+		Title:   m.Title.ValueString(),
+		Memo:    m.Memo.ValueString(),
+		URLPath: m.URLPath.ValueString(),
+		Widgets: widgets,
+	}
+}
+
+func (g DashboardGraphModel) mackerelGraph() mackerel.Graph {
+	switch {
+	case g.Host != nil:
+		return mackerel.Graph{
+			Type:   "host",
+			HostID: g.Host.HostID.ValueString(),
+			Name:   g.Host.Name.ValueString(),
+		}
+	case g.Role != nil:
+		return mackerel.Graph{
+			Type:         "role",
+			RoleFullName: g.Role.RoleFullname.ValueString(),
+			Name:         g.Role.Name.ValueString(),
+			IsStacked:    g.Role.IsStacked.ValueBool(),
+		}
+	case g.Service != nil:
+		return mackerel.Graph{
+			Type:        "service",
+			ServiceName: g.Service.ServiceName.ValueString(),
+			Name:        g.Service.Name.ValueString(),
+		}
+	case g.Expression != nil:
+		return mackerel.Graph{
+			Type:       "expression",
+			Expression: g.Expression.Expression.ValueString(),
+		}
+	case g.Query != nil:
+		return mackerel.Graph{
+			Type:   "query",
+			Query:  g.Query.Query.ValueString(),
+			Legend: g.Query.Legend.ValueString(),
+		}
+	default:
+		return mackerel.Graph{}
+	}
+}
+
+func (m *DashboardMetricModel) mackerelMetric() mackerel.Metric {
+	switch {
+	case m == nil:
+		return mackerel.Metric{}
+	case m.Host != nil:
+		return mackerel.Metric{
+			Type:   "host",
+			HostID: m.Host.HostID.ValueString(),
+			Name:   m.Host.Name.ValueString(),
+		}
+	case m.Service != nil:
+		return mackerel.Metric{
+			Type:        "service",
+			ServiceName: m.Service.ServiceName.ValueString(),
+			Name:        m.Service.Name.ValueString(),
+		}
+	case m.Expression != nil:
+		return mackerel.Metric{
+			Type:       "expression",
+			Expression: m.Expression.Expression.ValueString(),
+		}
+	case m.Query != nil:
+		return mackerel.Metric{
+			Type:   "query",
+			Query:  m.Query.Query.ValueString(),
+			Legend: m.Query.Legend.ValueString(),
+		}
+	default:
+		return mackerel.Metric{}
+	}
+}
+
+func (r *DashboardRangeModel) mackerelRange() mackerel.Range {
+	switch {
+	case r == nil:
+		return mackerel.Range{}
+	case r.Relative != nil:
+		return mackerel.Range{
+			Type:   "relative",
+			Period: r.Relative.Period.ValueInt64(),
+			Offset: r.Relative.Offset.ValueInt64(),
+		}
+	case r.Absolute != nil:
+		return mackerel.Range{
+			Type:  "absolute",
+			Start: r.Absolute.Start.ValueInt64(),
+			End:   r.Absolute.End.ValueInt64(),
+		}
+	default:
+		return mackerel.Range{}
+	}
+}
+
+func (l *DashboardLayoutModel) mackerelLayout() mackerel.Layout {
+	if l == nil {
+		return mackerel.Layout{}
+	}
+	return mackerel.Layout{
+		X:      l.X.ValueInt64(),
+		Y:      l.Y.ValueInt64(),
+		Width:  l.Width.ValueInt64(),
+		Height: l.Height.ValueInt64(),
+	}
+}
+
+// DashboardModelV0 is the state of the SDK resource,
+// which wraps every single object with a list.
+type DashboardModelV0 struct {
+	ID            types.String                  `tfsdk:"id"`
+	Title         types.String                  `tfsdk:"title"`
+	Memo          types.String                  `tfsdk:"memo"`
+	URLPath       types.String                  `tfsdk:"url_path"`
+	Graphs        []DashboardGraphModelV0       `tfsdk:"graph"`
+	Values        []DashboardValueModelV0       `tfsdk:"value"`
+	Markdowns     []DashboardMarkdownModelV0    `tfsdk:"markdown"`
+	AlertStatuses []DashboardAlertStatusModelV0 `tfsdk:"alert_status"`
+}
+
+type DashboardGraphModelV0 struct {
+	Title      types.String                  `tfsdk:"title"`
+	Host       []DashboardHostMetricModel    `tfsdk:"host"`
+	Role       []DashboardRoleMetricModel    `tfsdk:"role"`
+	Service    []DashboardServiceMetricModel `tfsdk:"service"`
+	Expression []DashboardExpressionModel    `tfsdk:"expression"`
+	Query      []DashboardQueryModel         `tfsdk:"query"`
+	Range      []DashboardRangeModelV0       `tfsdk:"range"`
+	Layout     []DashboardLayoutModel        `tfsdk:"layout"`
+}
+
+type DashboardValueModelV0 struct {
+	Title        types.String             `tfsdk:"title"`
+	Metric       []DashboardMetricModelV0 `tfsdk:"metric"`
+	FractionSize types.Int64              `tfsdk:"fraction_size"`
+	Suffix       types.String             `tfsdk:"suffix"`
+	Layout       []DashboardLayoutModel   `tfsdk:"layout"`
+}
+
+type DashboardMetricModelV0 struct {
+	Host       []DashboardHostMetricModel    `tfsdk:"host"`
+	Service    []DashboardServiceMetricModel `tfsdk:"service"`
+	Expression []DashboardExpressionModel    `tfsdk:"expression"`
+	Query      []DashboardQueryModel         `tfsdk:"query"`
+}
+
+type DashboardMarkdownModelV0 struct {
+	Title    types.String           `tfsdk:"title"`
+	Markdown types.String           `tfsdk:"markdown"`
+	Layout   []DashboardLayoutModel `tfsdk:"layout"`
+}
+
+type DashboardAlertStatusModelV0 struct {
+	Title        types.String           `tfsdk:"title"`
+	RoleFullname types.String           `tfsdk:"role_fullname"`
+	Layout       []DashboardLayoutModel `tfsdk:"layout"`
+}
+
+type DashboardRangeModelV0 struct {
+	Relative []DashboardRelativeRangeModel `tfsdk:"relative"`
+	Absolute []DashboardAbsoluteRangeModel `tfsdk:"absolute"`
+}
+
+// Upgrade converts the state of the SDK resource.
+func (m DashboardModelV0) Upgrade() (DashboardModel, error) {
+	data := DashboardModel{
+		ID:            m.ID,
+		Title:         m.Title,
+		Memo:          m.Memo,
+		URLPath:       m.URLPath,
+		Graphs:        make([]DashboardGraphModel, 0, len(m.Graphs)),
+		Values:        make([]DashboardValueModel, 0, len(m.Values)),
+		Markdowns:     make([]DashboardMarkdownModel, 0, len(m.Markdowns)),
+		AlertStatuses: make([]DashboardAlertStatusModel, 0, len(m.AlertStatuses)),
+	}
+
+	for i, g := range m.Graphs {
+		graph := DashboardGraphModel{
+			Title:      g.Title,
+			Host:       first(g.Host),
+			Role:       first(g.Role),
+			Service:    first(g.Service),
+			Expression: first(g.Expression),
+			Query:      first(g.Query),
+			Layout:     first(g.Layout),
+		}
+		// The SDK resource stores an empty range when the graph has no range.
+		if r := first(g.Range); r != nil && (len(r.Relative) > 0 || len(r.Absolute) > 0) {
+			graph.Range = &DashboardRangeModel{
+				Relative: first(r.Relative),
+				Absolute: first(r.Absolute),
+			}
+		}
+		if graph.Layout == nil {
+			return DashboardModel{}, fmt.Errorf("graph.%d has no layout", i)
+		}
+		data.Graphs = append(data.Graphs, graph)
+	}
+	for i, v := range m.Values {
+		value := DashboardValueModel{
+			Title:        v.Title,
+			FractionSize: v.FractionSize,
+			Suffix:       v.Suffix,
+			Layout:       first(v.Layout),
+		}
+		if metric := first(v.Metric); metric != nil {
+			value.Metric = &DashboardMetricModel{
+				Host:       first(metric.Host),
+				Service:    first(metric.Service),
+				Expression: first(metric.Expression),
+				Query:      first(metric.Query),
+			}
+		}
+		if value.Layout == nil {
+			return DashboardModel{}, fmt.Errorf("value.%d has no layout", i)
+		}
+		data.Values = append(data.Values, value)
+	}
+	for i, md := range m.Markdowns {
+		markdown := DashboardMarkdownModel{
+			Title:    md.Title,
+			Markdown: md.Markdown,
+			Layout:   first(md.Layout),
+		}
+		if markdown.Layout == nil {
+			return DashboardModel{}, fmt.Errorf("markdown.%d has no layout", i)
+		}
+		data.Markdowns = append(data.Markdowns, markdown)
+	}
+	for i, as := range m.AlertStatuses {
+		alertStatus := DashboardAlertStatusModel{
+			Title:        as.Title,
+			RoleFullname: as.RoleFullname,
+			Layout:       first(as.Layout),
+		}
+		if alertStatus.Layout == nil {
+			return DashboardModel{}, fmt.Errorf("alert_status.%d has no layout", i)
+		}
+		data.AlertStatuses = append(data.AlertStatuses, alertStatus)
+	}
+	return data, nil
+}
+
+func first[T any](s []T) *T {
+	if len(s) == 0 {
+		return nil
+	}
+	return &s[0]
+}
@@ -0,0 +1,440 @@
+package mackerel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio/mackerel-client-go"
+)
+
+func newTestDashboardLayoutModel(x, y, width, height int64) *DashboardLayoutModel {
+	return &DashboardLayoutModel{
+		X:      types.Int64Value(x),
+		Y:      types.Int64Value(y),
+		Width:  types.Int64Value(width),
+		Height: types.Int64Value(height),
+	}
+}
+
+func Test_Dashboard_Read(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		inID     string
+		inClient dashboardFinderFunc
+
+		wantErr bool
+		wants   DashboardModel
+	}{
+		"valid": {
+			inID: "d0",
+			inClient: func(id string) (*mackerel.Dashboard, error) {
+				return &mackerel.Dashboard{
+					ID:      id,
+					Title:   "dashboard",
+					URLPath: "path",
+					Memo:    "memo",
+					Widgets: []mackerel.Widget{
+						{
+							Type:   "markdown",
+							Title:  "markdown",
+							Layout: mackerel.Layout{X: 0, Y: 0, Width: 24, Height: 3},
+
+							Markdown: "# body",
+						},
+						{
+							Type:   "graph",
+							Title:  "graph",
+							Layout: mackerel.Layout{X: 0, Y: 3, Width: 8, Height: 10},
+
+							Graph: mackerel.Graph{Type: "role", RoleFullName: "service:role", Name: "loadavg5", IsStacked: true},
+							Range: mackerel.Range{Type: "relative", Period: 3600, Offset: 0},
+						},
+						{
+							Type:   "graph",
+							Title:  "graph without range",
+							Layout: mackerel.Layout{X: 8, Y: 3, Width: 8, Height: 10},
+
+							Graph: mackerel.Graph{Type: "query", Query: "container.cpu.utilization"},
+						},
+						{
+							Type:   "value",
+							Title:  "value",
+							Layout: mackerel.Layout{X: 16, Y: 3, Width: 8, Height: 5},
+
+							Metric: mackerel.Metric{Type: "expression", Expression: "max(role(service:role, loadavg5))"},
+							Suffix: "total",
+						},
+						{
+							Type:   "alertStatus",
+							Title:  "alert status",
+							Layout: mackerel.Layout{X: 16, Y: 8, Width: 8, Height: 5},
+
+							RoleFullName: "service:role",
+						},
+					},
+				}, nil
+			},
+
+			wants: DashboardModel{
+				ID:      types.StringValue("d0"),
+				Title:   types.StringValue("dashboard"),
+				Memo:    types.StringValue("memo"),
+				URLPath: types.StringValue("path"),
+				Graphs: []DashboardGraphModel{
+					{
+						Title: types.StringValue("graph"),
+						Role: &DashboardRoleMetricModel{
+							RoleFullname: types.StringValue("service:role"),
+							Name:         types.StringValue("loadavg5"),
+							IsStacked:    types.BoolValue(true),
+						},
+						Range: &DashboardRangeModel{
+							Relative: &DashboardRelativeRangeModel{
+								Period: types.Int64Value(3600),
+								Offset: types.Int64Value(0),
+							},
+						},
+						Layout: newTestDashboardLayoutModel(0, 3, 8, 10),
+					},
+					{
+						Title: types.StringValue("graph without range"),
+						Query: &DashboardQueryModel{
+							Query:  types.StringValue("container.cpu.utilization"),
+							Legend: types.StringValue(""),
+						},
+						Layout: newTestDashboardLayoutModel(8, 3, 8, 10),
+					},
+				},
+				Values: []DashboardValueModel{{
+					Title: types.StringValue("value"),
+					Metric: &DashboardMetricModel{
+						Expression: &DashboardExpressionModel{
+							Expression: types.StringValue("max(role(service:role, loadavg5))"),
+						},
+					},
+					FractionSize: types.Int64Value(0),
+					Suffix:       types.StringValue("total"),
+					Layout:       newTestDashboardLayoutModel(16, 3, 8, 5),
+				}},
+				Markdowns: []DashboardMarkdownModel{{
+					Title:    types.StringValue("markdown"),
+					Markdown: types.StringValue("# body"),
+					Layout:   newTestDashboardLayoutModel(0, 0, 24, 3),
+				}},
+				AlertStatuses: []DashboardAlertStatusModel{{
+					Title:        types.StringValue("alert status"),
+					RoleFullname: types.StringValue("service:role"),
+					Layout:       newTestDashboardLayoutModel(16, 8, 8, 5),
+				}},
+			},
+		},
+		"not found": {
+			inID: "d1",
+			inClient: func(string) (*mackerel.Dashboard, error) {
+				return nil, &APIError{StatusCode: 404}
+			},
+
+			wantErr: true,
+		},
+	}
+
+	ctx := context.Background()
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := readDashboardInner(ctx, tt.inClient, tt.inID)
+			if (err != nil) != tt.wantErr {
+				if tt.wantErr {
+					t.Errorf("expect error, but got no error")
+				} else {
+					t.Errorf("unexpected error: %+v", err)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(d, tt.wants); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+type dashboardFinderFunc func(string) (*mackerel.Dashboard, error)
+
+func (f dashboardFinderFunc) FindDashboard(id string) (*mackerel.Dashboard, error) {
+	return f(id)
+}
+
+func Test_Dashboard_Create(t *testing.T) {
+	t.Parallel()
+
+	fractionSize := int64(2)
+	cases := map[string]struct {
+		in          DashboardModel
+		inID        string
+		inURLPath   string
+		wantReq     mackerel.Dashboard
+		wantURLPath string
+	}{
+		"valid": {
+			in: DashboardModel{
+				ID:      types.StringUnknown(),
+				Title:   types.StringValue("dashboard"),
+				Memo:    types.StringValue(""),
+				URLPath: types.StringUnknown(),
+				Graphs: []DashboardGraphModel{{
+					Title: types.StringValue("graph"),
+					Host: &DashboardHostMetricModel{
+						HostID: types.StringValue("host0"),
+						Name:   types.StringValue("loadavg5"),
+					},
+					Range: &DashboardRangeModel{
+						Absolute: &DashboardAbsoluteRangeModel{
+							Start: types.Int64Value(1700000000),
+							End:   types.Int64Value(1700003600),
+						},
+					},
+					Layout: newTestDashboardLayoutModel(0, 0, 8, 10),
+				}},
+				Values: []DashboardValueModel{{
+					Title: types.StringValue("value"),
+					Metric: &DashboardMetricModel{
+						Service: &DashboardServiceMetricModel{
+							ServiceName: types.StringValue("service"),
+							Name:        types.StringValue("custom.metric"),
+						},
+					},
+					FractionSize: types.Int64Value(2),
+					Suffix:       types.StringValue(""),
+					Layout:       newTestDashboardLayoutModel(8, 0, 8, 5),
+				}},
+				Markdowns:     []DashboardMarkdownModel{},
+				AlertStatuses: []DashboardAlertStatusModel{},
+			},
+			inID:      "d0",
+			inURLPath: "generated",
+
+			wantReq: mackerel.Dashboard{
+				Title: "dashboard",
+				Widgets: []mackerel.Widget{
+					{
+						Type:   "graph",
+						Title:  "graph",
+						Layout: mackerel.Layout{X: 0, Y: 0, Width: 8, Height: 10},
+						Graph:  mackerel.Graph{Type: "host", HostID: "host0", Name: "loadavg5"},
+						Range:  mackerel.Range{Type: "absolute", Start: 1700000000, End: 1700003600},
+					},
+					{
+						Type:         "value",
+						Title:        "value",
+						Layout:       mackerel.Layout{X: 8, Y: 0, Width: 8, Height: 5},
+						Metric:       mackerel.Metric{Type: "service", ServiceName: "service", Name: "custom.metric"},
+						FractionSize: &fractionSize,
+					},
+				},
+			},
+			wantURLPath: "generated",
+		},
+	}
+
+	ctx := context.Background()
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			m := tt.in
+			client := &dashboardCreatorTester{ID: tt.inID, URLPath: tt.inURLPath}
+			if err := m.createInner(ctx, client); err != nil {
+				t.Errorf("unexpected error: %+v", err)
+				return
+			}
+
+			if diff := cmp.Diff(client.Request, tt.wantReq); diff != "" {
+				t.Errorf("invalid request:\n%s", diff)
+			}
+			if m.ID.ValueString() != tt.inID {
+				t.Errorf("expected ID to be %s, but got %s", tt.inID, m.ID)
+			}
+			if m.URLPath.ValueString() != tt.wantURLPath {
+				t.Errorf("expected url_path to be %s, but got %s", tt.wantURLPath, m.URLPath)
+			}
+		})
+	}
+}
+
+type dashboardCreatorTester struct {
+	ID      string
+	URLPath string
+
+	Request mackerel.Dashboard
+}
+
+func (ct *dashboardCreatorTester) CreateDashboard(param *mackerel.Dashboard) (*mackerel.Dashboard, error) {
+	ct.Request = *param
+	data := *param
+	data.ID = ct.ID
+	if data.URLPath == "" {
+		data.URLPath = ct.URLPath
+	}
+	return &data, nil
+}
+
+func Test_DashboardModelV0_Upgrade(t *testing.T) {
+	t.Parallel()
+
+	layoutV0 := []DashboardLayoutModel{*newTestDashboardLayoutModel(0, 0, 8, 10)}
+	cases := map[string]struct {
+		in DashboardModelV0
+
+		wantErr bool
+		wants   DashboardModel
+	}{
+		"valid": {
+			in: DashboardModelV0{
+				ID:      types.StringValue("d0"),
+				Title:   types.StringValue("dashboard"),
+				Memo:    types.StringValue(""),
+				URLPath: types.StringValue("path"),
+				Graphs: []DashboardGraphModelV0{
+					{
+						Title: types.StringValue("graph"),
+						Host: []DashboardHostMetricModel{{
+							HostID: types.StringValue("host0"),
+							Name:   types.StringValue("loadavg5"),
+						}},
+						Role:       []DashboardRoleMetricModel{},
+						Service:    []DashboardServiceMetricModel{},
+						Expression: []DashboardExpressionModel{},
+						Query:      []DashboardQueryModel{},
+						// An empty range is stored for graphs without ranges.
+						Range: []DashboardRangeModelV0{{
+							Relative: []DashboardRelativeRangeModel{},
+							Absolute: []DashboardAbsoluteRangeModel{},
+						}},
+						Layout: layoutV0,
+					},
+					{
+						Title:      types.StringValue("graph with range"),
+						Host:       []DashboardHostMetricModel{},
+						Role:       []DashboardRoleMetricModel{},
+						Service:    []DashboardServiceMetricModel{},
+						Expression: []DashboardExpressionModel{{Expression: types.StringValue("role(service:role, loadavg5)")}},
+						Query:      []DashboardQueryModel{},
+						Range: []DashboardRangeModelV0{{
+							Relative: []DashboardRelativeRangeModel{{
+								Period: types.Int64Value(3600),
+								Offset: types.Int64Value(60),
+							}},
+							Absolute: []DashboardAbsoluteRangeModel{},
+						}},
+						Layout: layoutV0,
+					},
+				},
+				Values: []DashboardValueModelV0{{
+					Title: types.StringValue("value"),
+					Metric: []DashboardMetricModelV0{{
+						Host:       []DashboardHostMetricModel{},
+						Service:    []DashboardServiceMetricModel{},
+						Expression: []DashboardExpressionModel{},
+						Query: []DashboardQueryModel{{
+							Query:  types.StringValue("query"),
+							Legend: types.StringValue("legend"),
+						}},
+					}},
+					FractionSize: types.Int64Value(1),
+					Suffix:       types.StringValue("ms"),
+					Layout:       layoutV0,
+				}},
+				Markdowns: []DashboardMarkdownModelV0{{
+					Title:    types.StringValue("markdown"),
+					Markdown: types.StringValue("# body"),
+					Layout:   layoutV0,
+				}},
+				AlertStatuses: []DashboardAlertStatusModelV0{},
+			},
+
+			wants: DashboardModel{
+				ID:      types.StringValue("d0"),
+				Title:   types.StringValue("dashboard"),
+				Memo:    types.StringValue(""),
+				URLPath: types.StringValue("path"),
+				Graphs: []DashboardGraphModel{
+					{
+						Title: types.StringValue("graph"),
+						Host: &DashboardHostMetricModel{
+							HostID: types.StringValue("host0"),
+							Name:   types.StringValue("loadavg5"),
+						},
+						Layout: newTestDashboardLayoutModel(0, 0, 8, 10),
+					},
+					{
+						Title:      types.StringValue("graph with range"),
+						Expression: &DashboardExpressionModel{Expression: types.StringValue("role(service:role, loadavg5)")},
+						Range: &DashboardRangeModel{
+							Relative: &DashboardRelativeRangeModel{
+								Period: types.Int64Value(3600),
+								Offset: types.Int64Value(60),
+							},
+						},
+						Layout: newTestDashboardLayoutModel(0, 0, 8, 10),
+					},
+				},
+				Values: []DashboardValueModel{{
+					Title: types.StringValue("value"),
+					Metric: &DashboardMetricModel{
+						Query: &DashboardQueryModel{
+							Query:  types.StringValue("query"),
+							Legend: types.StringValue("legend"),
+						},
+					},
+					FractionSize: types.Int64Value(1),
+					Suffix:       types.StringValue("ms"),
+					Layout:       newTestDashboardLayoutModel(0, 0, 8, 10),
+				}},
+				Markdowns: []DashboardMarkdownModel{{
+					Title:    types.StringValue("markdown"),
+					Markdown: types.StringValue("# body"),
+					Layout:   newTestDashboardLayoutModel(0, 0, 8, 10),
+				}},
+				AlertStatuses: []DashboardAlertStatusModel{},
+			},
+		},
+		"no layout": {
+			in: DashboardModelV0{
+				ID:    types.StringValue("d1"),
+				Title: types.StringValue("dashboard"),
+				AlertStatuses: []DashboardAlertStatusModelV0{{
+					Title:        types.StringValue("alert status"),
+					RoleFullname: types.StringValue("service:role"),
+					Layout:       []DashboardLayoutModel{},
+				}},
+			},
+
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := tt.in.Upgrade()
+			if (err != nil) != tt.wantErr {
+				if tt.wantErr {
+					t.Errorf("expect error, but got no error")
+				} else {
+					t.Errorf("unexpected error: %+v", err)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(d, tt.wants); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
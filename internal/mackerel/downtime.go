@@ -0,0 +1,233 @@
+package mackerel
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio/mackerel-client-go"
+)
+
+type DowntimeModel struct {
+	ID                   types.String             `tfsdk:"id"`
+	Name                 types.String             `tfsdk:"name"`
+	Memo                 types.String             `tfsdk:"memo"`
+	Start                types.Int64              `tfsdk:"start"`
+	Duration             types.Int64              `tfsdk:"duration"`
+	Recurrence           *DowntimeRecurrenceModel `tfsdk:"recurrence"`
+	ServiceScopes        []types.String           `tfsdk:"service_scopes"`
+	ServiceExcludeScopes []types.String           `tfsdk:"service_exclude_scopes"`
+	RoleScopes           []types.String           `tfsdk:"role_scopes"`
+	RoleExcludeScopes    []types.String           `tfsdk:"role_exclude_scopes"`
+	MonitorScopes        []types.String           `tfsdk:"monitor_scopes"`
+	MonitorExcludeScopes []types.String           `tfsdk:"monitor_exclude_scopes"`
+}
+
+type DowntimeRecurrenceModel struct {
+	Type     types.String   `tfsdk:"type"`
+	Interval types.Int64    `tfsdk:"interval"`
+	Weekdays []types.String `tfsdk:"weekdays"`
+	Until    types.Int64    `tfsdk:"until"`
+}
+
+var downtimeRecurrenceTypes = map[string]mackerel.DowntimeRecurrenceType{
+	"hourly":  mackerel.DowntimeRecurrenceTypeHourly,
+	"daily":   mackerel.DowntimeRecurrenceTypeDaily,
+	"weekly":  mackerel.DowntimeRecurrenceTypeWeekly,
+	"monthly": mackerel.DowntimeRecurrenceTypeMonthly,
+	"yearly":  mackerel.DowntimeRecurrenceTypeYearly,
+}
+
+var downtimeWeekdays = map[string]mackerel.DowntimeWeekday{
+	"Sunday":    mackerel.DowntimeWeekday(time.Sunday),
+	"Monday":    mackerel.DowntimeWeekday(time.Monday),
+	"Tuesday":   mackerel.DowntimeWeekday(time.Tuesday),
+	"Wednesday": mackerel.DowntimeWeekday(time.Wednesday),
+	"Thursday":  mackerel.DowntimeWeekday(time.Thursday),
+	"Friday":    mackerel.DowntimeWeekday(time.Friday),
+	"Saturday":  mackerel.DowntimeWeekday(time.Saturday),
+}
+
+func DowntimeRecurrenceTypeValidator() validator.String {
+	return stringvalidator.OneOf("hourly", "daily", "weekly", "monthly", "yearly")
+}
+
+func DowntimeWeekdayValidator() validator.String {
+	return stringvalidator.OneOf("Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday")
+}
+
+// Reads a downtime by `id`
+func ReadDowntime(ctx context.Context, client *Client, id string) (DowntimeModel, error) {
+	return readDowntimeInner(ctx, WithContext(ctx, client), id)
+}
+
+type downtimeFinder interface {
+	FindDowntimes() ([]*mackerel.Downtime, error)
+}
+
+func readDowntimeInner(_ context.Context, client downtimeFinder, id string) (DowntimeModel, error) {
+	downtimes, err := client.FindDowntimes()
+	if err != nil {
+		return DowntimeModel{}, err
+	}
+
+	downtimeIdx := slices.IndexFunc(downtimes, func(dt *mackerel.Downtime) bool {
+		return dt.ID == id
+	})
+	if downtimeIdx < 0 {
+		return DowntimeModel{}, newNotFoundError("the ID '%s' does not match any downtime in mackerel.io", id)
+	}
+
+	return newDowntimeModel(*downtimes[downtimeIdx]), nil
+}
+
+// Creates a downtime
+func (m *DowntimeModel) Create(ctx context.Context, client *Client) error {
+	return m.createInner(ctx, WithContext(ctx, client))
+}
+
+type downtimeCreator interface {
+	CreateDowntime(*mackerel.Downtime) (*mackerel.Downtime, error)
+}
+
+func (m *DowntimeModel) createInner(_ context.Context, client downtimeCreator) error {
+	param := m.mackerelDowntime()
+	dt, err := client.CreateDowntime(&param)
+	if err != nil {
+		return err
+	}
+
+	m.ID = types.StringValue(dt.ID)
+	return nil
+}
+
+// Reads the downtime
+func (m *DowntimeModel) Read(ctx context.Context, client *Client) error {
+	data, err := ReadDowntime(ctx, client, m.ID.ValueString())
+	if err != nil {
+		return err
+	}
+	*m = data
+	return nil
+}
+
+// Updates the downtime
+func (m *DowntimeModel) Update(ctx context.Context, client *Client) error {
+	param := m.mackerelDowntime()
+	if _, err := WithContext(ctx, client).UpdateDowntime(m.ID.ValueString(), &param); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Deletes the downtime
+func (m *DowntimeModel) Delete(ctx context.Context, client *Client) error {
+	if _, err := WithContext(ctx, client).DeleteDowntime(m.ID.ValueString()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// API -> Model
+func newDowntimeModel(dt mackerel.Downtime) DowntimeModel {
+	data := DowntimeModel{
+		ID:                   types.StringValue(dt.ID),
+		Name:                 types.StringValue(dt.Name),
+		Memo:                 types.StringValue(dt.Memo),
+		Start:                types.Int64Value(dt.Start),
+		Duration:             types.Int64Value(dt.Duration),
+		ServiceScopes:        normalizeScopes(dt.ServiceScopes),
+		ServiceExcludeScopes: normalizeScopes(dt.ServiceExcludeScopes),
+		RoleScopes:           normalizeScopes(dt.RoleScopes),
+		RoleExcludeScopes:    normalizeScopes(dt.RoleExcludeScopes),
+		MonitorScopes:        normalizeScopes(dt.MonitorScopes),
+		MonitorExcludeScopes: normalizeScopes(dt.MonitorExcludeScopes),
+	}
+
+	if r := dt.Recurrence; r != nil {
+		weekdays := make([]types.String, 0, len(r.Weekdays))
+		for _, weekday := range r.Weekdays {
+			weekdays = append(weekdays, types.StringValue(weekday.String()))
+		}
+		data.Recurrence = &DowntimeRecurrenceModel{
+			Type:     types.StringValue(r.Type.String()),
+			Interval: types.Int64Value(r.Interval),
+			Weekdays: weekdays,
+			Until:    types.Int64Value(r.Until),
+		}
+	}
+
+	return data
+}
+
+// Model -> API
+func (m DowntimeModel) mackerelDowntime() mackerel.Downtime {
+	dt := mackerel.Downtime{
+		ID:                   m.ID.ValueString(),
+		Name:                 m.Name.ValueString(),
+		Memo:                 m.Memo.ValueString(),
+		Start:                m.Start.ValueInt64(),
+		Duration:             m.Duration.ValueInt64(),
+		ServiceScopes:        stringsFromValues(m.ServiceScopes),
+		ServiceExcludeScopes: stringsFromValues(m.ServiceExcludeScopes),
+		RoleScopes:           stringsFromValues(m.RoleScopes),
+		RoleExcludeScopes:    stringsFromValues(m.RoleExcludeScopes),
+		MonitorScopes:        stringsFromValues(m.MonitorScopes),
+		MonitorExcludeScopes: stringsFromValues(m.MonitorExcludeScopes),
+	}
+
+	if r := m.Recurrence; r != nil {
+		weekdays := make([]mackerel.DowntimeWeekday, 0, len(r.Weekdays))
+		for _, weekday := range r.Weekdays {
+			if w, ok := downtimeWeekdays[weekday.ValueString()]; ok {
+				weekdays = append(weekdays, w)
+			}
+		}
+		dt.Recurrence = &mackerel.DowntimeRecurrence{
+			Type:     downtimeRecurrenceTypes[r.Type.ValueString()],
+			Interval: r.Interval.ValueInt64(),
+			Weekdays: weekdays,
+			Until:    r.Until.ValueInt64(),
+		}
+	}
+
+	return dt
+}
+
+// DowntimeModelV0 is the state of the downtime resource before nested attributes,
+// where the recurrence is a list of at most one element to be compatible with the SDK resource.
+type DowntimeModelV0 struct {
+	ID                   types.String              `tfsdk:"id"`
+	Name                 types.String              `tfsdk:"name"`
+	Memo                 types.String              `tfsdk:"memo"`
+	Start                types.Int64               `tfsdk:"start"`
+	Duration             types.Int64               `tfsdk:"duration"`
+	Recurrence           []DowntimeRecurrenceModel `tfsdk:"recurrence"`
+	ServiceScopes        []types.String            `tfsdk:"service_scopes"`
+	ServiceExcludeScopes []types.String            `tfsdk:"service_exclude_scopes"`
+	RoleScopes           []types.String            `tfsdk:"role_scopes"`
+	RoleExcludeScopes    []types.String            `tfsdk:"role_exclude_scopes"`
+	MonitorScopes        []types.String            `tfsdk:"monitor_scopes"`
+	MonitorExcludeScopes []types.String            `tfsdk:"monitor_exclude_scopes"`
+}
+
+// Upgrade converts the state of version 0.
+func (m DowntimeModelV0) Upgrade() DowntimeModel {
+	return DowntimeModel{
+		ID:                   m.ID,
+		Name:                 m.Name,
+		Memo:                 m.Memo,
+		Start:                m.Start,
+		Duration:             m.Duration,
+		Recurrence:           first(m.Recurrence),
+		ServiceScopes:        m.ServiceScopes,
+		ServiceExcludeScopes: m.ServiceExcludeScopes,
+		RoleScopes:           m.RoleScopes,
+		RoleExcludeScopes:    m.RoleExcludeScopes,
+		MonitorScopes:        m.MonitorScopes,
+		MonitorExcludeScopes: m.MonitorExcludeScopes,
+	}
+}
@@ -0,0 +1,253 @@
+package mackerel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio/mackerel-client-go"
+)
+
+// Returns a model without recurrence and scopes
+func emptyDowntimeModel(id, name string) DowntimeModel {
+	return DowntimeModel{
+		ID:                   types.StringValue(id),
+		Name:                 types.StringValue(name),
+		Memo:                 types.StringValue(""),
+		Start:                types.Int64Value(1735707600),
+		Duration:             types.Int64Value(3600),
+		ServiceScopes:        []types.String{},
+		ServiceExcludeScopes: []types.String{},
+		RoleScopes:           []types.String{},
+		RoleExcludeScopes:    []types.String{},
+		MonitorScopes:        []types.String{},
+		MonitorExcludeScopes: []types.String{},
+	}
+}
+
+func Test_Downtime_Read(t *testing.T) {
+	t.Parallel()
+
+	downtimes := []*mackerel.Downtime{
+		{
+			ID:       "dt0",
+			Name:     "maintenance",
+			Memo:     "memo",
+			Start:    1735707600,
+			Duration: 3600,
+			Recurrence: &mackerel.DowntimeRecurrence{
+				Type:     mackerel.DowntimeRecurrenceTypeWeekly,
+				Interval: 2,
+				Weekdays: []mackerel.DowntimeWeekday{
+					mackerel.DowntimeWeekday(time.Monday),
+					mackerel.DowntimeWeekday(time.Friday),
+				},
+			},
+			ServiceScopes: []string{"service"},
+			RoleScopes:    []string{"service: role"},
+		},
+		{
+			ID:       "dt1",
+			Name:     "once",
+			Start:    1735707600,
+			Duration: 3600,
+		},
+	}
+
+	cases := map[string]struct {
+		inID string
+
+		wantErr bool
+		wants   DowntimeModel
+	}{
+		"recurrence": {
+			inID: "dt0",
+
+			wants: func() DowntimeModel {
+				m := emptyDowntimeModel("dt0", "maintenance")
+				m.Memo = types.StringValue("memo")
+				m.Recurrence = &DowntimeRecurrenceModel{
+					Type:     types.StringValue("weekly"),
+					Interval: types.Int64Value(2),
+					Weekdays: []types.String{types.StringValue("Monday"), types.StringValue("Friday")},
+					Until:    types.Int64Value(0),
+				}
+				m.ServiceScopes = []types.String{types.StringValue("service")}
+				m.RoleScopes = []types.String{types.StringValue("service:role")}
+				return m
+			}(),
+		},
+		"no recurrence": {
+			inID: "dt1",
+
+			wants: emptyDowntimeModel("dt1", "once"),
+		},
+		"missing": {
+			inID: "dt2",
+
+			wantErr: true,
+		},
+	}
+
+	ctx := context.Background()
+	client := downtimeFinderFunc(func() ([]*mackerel.Downtime, error) {
+		return downtimes, nil
+	})
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dt, err := readDowntimeInner(ctx, client, tt.inID)
+			if (err != nil) != tt.wantErr {
+				if tt.wantErr {
+					t.Errorf("expect error, but got no error")
+				} else {
+					t.Errorf("unexpected error: %+v", err)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(dt, tt.wants); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+type downtimeFinderFunc func() ([]*mackerel.Downtime, error)
+
+func (f downtimeFinderFunc) FindDowntimes() ([]*mackerel.Downtime, error) {
+	return f()
+}
+
+func Test_Downtime_Create(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		in   DowntimeModel
+		inID string
+
+		wantReq mackerel.Downtime
+	}{
+		"recurrence": {
+			in: func() DowntimeModel {
+				m := emptyDowntimeModel("", "maintenance")
+				m.ID = types.StringUnknown()
+				m.Recurrence = &DowntimeRecurrenceModel{
+					Type:     types.StringValue("daily"),
+					Interval: types.Int64Value(1),
+					Weekdays: []types.String{},
+					Until:    types.Int64Value(1767243600),
+				}
+				m.MonitorExcludeScopes = []types.String{types.StringValue("m0")}
+				return m
+			}(),
+			inID: "dt0",
+
+			wantReq: mackerel.Downtime{
+				Name:     "maintenance",
+				Start:    1735707600,
+				Duration: 3600,
+				Recurrence: &mackerel.DowntimeRecurrence{
+					Type:     mackerel.DowntimeRecurrenceTypeDaily,
+					Interval: 1,
+					Weekdays: []mackerel.DowntimeWeekday{},
+					Until:    1767243600,
+				},
+				ServiceScopes:        []string{},
+				ServiceExcludeScopes: []string{},
+				RoleScopes:           []string{},
+				RoleExcludeScopes:    []string{},
+				MonitorScopes:        []string{},
+				MonitorExcludeScopes: []string{"m0"},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dt := tt.in
+			client := &downtimeCreatorTester{ID: tt.inID}
+			if err := dt.createInner(ctx, client); err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+
+			if diff := cmp.Diff(client.Request, tt.wantReq); diff != "" {
+				t.Errorf("invalid request:\n%s", diff)
+			}
+			if dt.ID.ValueString() != tt.inID {
+				t.Errorf("expected ID to be %s, but got %s", tt.inID, dt.ID)
+			}
+		})
+	}
+}
+
+type downtimeCreatorTester struct {
+	ID string
+
+	Request mackerel.Downtime
+}
+
+func (ct *downtimeCreatorTester) CreateDowntime(param *mackerel.Downtime) (*mackerel.Downtime, error) {
+	ct.Request = *param
+	data := *param
+	data.ID = ct.ID
+	return &data, nil
+}
+
+func Test_DowntimeModelV0_Upgrade(t *testing.T) {
+	t.Parallel()
+
+	recurrence := DowntimeRecurrenceModel{
+		Type:     types.StringValue("hourly"),
+		Interval: types.Int64Value(3),
+		Weekdays: []types.String{},
+		Until:    types.Int64Value(0),
+	}
+	cases := map[string]struct {
+		in    []DowntimeRecurrenceModel
+		wants *DowntimeRecurrenceModel
+	}{
+		"recurrence": {
+			in:    []DowntimeRecurrenceModel{recurrence},
+			wants: &recurrence,
+		},
+		"no recurrence": {
+			in: []DowntimeRecurrenceModel{},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			in := DowntimeModelV0{
+				ID:                   types.StringValue("dt0"),
+				Name:                 types.StringValue("maintenance"),
+				Memo:                 types.StringValue(""),
+				Start:                types.Int64Value(1735707600),
+				Duration:             types.Int64Value(3600),
+				Recurrence:           tt.in,
+				ServiceScopes:        []types.String{},
+				ServiceExcludeScopes: []types.String{},
+				RoleScopes:           []types.String{types.StringValue("service:role")},
+				RoleExcludeScopes:    []types.String{},
+				MonitorScopes:        []types.String{},
+				MonitorExcludeScopes: []types.String{},
+			}
+
+			wants := emptyDowntimeModel("dt0", "maintenance")
+			wants.Recurrence = tt.wants
+			wants.RoleScopes = []types.String{types.StringValue("service:role")}
+
+			if diff := cmp.Diff(in.Upgrade(), wants); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package mackerel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/mackerelio/mackerel-client-go"
+)
+
+var (
+	// ErrNotFound indicates that the object does not exist (anymore) in Mackerel.
+	ErrNotFound = errors.New("not found")
+	// ErrUnauthorized indicates that the API key is missing or invalid.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden indicates that the API key is not permitted the operation,
+	// typically because it is a read-only key.
+	ErrForbidden = errors.New("forbidden")
+	// ErrConflict indicates that the object conflicts with an existing one.
+	ErrConflict = errors.New("conflict")
+	// ErrRateLimited indicates that the request was rejected by the rate limit of the API.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrValidation indicates that the API rejected the request parameters.
+	ErrValidation = errors.New("validation failed")
+)
+
+type notFoundError struct {
+	message string
+}
+
+func newNotFoundError(format string, a ...any) error {
+	return &notFoundError{message: fmt.Sprintf(format, a...)}
+}
+
+func (e *notFoundError) Error() string {
+	return e.message
+}
+
+func (e *notFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// IsNotFound reports whether err means that the object is missing in Mackerel,
+// either because the API responded with 404 or because a lookup in a list failed.
+func IsNotFound(err error) bool {
+	if errors.Is(err, ErrNotFound) {
+		return true
+	}
+	apiErr, ok := AsAPIError(err)
+	return ok && errors.Is(apiErr, ErrNotFound)
+}
+
+// APIError is an error response from the Mackerel API.
+// Use errors.Is with ErrNotFound, ErrForbidden, etc. to branch on the kind of the error.
+type APIError struct {
+	StatusCode int
+	Message    string
+	// Empty if the API did not return it.
+	RequestID string
+
+	Method string
+	Path   string
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	if e.Method != "" {
+		fmt.Fprintf(&b, "%s %s: ", e.Method, e.Path)
+	}
+	fmt.Fprintf(&b, "%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request ID: %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+func (e *APIError) Is(target error) bool {
+	kind := e.kind()
+	return kind != nil && target == kind
+}
+
+func (e *APIError) kind() error {
+	switch e.StatusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrValidation
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		return nil
+	}
+}
+
+// Matches a field name quoted in error messages, e.g. "'name' is required".
+var quotedFieldRegex = regexp.MustCompile("['\"`]([a-zA-Z][a-zA-Z0-9_]*)['\"`]")
+
+// Field returns the attribute name which the error message points at, if any.
+// The API field name is converted to snake case as the schema does, e.g. "notificationInterval" becomes "notification_interval".
+// The name is only a guess from the message; check that the schema has it before pointing at it.
+func (e *APIError) Field() string {
+	if e.kind() != ErrValidation {
+		return ""
+	}
+	m := quotedFieldRegex.FindStringSubmatch(e.Message)
+	if m == nil {
+		return ""
+	}
+	return toSnakeCase(m[1])
+}
+
+// Hint returns a sentence which suggests how to resolve the error.
+func (e *APIError) Hint() string {
+	switch e.kind() {
+	case ErrUnauthorized:
+		return "Check that the API key is valid."
+	case ErrForbidden:
+		return "The API key is not permitted the operation. Write operations require an API key with write permission."
+	case ErrConflict:
+		return "The object may already exist or may be modified concurrently."
+	case ErrRateLimited:
+		return "The API rate limit was exceeded. Retry later, or lower requests_per_minute or raise max_retries."
+	case ErrValidation:
+		return "The API rejected the configuration."
+	default:
+		return ""
+	}
+}
+
+// AsAPIError finds the API error in err.
+// Errors of mackerel-client-go are converted, though they do not have a request ID.
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	var clientErr *mackerel.APIError
+	if errors.As(err, &clientErr) {
+		return &APIError{StatusCode: clientErr.StatusCode, Message: clientErr.Message}, true
+	}
+	return nil, false
+}
+
+func toSnakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if 'A' <= r && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+const requestIDHeader = "X-Request-Id"
+
+// Error responses are small, so the rest of a large body is ignored.
+const maxErrorBodySize = 64 << 10
+
+// apiErrorTransport turns error responses into *APIError,
+// since mackerel-client-go drops the response headers and the request.
+type apiErrorTransport struct {
+	base http.RoundTripper
+}
+
+func (t *apiErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	// Redirects and other non-error responses are left to the HTTP client.
+	if err != nil || resp.StatusCode < 400 {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	return nil, &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    extractErrorMessage(body),
+		RequestID:  resp.Header.Get(requestIDHeader),
+		Method:     req.Method,
+		Path:       req.URL.Path,
+	}
+}
+
+// Accepts both `{"error": "message"}` and `{"error": {"message": "message"}}` as mackerel-client-go does.
+func extractErrorMessage(body []byte) string {
+	var data struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil || data.Error == nil {
+		return ""
+	}
+	var message string
+	if err := json.Unmarshal(data.Error, &message); err == nil {
+		return message
+	}
+	var detail struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(data.Error, &detail); err == nil {
+		return detail.Message
+	}
+	return ""
+}
+
+// NewErrorDiagnostic explains err for the framework provider.
+func NewErrorDiagnostic(summary string, err error) diag.Diagnostic {
+	return NewAttributeErrorDiagnostic(context.Background(), summary, err, nil)
+}
+
+// AttributeSchema is the schema which the attributes of a diagnostic are looked up in,
+// such as tfsdk.Plan.Schema.
+type AttributeSchema interface {
+	TypeAtPath(context.Context, path.Path) (attr.Type, diag.Diagnostics)
+}
+
+// NewAttributeErrorDiagnostic explains err for the framework provider,
+// pointing at the attribute if the API says so and s has the attribute at the top level.
+func NewAttributeErrorDiagnostic(ctx context.Context, summary string, err error, s AttributeSchema) diag.Diagnostic {
+	if roErr, ok := AsReadOnlyError(err); ok {
+		return diag.NewErrorDiagnostic(summary, roErr.Error())
+	}
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		return diag.NewErrorDiagnostic(summary, err.Error())
+	}
+	detail := apiErr.Error()
+	if hint := apiErr.Hint(); hint != "" {
+		detail += "\n\n" + hint
+	}
+	if field := apiErr.Field(); field != "" && s != nil {
+		if _, diags := s.TypeAtPath(ctx, path.Root(field)); !diags.HasError() {
+			return diag.NewAttributeErrorDiagnostic(path.Root(field), summary, detail)
+		}
+	}
+	return diag.NewErrorDiagnostic(summary, detail)
+}
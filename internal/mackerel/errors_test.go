@@ -0,0 +1,325 @@
+package mackerel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio/mackerel-client-go"
+)
+
+func Test_IsNotFound(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		in   error
+		want bool
+	}{
+		"nil": {
+			in:   nil,
+			want: false,
+		},
+		"ErrNotFound": {
+			in:   ErrNotFound,
+			want: true,
+		},
+		"lookup failure": {
+			in:   newNotFoundError("the ID '%s' does not match any channel in mackerel.io", "ch0"),
+			want: true,
+		},
+		"wrapped lookup failure": {
+			in:   fmt.Errorf("failed to read: %w", newNotFoundError("missing")),
+			want: true,
+		},
+		"404": {
+			in:   &mackerel.APIError{StatusCode: http.StatusNotFound, Message: "Monitor not found"},
+			want: true,
+		},
+		"wrapped 404": {
+			in:   fmt.Errorf("failed to read: %w", &mackerel.APIError{StatusCode: http.StatusNotFound}),
+			want: true,
+		},
+		"500": {
+			in:   &mackerel.APIError{StatusCode: http.StatusInternalServerError},
+			want: false,
+		},
+		"other error": {
+			in:   errors.New("connection refused"),
+			want: false,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := IsNotFound(tt.in); got != tt.want {
+				t.Errorf("expected to be %t, but got %t", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_readInner_notFound(t *testing.T) {
+	t.Parallel()
+
+	apiNotFound := &mackerel.APIError{StatusCode: http.StatusNotFound, Message: "not found"}
+
+	cases := map[string]func(context.Context) error{
+		"service": func(context.Context) error {
+			_, err := readServiceInner(serviceFinderFunc(func() ([]*mackerel.Service, error) {
+				return []*mackerel.Service{{Name: "service0"}}, nil
+			}), "service1")
+			return err
+		},
+		"role": func(ctx context.Context) error {
+			_, err := readRoleInner(ctx, roleFinderFunc(func(string) ([]*mackerel.Role, error) {
+				return []*mackerel.Role{{Name: "role0"}}, nil
+			}), "service0", "role1")
+			return err
+		},
+		"role of missing service": func(ctx context.Context) error {
+			_, err := readRoleInner(ctx, roleFinderFunc(func(string) ([]*mackerel.Role, error) {
+				return nil, apiNotFound
+			}), "service0", "role0")
+			return err
+		},
+		"notification group": func(ctx context.Context) error {
+			_, err := readNotificationGroupInner(ctx, notificationGroupFinderFunc(func() ([]*mackerel.NotificationGroup, error) {
+				return []*mackerel.NotificationGroup{{ID: "ng0"}}, nil
+			}), "ng1")
+			return err
+		},
+		"service metadata": func(ctx context.Context) error {
+			_, err := readServiceMetadataInner(ctx, serviceMetadataGetterFunc(func(string, string) (*mackerel.ServiceMetaDataResp, error) {
+				return nil, apiNotFound
+			}), ServiceMetadataModel{ID: types.StringValue("service0/ns0")})
+			return err
+		},
+		"role metadata": func(context.Context) error {
+			_, err := readRoleMetadata(roleMetadataReaderFunc(func(string, string, string) (*mackerel.RoleMetaDataResp, error) {
+				return nil, apiNotFound
+			}), "service0", "role0", "ns0")
+			return err
+		},
+	}
+
+	ctx := context.Background()
+	for name, read := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := read(ctx)
+			if err == nil {
+				t.Fatal("expected error, but got no error")
+			}
+			if !IsNotFound(err) {
+				t.Errorf("expected not found error, but got: %+v", err)
+			}
+		})
+	}
+}
+
+func Test_APIError_kind(t *testing.T) {
+	t.Parallel()
+
+	kinds := []error{ErrNotFound, ErrUnauthorized, ErrForbidden, ErrConflict, ErrRateLimited, ErrValidation}
+	cases := map[int]error{
+		http.StatusBadRequest:          ErrValidation,
+		http.StatusUnauthorized:        ErrUnauthorized,
+		http.StatusForbidden:           ErrForbidden,
+		http.StatusNotFound:            ErrNotFound,
+		http.StatusConflict:            ErrConflict,
+		http.StatusUnprocessableEntity: ErrValidation,
+		http.StatusTooManyRequests:     ErrRateLimited,
+		http.StatusInternalServerError: nil,
+	}
+
+	for status, want := range cases {
+		err := fmt.Errorf("wrapped: %w", &APIError{StatusCode: status})
+		for _, kind := range kinds {
+			if got := errors.Is(err, kind); got != (kind == want) {
+				t.Errorf("%d: expected errors.Is(err, %v) to be %t, but got %t", status, kind, kind == want, got)
+			}
+		}
+	}
+}
+
+func Test_APIError_Field(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		in   *APIError
+		want string
+	}{
+		"single quoted": {
+			in:   &APIError{StatusCode: http.StatusBadRequest, Message: "'name' is required"},
+			want: "name",
+		},
+		"camel case": {
+			in:   &APIError{StatusCode: http.StatusBadRequest, Message: "`notificationInterval` must be at least 10"},
+			want: "notification_interval",
+		},
+		"no field": {
+			in:   &APIError{StatusCode: http.StatusBadRequest, Message: "invalid parameter"},
+			want: "",
+		},
+		"not validation": {
+			in:   &APIError{StatusCode: http.StatusConflict, Message: "'name' is already used"},
+			want: "",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.in.Field(); got != tt.want {
+				t.Errorf("expected '%s', but got '%s'", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_apiErrorTransport(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-0")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"message":"Permission denied"}}`))
+	}))
+	defer ts.Close()
+
+	client, err := mackerel.NewClientWithOptions("Test_apiErrorTransport", ts.URL, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetupTransport(client, TransportOptions{})
+
+	_, err = client.DeleteService("service0")
+	if !errors.Is(err, ErrForbidden) {
+		t.Fatalf("expected forbidden error, but got: %+v", err)
+	}
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		t.Fatalf("expected API error, but got: %+v", err)
+	}
+	want := &APIError{
+		StatusCode: http.StatusForbidden,
+		Message:    "Permission denied",
+		RequestID:  "req-0",
+		Method:     http.MethodDelete,
+		Path:       "/api/v0/services/service0",
+	}
+	if diff := cmp.Diff(want, apiErr); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func Test_apiErrorTransport_notError(t *testing.T) {
+	t.Parallel()
+
+	for _, status := range []int{http.StatusOK, http.StatusFound, http.StatusNotModified} {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+		defer ts.Close()
+
+		transport := &apiErrorTransport{base: http.DefaultTransport}
+		req, err := http.NewRequest(http.MethodGet, ts.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			t.Errorf("%d: expected no error, but got: %+v", status, err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode != status {
+			t.Errorf("expected status %d, but got %d", status, resp.StatusCode)
+		}
+	}
+}
+
+func Test_NewAttributeErrorDiagnostic(t *testing.T) {
+	t.Parallel()
+
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"memo": schema.StringAttribute{Optional: true},
+			"host_metric": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"warning": schema.StringAttribute{Optional: true},
+				},
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		message string
+		schema  AttributeSchema
+		want    path.Path
+	}{
+		"top level attribute": {
+			message: "'memo' is too long",
+			schema:  s,
+			want:    path.Root("memo"),
+		},
+		"nested attribute": {
+			message: "'warning' must be a number",
+			schema:  s,
+		},
+		"unknown attribute": {
+			message: "'monitorType' is invalid",
+			schema:  s,
+		},
+		"no schema": {
+			message: "'memo' is too long",
+		},
+	}
+
+	ctx := context.Background()
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			d := NewAttributeErrorDiagnostic(ctx, "Unable to create Monitor", &APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    tt.message,
+				RequestID:  "req-0",
+			}, tt.schema)
+			if detail := d.Detail(); !strings.Contains(detail, "400") || !strings.Contains(detail, "req-0") {
+				t.Errorf("expected detail to contain the status and the request ID, but got: %s", detail)
+			}
+			withPath, ok := d.(diag.DiagnosticWithPath)
+			if ok != (len(tt.want.Steps()) > 0) {
+				t.Fatalf("unexpected diagnostic: %+v", d)
+			}
+			if ok && !withPath.Path().Equal(tt.want) {
+				t.Errorf("expected to point at %s, but got %s", tt.want, withPath.Path())
+			}
+		})
+	}
+}
+
+func Test_NewErrorDiagnostic(t *testing.T) {
+	t.Parallel()
+
+	d := NewErrorDiagnostic("Unable to read Service", errors.New("connection refused"))
+	if _, ok := d.(diag.DiagnosticWithPath); ok || d.Detail() != "connection refused" {
+		t.Errorf("unexpected diagnostic: %+v", d)
+	}
+}
@@ -0,0 +1,178 @@
+package mackerel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const listCacheTTL = 10 * time.Second
+
+// Collection endpoints which Reads fetch entirely and scan.
+var cachedListPathRegex = regexp.MustCompile(
+	`^/api/v0/(services|services/[^/]+/roles|channels|downtimes|notification-groups|alert-group-settings|monitors|dashboards|aws-integrations)$`,
+)
+
+// List caches are shared in the same way as rate limiters.
+var listCaches sync.Map // map[sharedKey]*listCache
+
+func sharedListCache(apiBase, apiKey string) *listCache {
+	c, _ := listCaches.LoadOrStore(sharedKey{apiBase: apiBase, apiKey: apiKey}, newListCache(listCacheTTL))
+	return c.(*listCache)
+}
+
+// listCache coalesces concurrent GET requests to collection endpoints and
+// keeps successful responses for a short time, so that refreshing N resources
+// of the same kind issues a single list request.
+// Any other request to the same path prefix invalidates the cached responses.
+type listCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*listCacheEntry
+}
+
+type listCacheEntry struct {
+	done    chan struct{}
+	resp    *cachedResponse
+	err     error
+	expires time.Time
+}
+
+type cachedResponse struct {
+	status     string
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+func newListCache(ttl time.Duration) *listCache {
+	return &listCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]*listCacheEntry),
+	}
+}
+
+type listCacheTransport struct {
+	base  http.RoundTripper
+	cache *listCache
+}
+
+func (t *listCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := req.URL.Path
+	if req.Method != http.MethodGet {
+		t.cache.invalidate(path)
+		defer t.cache.invalidate(path)
+		return t.base.RoundTrip(req)
+	}
+	if req.URL.RawQuery != "" || !cachedListPathRegex.MatchString(path) {
+		return t.base.RoundTrip(req)
+	}
+	return t.cache.get(req, t.base)
+}
+
+func (c *listCache) get(req *http.Request, base http.RoundTripper) (*http.Response, error) {
+	ctx := req.Context()
+	key := req.URL.Path
+	for {
+		c.mu.Lock()
+		e, ok := c.entries[key]
+		if !ok {
+			e = &listCacheEntry{done: make(chan struct{})}
+			c.entries[key] = e
+			c.mu.Unlock()
+			return c.fetch(req, base, key, e)
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-e.done:
+		}
+
+		if e.err != nil {
+			// The leading request was canceled by its own context.
+			if errors.Is(e.err, context.Canceled) || errors.Is(e.err, context.DeadlineExceeded) {
+				continue
+			}
+			return nil, e.err
+		}
+		if c.now().Before(e.expires) || e.expires.IsZero() {
+			return e.resp.response(req), nil
+		}
+
+		// expired
+		c.mu.Lock()
+		if c.entries[key] == e {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+	}
+}
+
+func (c *listCache) fetch(req *http.Request, base http.RoundTripper, key string, e *listCacheEntry) (*http.Response, error) {
+	resp, err := base.RoundTrip(req)
+	if err == nil {
+		var body []byte
+		body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err == nil {
+			e.resp = &cachedResponse{
+				status:     resp.Status,
+				statusCode: resp.StatusCode,
+				header:     resp.Header.Clone(),
+				body:       body,
+			}
+		}
+	}
+	e.err = err
+
+	c.mu.Lock()
+	if err == nil && e.resp.statusCode == http.StatusOK {
+		e.expires = c.now().Add(c.ttl)
+	} else if c.entries[key] == e {
+		// Waiters receive the same result, but it is not cached.
+		delete(c.entries, key)
+	}
+	close(e.done)
+	c.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	return e.resp.response(req), nil
+}
+
+// Drops cached responses of collections which contain or are contained in the path.
+func (c *listCache) invalidate(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if strings.HasPrefix(path, key) || strings.HasPrefix(key, path) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (r *cachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        r.status,
+		StatusCode:    r.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.body)),
+		ContentLength: int64(len(r.body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,139 @@
+package mackerel
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type listCacheTestServer struct {
+	*httptest.Server
+	hits    sync.Map // map[string]*atomic.Int32
+	release chan struct{}
+}
+
+func newListCacheTestServer(t *testing.T) *listCacheTestServer {
+	t.Helper()
+
+	s := &listCacheTestServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n, _ := s.hits.LoadOrStore(r.Method+" "+r.URL.Path, &atomic.Int32{})
+		n.(*atomic.Int32).Add(1)
+		if s.release != nil {
+			<-s.release
+		}
+		if r.URL.Path == "/api/v0/channels/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, `{"channels":[]}`)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *listCacheTestServer) count(methodAndPath string) int32 {
+	n, ok := s.hits.Load(methodAndPath)
+	if !ok {
+		return 0
+	}
+	return n.(*atomic.Int32).Load()
+}
+
+func (s *listCacheTestServer) do(t *testing.T, transport http.RoundTripper, method, path string) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, s.URL+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Errorf("unexpected error: %+v", err)
+		return
+	}
+	defer resp.Body.Close()
+	if _, err := io.ReadAll(resp.Body); err != nil {
+		t.Errorf("failed to read body: %+v", err)
+	}
+}
+
+func Test_listCacheTransport_coalesce(t *testing.T) {
+	t.Parallel()
+
+	s := newListCacheTestServer(t)
+	s.release = make(chan struct{})
+	transport := &listCacheTransport{base: http.DefaultTransport, cache: newListCache(time.Minute)}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.do(t, transport, http.MethodGet, "/api/v0/channels")
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(s.release)
+	wg.Wait()
+
+	if n := s.count("GET /api/v0/channels"); n != 1 {
+		t.Errorf("expected concurrent requests to be coalesced, but got %d requests", n)
+	}
+}
+
+func Test_listCacheTransport(t *testing.T) {
+	t.Parallel()
+
+	s := newListCacheTestServer(t)
+	now := time.Now()
+	cache := newListCache(10 * time.Second)
+	cache.now = func() time.Time { return now }
+	transport := &listCacheTransport{base: http.DefaultTransport, cache: cache}
+
+	s.do(t, transport, http.MethodGet, "/api/v0/channels")
+	s.do(t, transport, http.MethodGet, "/api/v0/channels")
+	if n := s.count("GET /api/v0/channels"); n != 1 {
+		t.Errorf("expected to be cached, but got %d requests", n)
+	}
+
+	// expired
+	now = now.Add(11 * time.Second)
+	s.do(t, transport, http.MethodGet, "/api/v0/channels")
+	if n := s.count("GET /api/v0/channels"); n != 2 {
+		t.Errorf("expected to be expired, but got %d requests", n)
+	}
+
+	// invalidated by a mutation
+	s.do(t, transport, http.MethodDelete, "/api/v0/channels/ch0")
+	s.do(t, transport, http.MethodGet, "/api/v0/channels")
+	if n := s.count("GET /api/v0/channels"); n != 3 {
+		t.Errorf("expected to be invalidated, but got %d requests", n)
+	}
+
+	// nested collection
+	s.do(t, transport, http.MethodGet, "/api/v0/services/svc0/roles")
+	s.do(t, transport, http.MethodDelete, "/api/v0/services/svc0")
+	s.do(t, transport, http.MethodGet, "/api/v0/services/svc0/roles")
+	if n := s.count("GET /api/v0/services/svc0/roles"); n != 2 {
+		t.Errorf("expected to be invalidated by the parent, but got %d requests", n)
+	}
+
+	// not a collection
+	s.do(t, transport, http.MethodGet, "/api/v0/monitors/m0")
+	s.do(t, transport, http.MethodGet, "/api/v0/monitors/m0")
+	if n := s.count("GET /api/v0/monitors/m0"); n != 2 {
+		t.Errorf("expected not to be cached, but got %d requests", n)
+	}
+
+	// error responses
+	s.do(t, transport, http.MethodGet, "/api/v0/channels/missing")
+	s.do(t, transport, http.MethodGet, "/api/v0/channels/missing")
+	if n := s.count("GET /api/v0/channels/missing"); n != 2 {
+		t.Errorf("expected not to be cached, but got %d requests", n)
+	}
+}
@@ -0,0 +1,181 @@
+package mackerel
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+)
+
+const (
+	logSubsystem = "mackerel"
+
+	redacted = "***"
+)
+
+// Headers whose values are never logged.
+var sensitiveHeaders = []string{
+	"X-Api-Key",
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// JSON fields whose values are never logged, wherever they appear in a body.
+var sensitiveFields = map[string]bool{
+	"apiKey":     true,
+	"apikey":     true,
+	"secretKey":  true,
+	"externalId": true,
+	// Headers of external monitors, which may contain credentials of the monitored site.
+	"headers": true,
+}
+
+// JSON fields whose values are never logged in the bodies of the paths with the prefix.
+var sensitiveFieldsByPath = map[string]map[string]bool{
+	// Webhook URLs of Slack, Chatwork, etc. contain their tokens.
+	"/api/v0/channels": {"url": true},
+}
+
+// loggingTransport logs requests and responses with tflog, redacting the API key and the secrets in the bodies.
+type loggingTransport struct {
+	base http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := tflog.NewSubsystem(req.Context(), logSubsystem)
+	if apiKey := req.Header.Get("X-Api-Key"); apiKey != "" {
+		// In case the key appears somewhere else, e.g. in an error message.
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, logSubsystem, apiKey)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, logSubsystem, apiKey)
+	}
+	if id, err := uuid.GenerateUUID(); err == nil {
+		ctx = tflog.SubsystemSetField(ctx, logSubsystem, logging.FieldHttpTransactionId, id)
+	}
+
+	reqBody, err := peekRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	fields := headerFields(req.Header)
+	fields[logging.FieldHttpOperationType] = logging.OperationHttpRequest
+	fields[logging.FieldHttpRequestMethod] = req.Method
+	fields[logging.FieldHttpRequestUri] = req.URL.RequestURI()
+	fields[logging.FieldHttpRequestBody] = redactBody(req.URL.Path, reqBody)
+	tflog.SubsystemDebug(ctx, logSubsystem, "Sending HTTP Request", fields)
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		tflog.SubsystemDebug(ctx, logSubsystem, "HTTP Request failed", map[string]any{"error": err.Error()})
+		return resp, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	fields = headerFields(resp.Header)
+	fields[logging.FieldHttpOperationType] = logging.OperationHttpResponse
+	fields[logging.FieldHttpResponseProtoVersion] = resp.Proto
+	fields[logging.FieldHttpResponseStatusCode] = resp.StatusCode
+	fields[logging.FieldHttpResponseStatusReason] = http.StatusText(resp.StatusCode)
+	fields[logging.FieldHttpResponseBody] = redactBody(req.URL.Path, respBody)
+	tflog.SubsystemDebug(ctx, logSubsystem, "Received HTTP Response", fields)
+
+	return resp, nil
+}
+
+// Returns the body of req, leaving req.Body readable.
+func peekRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+	b, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(b))
+	return b, nil
+}
+
+func headerFields(header http.Header) map[string]any {
+	fields := make(map[string]any, len(header)+5)
+	for name, values := range header {
+		fields[name] = strings.Join(values, ", ")
+	}
+	for _, name := range sensitiveHeaders {
+		if _, ok := fields[name]; ok {
+			fields[name] = redacted
+		}
+	}
+	return fields
+}
+
+// Returns body with the values of the sensitive fields replaced.
+// Bodies other than JSON are not logged, since they cannot be redacted.
+func redactBody(path string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return fmt.Sprintf("(%d bytes of non-JSON body)", len(body))
+	}
+	fields := sensitiveFields
+	for prefix, byPath := range sensitiveFieldsByPath {
+		if strings.HasPrefix(path, prefix) {
+			fields = make(map[string]bool, len(sensitiveFields)+len(byPath))
+			for k := range sensitiveFields {
+				fields[k] = true
+			}
+			for k := range byPath {
+				fields[k] = true
+			}
+		}
+	}
+	b, err := json.Marshal(redactValue(v, fields))
+	if err != nil {
+		return fmt.Sprintf("(%d bytes of body)", len(body))
+	}
+	return string(b)
+}
+
+func redactValue(v any, fields map[string]bool) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, val := range v {
+			if fields[k] {
+				v[k] = redacted
+			} else {
+				v[k] = redactValue(val, fields)
+			}
+		}
+		return v
+	case []any:
+		for i, val := range v {
+			v[i] = redactValue(val, fields)
+		}
+		return v
+	default:
+		return v
+	}
+}
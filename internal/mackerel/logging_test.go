@@ -0,0 +1,108 @@
+package mackerel
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func Test_loggingTransport(t *testing.T) {
+	t.Parallel()
+
+	// Echoes the request body, so that the secrets appear in both of the request and the response.
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret-cookie")
+		_, _ = io.Copy(w, r.Body)
+	}))
+	t.Cleanup(ts.Close)
+
+	cases := map[string]struct {
+		path    string
+		body    string
+		secrets []string
+		keep    []string
+	}{
+		"aws integration": {
+			path:    "/api/v0/aws-integrations",
+			body:    `{"name":"aws","key":"AKIA","secretKey":"secret-key","roleArn":"","externalId":"external-id"}`,
+			secrets: []string{"secret-key", "external-id"},
+			keep:    []string{`"name":"aws"`},
+		},
+		"external monitor": {
+			path:    "/api/v0/monitors",
+			body:    `{"type":"external","url":"https://example.com/health","headers":[{"name":"Authorization","value":"Bearer monitor-token"}]}`,
+			secrets: []string{"monitor-token"},
+			keep:    []string{"https://example.com/health"},
+		},
+		"webhook channel": {
+			path:    "/api/v0/channels",
+			body:    `{"channels":[{"type":"slack","url":"https://hooks.slack.com/services/slack-token"}]}`,
+			secrets: []string{"slack-token"},
+			keep:    []string{`"type":"slack"`},
+		},
+		"non-JSON body": {
+			path:    "/api/v0/services",
+			body:    `secretKey=plain-secret`,
+			secrets: []string{"plain-secret"},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &out)
+			client := &http.Client{Transport: &loggingTransport{base: http.DefaultTransport}}
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, ts.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("X-Api-Key", "api-key-secret")
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			// The bodies are passed through as is.
+			got, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.body {
+				t.Errorf("expected the body to be passed through, but got: %s", got)
+			}
+
+			raw := out.String()
+			entries, err := tflogtest.MultilineJSONDecode(&out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != 2 {
+				t.Fatalf("expected a request and a response to be logged, but got: %+v", entries)
+			}
+			// Checks the decoded entries too, since the bodies are escaped in the raw output.
+			logged := raw + fmt.Sprint(entries)
+			for _, secret := range append(tt.secrets, "api-key-secret", "secret-cookie") {
+				if strings.Contains(logged, secret) {
+					t.Errorf("expected %q to be redacted, but got: %s", secret, logged)
+				}
+			}
+			for _, s := range tt.keep {
+				if !strings.Contains(logged, s) {
+					t.Errorf("expected %q to be logged, but got: %s", s, logged)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,518 @@
+package mackerel
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio/mackerel-client-go"
+
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/typeutil"
+)
+
+// MonitorTypes are the names of the attributes of monitor rules, exactly one of which is set.
+var MonitorTypes = []string{
+	"host_metric",
+	"connectivity",
+	"service_metric",
+	"external",
+	"expression",
+	"anomaly_detection",
+	"query",
+}
+
+// ThresholdMonitorTypes are the monitor types which have operator, warning and critical.
+var ThresholdMonitorTypes = []string{
+	"host_metric",
+	"service_metric",
+	"expression",
+	"query",
+}
+
+// Exactly one of the monitor types is non-nil.
+type MonitorModel struct {
+	ID                   types.String `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	Memo                 types.String `tfsdk:"memo"`
+	IsMute               types.Bool   `tfsdk:"is_mute"`
+	NotificationInterval types.Int64  `tfsdk:"notification_interval"`
+
+	HostMetricMonitor       *MonitorHostMetricModel       `tfsdk:"host_metric"`
+	ConnectivityMonitor     *MonitorConnectivityModel     `tfsdk:"connectivity"`
+	ServiceMetricMonitor    *MonitorServiceMetricModel    `tfsdk:"service_metric"`
+	ExternalMonitor         *MonitorExternalModel         `tfsdk:"external"`
+	ExpressionMonitor       *MonitorExpressionModel       `tfsdk:"expression"`
+	AnomalyDetectionMonitor *MonitorAnomalyDetectionModel `tfsdk:"anomaly_detection"`
+	QueryMonitor            *MonitorQueryModel            `tfsdk:"query"`
+}
+
+type MonitorHostMetricModel struct {
+	Metric           types.String         `tfsdk:"metric"`
+	Operator         types.String         `tfsdk:"operator"`
+	Warning          typeutil.FloatString `tfsdk:"warning"`
+	Critical         typeutil.FloatString `tfsdk:"critical"`
+	Duration         types.Int64          `tfsdk:"duration"`
+	MaxCheckAttempts types.Int64          `tfsdk:"max_check_attempts"`
+	Scopes           []types.String       `tfsdk:"scopes"`
+	ExcludeScopes    []types.String       `tfsdk:"exclude_scopes"`
+}
+
+type MonitorConnectivityModel struct {
+	Scopes            []types.String `tfsdk:"scopes"`
+	ExcludeScopes     []types.String `tfsdk:"exclude_scopes"`
+	AlertStatusOnGone types.String   `tfsdk:"alert_status_on_gone"`
+}
+
+type MonitorServiceMetricModel struct {
+	Service                 types.String         `tfsdk:"service"`
+	Metric                  types.String         `tfsdk:"metric"`
+	Operator                types.String         `tfsdk:"operator"`
+	Warning                 typeutil.FloatString `tfsdk:"warning"`
+	Critical                typeutil.FloatString `tfsdk:"critical"`
+	Duration                types.Int64          `tfsdk:"duration"`
+	MaxCheckAttempts        types.Int64          `tfsdk:"max_check_attempts"`
+	MissingDurationWarning  types.Int64          `tfsdk:"missing_duration_warning"`
+	MissingDurationCritical types.Int64          `tfsdk:"missing_duration_critical"`
+}
+
+type MonitorExternalModel struct {
+	Method                          types.String            `tfsdk:"method"`
+	URL                             types.String            `tfsdk:"url"`
+	MaxCheckAttempts                types.Int64             `tfsdk:"max_check_attempts"`
+	Service                         types.String            `tfsdk:"service"`
+	ResponseTimeCritical            types.Float64           `tfsdk:"response_time_critical"`
+	ResponseTimeWarning             types.Float64           `tfsdk:"response_time_warning"`
+	ResponseTimeDuration            types.Int64             `tfsdk:"response_time_duration"`
+	RequestBody                     types.String            `tfsdk:"request_body"`
+	ContainsString                  types.String            `tfsdk:"contains_string"`
+	CertificationExpirationCritical types.Int64             `tfsdk:"certification_expiration_critical"`
+	CertificationExpirationWarning  types.Int64             `tfsdk:"certification_expiration_warning"`
+	SkipCertificateVerification     types.Bool              `tfsdk:"skip_certificate_verification"`
+	Headers                         map[string]types.String `tfsdk:"headers"`
+	FollowRedirect                  types.Bool              `tfsdk:"follow_redirect"`
+}
+
+type MonitorExpressionModel struct {
+	Expression types.String         `tfsdk:"expression"`
+	Operator   types.String         `tfsdk:"operator"`
+	Warning    typeutil.FloatString `tfsdk:"warning"`
+	Critical   typeutil.FloatString `tfsdk:"critical"`
+}
+
+type MonitorAnomalyDetectionModel struct {
+	WarningSensitivity  types.String   `tfsdk:"warning_sensitivity"`
+	CriticalSensitivity types.String   `tfsdk:"critical_sensitivity"`
+	MaxCheckAttempts    types.Int64    `tfsdk:"max_check_attempts"`
+	TrainingPeriodFrom  types.Int64    `tfsdk:"training_period_from"`
+	Scopes              []types.String `tfsdk:"scopes"`
+}
+
+type MonitorQueryModel struct {
+	Query    types.String         `tfsdk:"query"`
+	Legend   types.String         `tfsdk:"legend"`
+	Operator types.String         `tfsdk:"operator"`
+	Warning  typeutil.FloatString `tfsdk:"warning"`
+	Critical typeutil.FloatString `tfsdk:"critical"`
+}
+
+func MonitorOperatorValidator() validator.String {
+	return stringvalidator.OneOf(">", "<")
+}
+
+func MonitorSensitivityValidator() validator.String {
+	return stringvalidator.OneOf("insensitive", "normal", "sensitive")
+}
+
+// Checks that the warning threshold is reached before the critical one under the operator;
+// for ">", warning must be less than or equal to critical, and vice versa for "<".
+func ValidateMonitorThresholdOrder(operator string, warning, critical float64) error {
+	switch operator {
+	case ">":
+		if warning > critical {
+			return fmt.Errorf("warning (%g) must be less than or equal to critical (%g) when operator is \">\"", warning, critical)
+		}
+	case "<":
+		if warning < critical {
+			return fmt.Errorf("warning (%g) must be greater than or equal to critical (%g) when operator is \"<\"", warning, critical)
+		}
+	}
+	return nil
+}
+
+// Reads a monitor by `id`
+func ReadMonitor(ctx context.Context, client *Client, id string) (MonitorModel, error) {
+	return readMonitorInner(ctx, WithContext(ctx, client), id)
+}
+
+type monitorGetter interface {
+	GetMonitor(string) (mackerel.Monitor, error)
+}
+
+func readMonitorInner(_ context.Context, client monitorGetter, id string) (MonitorModel, error) {
+	monitor, err := client.GetMonitor(id)
+	if err != nil {
+		return MonitorModel{}, err
+	}
+	return newMonitorModel(monitor)
+}
+
+// Creates a monitor
+func (m *MonitorModel) Create(ctx context.Context, client *Client) error {
+	return m.createInner(ctx, WithContext(ctx, client))
+}
+
+type monitorCreator interface {
+	CreateMonitor(mackerel.Monitor) (mackerel.Monitor, error)
+}
+
+func (m *MonitorModel) createInner(_ context.Context, client monitorCreator) error {
+	param, err := m.mackerelMonitor()
+	if err != nil {
+		return err
+	}
+	monitor, err := client.CreateMonitor(param)
+	if err != nil {
+		return err
+	}
+
+	m.ID = types.StringValue(monitor.MonitorID())
+	return nil
+}
+
+// Reads the monitor
+func (m *MonitorModel) Read(ctx context.Context, client *Client) error {
+	data, err := ReadMonitor(ctx, client, m.ID.ValueString())
+	if err != nil {
+		return err
+	}
+	*m = data
+	return nil
+}
+
+// Updates the monitor
+func (m *MonitorModel) Update(ctx context.Context, client *Client) error {
+	param, err := m.mackerelMonitor()
+	if err != nil {
+		return err
+	}
+	if _, err := WithContext(ctx, client).UpdateMonitor(m.ID.ValueString(), param); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Deletes the monitor
+func (m *MonitorModel) Delete(ctx context.Context, client *Client) error {
+	if _, err := WithContext(ctx, client).DeleteMonitor(m.ID.ValueString()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// API -> Model
+func newMonitorModel(monitor mackerel.Monitor) (MonitorModel, error) {
+	data := MonitorModel{
+		ID:   types.StringValue(monitor.MonitorID()),
+		Name: types.StringValue(monitor.MonitorName()),
+	}
+
+	switch m := monitor.(type) {
+	case *mackerel.MonitorHostMetric:
+		data.setCommon(m.Memo, m.IsMute, m.NotificationInterval)
+		data.HostMetricMonitor = &MonitorHostMetricModel{
+			Metric:           types.StringValue(m.Metric),
+			Operator:         types.StringValue(m.Operator),
+			Warning:          floatStringFromPointer(m.Warning),
+			Critical:         floatStringFromPointer(m.Critical),
+			Duration:         types.Int64Value(int64(m.Duration)),
+			MaxCheckAttempts: types.Int64Value(int64(m.MaxCheckAttempts)),
+			Scopes:           normalizeScopes(m.Scopes),
+			ExcludeScopes:    normalizeScopes(m.ExcludeScopes),
+		}
+	case *mackerel.MonitorConnectivity:
+		data.setCommon(m.Memo, m.IsMute, m.NotificationInterval)
+		data.ConnectivityMonitor = &MonitorConnectivityModel{
+			Scopes:            normalizeScopes(m.Scopes),
+			ExcludeScopes:     normalizeScopes(m.ExcludeScopes),
+			AlertStatusOnGone: types.StringValue(m.AlertStatusOnGone),
+		}
+	case *mackerel.MonitorServiceMetric:
+		data.setCommon(m.Memo, m.IsMute, m.NotificationInterval)
+		data.ServiceMetricMonitor = &MonitorServiceMetricModel{
+			Service:                 types.StringValue(m.Service),
+			Metric:                  types.StringValue(m.Metric),
+			Operator:                types.StringValue(m.Operator),
+			Warning:                 floatStringFromPointer(m.Warning),
+			Critical:                floatStringFromPointer(m.Critical),
+			Duration:                types.Int64Value(int64(m.Duration)),
+			MaxCheckAttempts:        types.Int64Value(int64(m.MaxCheckAttempts)),
+			MissingDurationWarning:  types.Int64Value(int64(m.MissingDurationWarning)),
+			MissingDurationCritical: types.Int64Value(int64(m.MissingDurationCritical)),
+		}
+	case *mackerel.MonitorExternalHTTP:
+		data.setCommon(m.Memo, m.IsMute, m.NotificationInterval)
+		headers := make(map[string]types.String, len(m.Headers))
+		for _, h := range m.Headers {
+			headers[h.Name] = types.StringValue(h.Value)
+		}
+		data.ExternalMonitor = &MonitorExternalModel{
+			Method:                          types.StringValue(m.Method),
+			URL:                             types.StringValue(m.URL),
+			MaxCheckAttempts:                types.Int64Value(int64(m.MaxCheckAttempts)),
+			Service:                         types.StringValue(m.Service),
+			ResponseTimeCritical:            types.Float64PointerValue(m.ResponseTimeCritical),
+			ResponseTimeWarning:             types.Float64PointerValue(m.ResponseTimeWarning),
+			ResponseTimeDuration:            int64FromUint64Pointer(m.ResponseTimeDuration),
+			RequestBody:                     types.StringValue(m.RequestBody),
+			ContainsString:                  types.StringValue(m.ContainsString),
+			CertificationExpirationCritical: int64FromUint64Pointer(m.CertificationExpirationCritical),
+			CertificationExpirationWarning:  int64FromUint64Pointer(m.CertificationExpirationWarning),
+			SkipCertificateVerification:     types.BoolValue(m.SkipCertificateVerification),
+			Headers:                         headers,
+			FollowRedirect:                  types.BoolValue(m.FollowRedirect),
+		}
+	case *mackerel.MonitorExpression:
+		data.setCommon(m.Memo, m.IsMute, m.NotificationInterval)
+		data.ExpressionMonitor = &MonitorExpressionModel{
+			Expression: types.StringValue(m.Expression),
+			Operator:   types.StringValue(m.Operator),
+			Warning:    floatStringFromPointer(m.Warning),
+			Critical:   floatStringFromPointer(m.Critical),
+		}
+	case *mackerel.MonitorAnomalyDetection:
+		data.setCommon(m.Memo, m.IsMute, m.NotificationInterval)
+		data.AnomalyDetectionMonitor = &MonitorAnomalyDetectionModel{
+			WarningSensitivity:  types.StringValue(m.WarningSensitivity),
+			CriticalSensitivity: types.StringValue(m.CriticalSensitivity),
+			MaxCheckAttempts:    types.Int64Value(int64(m.MaxCheckAttempts)),
+			TrainingPeriodFrom:  types.Int64Value(int64(m.TrainingPeriodFrom)),
+			Scopes:              normalizeScopes(m.Scopes),
+		}
+	case *mackerel.MonitorQuery:
+		data.setCommon(m.Memo, m.IsMute, m.NotificationInterval)
+		data.QueryMonitor = &MonitorQueryModel{
+			Query:    types.StringValue(m.Query),
+			Legend:   types.StringValue(m.Legend),
+			Operator: types.StringValue(m.Operator),
+			Warning:  floatStringFromPointer(m.Warning),
+			Critical: floatStringFromPointer(m.Critical),
+		}
+	default:
+		return MonitorModel{}, fmt.Errorf("the monitor '%s' has the type '%s', which is not supported", monitor.MonitorID(), monitor.MonitorType())
+	}
+	return data, nil
+}
+
+func (m *MonitorModel) setCommon(memo string, isMute bool, notificationInterval uint64) {
+	m.Memo = types.StringValue(memo)
+	m.IsMute = types.BoolValue(isMute)
+	m.NotificationInterval = types.Int64Value(int64(notificationInterval))
+}
+
+// Model -> API
+func (m MonitorModel) mackerelMonitor() (mackerel.Monitor, error) {
+	name := m.Name.ValueString()
+	memo := m.Memo.ValueString()
+	isMute := m.IsMute.ValueBool()
+	notificationInterval := uint64(m.NotificationInterval.ValueInt64())
+
+	switch {
+	case m.HostMetricMonitor != nil:
+		hm := m.HostMetricMonitor
+		return &mackerel.MonitorHostMetric{
+			Name:                 name,
+			Memo:                 memo,
+			Type:                 "host",
+			IsMute:               isMute,
+			NotificationInterval: notificationInterval,
+			Metric:               hm.Metric.ValueString(),
+			Operator:             hm.Operator.ValueString(),
+			Warning:              hm.Warning.ValueFloat64Pointer(),
+			Critical:             hm.Critical.ValueFloat64Pointer(),
+			Duration:             uint64(hm.Duration.ValueInt64()),
+			MaxCheckAttempts:     uint64(hm.MaxCheckAttempts.ValueInt64()),
+			Scopes:               stringsFromValues(hm.Scopes),
+			ExcludeScopes:        stringsFromValues(hm.ExcludeScopes),
+		}, nil
+	case m.ConnectivityMonitor != nil:
+		cm := m.ConnectivityMonitor
+		return &mackerel.MonitorConnectivity{
+			Name:                 name,
+			Memo:                 memo,
+			Type:                 "connectivity",
+			IsMute:               isMute,
+			NotificationInterval: notificationInterval,
+			Scopes:               stringsFromValues(cm.Scopes),
+			ExcludeScopes:        stringsFromValues(cm.ExcludeScopes),
+			AlertStatusOnGone:    cm.AlertStatusOnGone.ValueString(),
+		}, nil
+	case m.ServiceMetricMonitor != nil:
+		sm := m.ServiceMetricMonitor
+		return &mackerel.MonitorServiceMetric{
+			Name:                    name,
+			Memo:                    memo,
+			Type:                    "service",
+			IsMute:                  isMute,
+			NotificationInterval:    notificationInterval,
+			Service:                 sm.Service.ValueString(),
+			Metric:                  sm.Metric.ValueString(),
+			Operator:                sm.Operator.ValueString(),
+			Warning:                 sm.Warning.ValueFloat64Pointer(),
+			Critical:                sm.Critical.ValueFloat64Pointer(),
+			Duration:                uint64(sm.Duration.ValueInt64()),
+			MaxCheckAttempts:        uint64(sm.MaxCheckAttempts.ValueInt64()),
+			MissingDurationWarning:  uint64(sm.MissingDurationWarning.ValueInt64()),
+			MissingDurationCritical: uint64(sm.MissingDurationCritical.ValueInt64()),
+		}, nil
+	case m.ExternalMonitor != nil:
+		em := m.ExternalMonitor
+		headers := make([]mackerel.HeaderField, 0, len(em.Headers))
+		for name, value := range em.Headers {
+			headers = append(headers, mackerel.HeaderField{Name: name, Value: value.ValueString()})
+		}
+		return &mackerel.MonitorExternalHTTP{
+			Name:                            name,
+			Memo:                            memo,
+			Type:                            "external",
+			IsMute:                          isMute,
+			NotificationInterval:            notificationInterval,
+			Method:                          em.Method.ValueString(),
+			URL:                             em.URL.ValueString(),
+			MaxCheckAttempts:                uint64(em.MaxCheckAttempts.ValueInt64()),
+			Service:                         em.Service.ValueString(),
+			ResponseTimeCritical:            em.ResponseTimeCritical.ValueFloat64Pointer(),
+			ResponseTimeWarning:             em.ResponseTimeWarning.ValueFloat64Pointer(),
+			ResponseTimeDuration:            uint64PointerFromInt64(em.ResponseTimeDuration),
+			RequestBody:                     em.RequestBody.ValueString(),
+			ContainsString:                  em.ContainsString.ValueString(),
+			CertificationExpirationCritical: uint64PointerFromInt64(em.CertificationExpirationCritical),
+			CertificationExpirationWarning:  uint64PointerFromInt64(em.CertificationExpirationWarning),
+			SkipCertificateVerification:     em.SkipCertificateVerification.ValueBool(),
+			Headers:                         headers,
+			FollowRedirect:                  em.FollowRedirect.ValueBool(),
+		}, nil
+	case m.ExpressionMonitor != nil:
+		em := m.ExpressionMonitor
+		return &mackerel.MonitorExpression{
+			Name:                 name,
+			Memo:                 memo,
+			Type:                 "expression",
+			IsMute:               isMute,
+			NotificationInterval: notificationInterval,
+			Expression:           em.Expression.ValueString(),
+			Operator:             em.Operator.ValueString(),
+			Warning:              em.Warning.ValueFloat64Pointer(),
+			Critical:             em.Critical.ValueFloat64Pointer(),
+		}, nil
+	case m.AnomalyDetectionMonitor != nil:
+		am := m.AnomalyDetectionMonitor
+		return &mackerel.MonitorAnomalyDetection{
+			Name:                 name,
+			Memo:                 memo,
+			Type:                 "anomalyDetection",
+			IsMute:               isMute,
+			NotificationInterval: notificationInterval,
+			WarningSensitivity:   am.WarningSensitivity.ValueString(),
+			CriticalSensitivity:  am.CriticalSensitivity.ValueString(),
+			TrainingPeriodFrom:   uint64(am.TrainingPeriodFrom.ValueInt64()),
+			MaxCheckAttempts:     uint64(am.MaxCheckAttempts.ValueInt64()),
+			Scopes:               stringsFromValues(am.Scopes),
+		}, nil
+	case m.QueryMonitor != nil:
+		qm := m.QueryMonitor
+		return &mackerel.MonitorQuery{
+			Name:                 name,
+			Memo:                 memo,
+			Type:                 "query",
+			IsMute:               isMute,
+			NotificationInterval: notificationInterval,
+			Query:                qm.Query.ValueString(),
+			Legend:               qm.Legend.ValueString(),
+			Operator:             qm.Operator.ValueString(),
+			Warning:              qm.Warning.ValueFloat64Pointer(),
+			Critical:             qm.Critical.ValueFloat64Pointer(),
+		}, nil
+	default:
+		return nil, fmt.Errorf("exactly one of %s must be specified", strings.Join(MonitorTypes, ", "))
+	}
+}
+
+// MonitorModelV0 is the state of the monitor resource before nested attributes,
+// where each monitor type is a list of at most one element to be compatible with the SDK resource.
+type MonitorModelV0 struct {
+	ID                   types.String `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	Memo                 types.String `tfsdk:"memo"`
+	IsMute               types.Bool   `tfsdk:"is_mute"`
+	NotificationInterval types.Int64  `tfsdk:"notification_interval"`
+
+	HostMetricMonitor       []MonitorHostMetricModel       `tfsdk:"host_metric"`
+	ConnectivityMonitor     []MonitorConnectivityModel     `tfsdk:"connectivity"`
+	ServiceMetricMonitor    []MonitorServiceMetricModel    `tfsdk:"service_metric"`
+	ExternalMonitor         []MonitorExternalModel         `tfsdk:"external"`
+	ExpressionMonitor       []MonitorExpressionModel       `tfsdk:"expression"`
+	AnomalyDetectionMonitor []MonitorAnomalyDetectionModel `tfsdk:"anomaly_detection"`
+	QueryMonitor            []MonitorQueryModel            `tfsdk:"query"`
+}
+
+// Upgrade converts the state of version 0.
+func (m MonitorModelV0) Upgrade() MonitorModel {
+	return MonitorModel{
+		ID:                      m.ID,
+		Name:                    m.Name,
+		Memo:                    m.Memo,
+		IsMute:                  m.IsMute,
+		NotificationInterval:    m.NotificationInterval,
+		HostMetricMonitor:       first(m.HostMetricMonitor),
+		ConnectivityMonitor:     first(m.ConnectivityMonitor),
+		ServiceMetricMonitor:    first(m.ServiceMetricMonitor),
+		ExternalMonitor:         first(m.ExternalMonitor),
+		ExpressionMonitor:       first(m.ExpressionMonitor),
+		AnomalyDetectionMonitor: first(m.AnomalyDetectionMonitor),
+		QueryMonitor:            first(m.QueryMonitor),
+	}
+}
+
+// Empty thresholds are null, while the SDK resource stores empty strings.
+func floatStringFromPointer(f *float64) typeutil.FloatString {
+	if f == nil {
+		return typeutil.NewFloatStringNull()
+	}
+	return typeutil.NewFloatStringValue(strconv.FormatFloat(*f, 'f', -1, 64))
+}
+
+func int64FromUint64Pointer(v *uint64) types.Int64 {
+	if v == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(*v))
+}
+
+func uint64PointerFromInt64(v types.Int64) *uint64 {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	u := uint64(v.ValueInt64())
+	return &u
+}
+
+// The API may return scopes with spaces, e.g. "service: role".
+func normalizeScopes(scopes []string) []types.String {
+	values := make([]types.String, 0, len(scopes))
+	for _, s := range scopes {
+		values = append(values, types.StringValue(strings.ReplaceAll(s, " ", "")))
+	}
+	return values
+}
+
+func stringsFromValues(values []types.String) []string {
+	ss := make([]string, 0, len(values))
+	for _, v := range values {
+		ss = append(ss, v.ValueString())
+	}
+	return ss
+}
@@ -0,0 +1,362 @@
+package mackerel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio/mackerel-client-go"
+
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/typeutil"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// Returns a model without any monitor types
+func emptyMonitorModel(id, name string) MonitorModel {
+	return MonitorModel{
+		ID:                   types.StringValue(id),
+		Name:                 types.StringValue(name),
+		Memo:                 types.StringValue(""),
+		IsMute:               types.BoolValue(false),
+		NotificationInterval: types.Int64Value(0),
+	}
+}
+
+func Test_ValidateMonitorThresholdOrder(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		operator string
+		warning  float64
+		critical float64
+		wantErr  bool
+	}{
+		"greater ordered":   {operator: ">", warning: 80, critical: 90},
+		"greater equal":     {operator: ">", warning: 90, critical: 90},
+		"greater reversed":  {operator: ">", warning: 90, critical: 80, wantErr: true},
+		"less ordered":      {operator: "<", warning: 20, critical: 10},
+		"less equal":        {operator: "<", warning: 10, critical: 10},
+		"less reversed":     {operator: "<", warning: 10, critical: 20, wantErr: true},
+		"negative reversed": {operator: ">", warning: -1, critical: -2, wantErr: true},
+		"unknown operator":  {operator: "", warning: 90, critical: 80},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateMonitorThresholdOrder(tt.operator, tt.warning, tt.critical)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("unexpected error: %+v", err)
+			}
+		})
+	}
+}
+
+func Test_Monitor_Read(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		inID     string
+		inClient monitorGetterFunc
+
+		wantErr bool
+		wants   MonitorModel
+	}{
+		"host metric": {
+			inID: "m0",
+			inClient: func(id string) (mackerel.Monitor, error) {
+				return &mackerel.MonitorHostMetric{
+					ID:                   id,
+					Name:                 "cpu",
+					Memo:                 "memo",
+					Type:                 "host",
+					IsMute:               true,
+					NotificationInterval: 60,
+					Metric:               "cpu%",
+					Operator:             ">",
+					Critical:             ptr(90.5),
+					Duration:             3,
+					MaxCheckAttempts:     2,
+					Scopes:               []string{"service: role"},
+					ExcludeScopes:        []string{},
+				}, nil
+			},
+
+			wants: func() MonitorModel {
+				m := emptyMonitorModel("m0", "cpu")
+				m.Memo = types.StringValue("memo")
+				m.IsMute = types.BoolValue(true)
+				m.NotificationInterval = types.Int64Value(60)
+				m.HostMetricMonitor = &MonitorHostMetricModel{
+					Metric:           types.StringValue("cpu%"),
+					Operator:         types.StringValue(">"),
+					Warning:          typeutil.NewFloatStringNull(),
+					Critical:         typeutil.NewFloatStringValue("90.5"),
+					Duration:         types.Int64Value(3),
+					MaxCheckAttempts: types.Int64Value(2),
+					Scopes:           []types.String{types.StringValue("service:role")},
+					ExcludeScopes:    []types.String{},
+				}
+				return m
+			}(),
+		},
+		"external": {
+			inID: "m1",
+			inClient: func(id string) (mackerel.Monitor, error) {
+				return &mackerel.MonitorExternalHTTP{
+					ID:                             id,
+					Name:                           "web",
+					Type:                           "external",
+					Method:                         "GET",
+					URL:                            "https://example.com",
+					MaxCheckAttempts:               1,
+					Service:                        "service",
+					ResponseTimeWarning:            ptr(500.0),
+					ResponseTimeDuration:           ptr(uint64(3)),
+					CertificationExpirationWarning: ptr(uint64(30)),
+					Headers:                        []mackerel.HeaderField{{Name: "Cache-Control", Value: "no-cache"}},
+				}, nil
+			},
+
+			wants: func() MonitorModel {
+				m := emptyMonitorModel("m1", "web")
+				m.ExternalMonitor = &MonitorExternalModel{
+					Method:                          types.StringValue("GET"),
+					URL:                             types.StringValue("https://example.com"),
+					MaxCheckAttempts:                types.Int64Value(1),
+					Service:                         types.StringValue("service"),
+					ResponseTimeCritical:            types.Float64Null(),
+					ResponseTimeWarning:             types.Float64Value(500),
+					ResponseTimeDuration:            types.Int64Value(3),
+					RequestBody:                     types.StringValue(""),
+					ContainsString:                  types.StringValue(""),
+					CertificationExpirationCritical: types.Int64Null(),
+					CertificationExpirationWarning:  types.Int64Value(30),
+					SkipCertificateVerification:     types.BoolValue(false),
+					Headers:                         map[string]types.String{"Cache-Control": types.StringValue("no-cache")},
+					FollowRedirect:                  types.BoolValue(false),
+				}
+				return m
+			}(),
+		},
+		"query": {
+			inID: "m2",
+			inClient: func(id string) (mackerel.Monitor, error) {
+				return &mackerel.MonitorQuery{
+					ID:       id,
+					Name:     "query",
+					Type:     "query",
+					Query:    "container.cpu.utilization{k8s.deployment.name=\"nginx\"}",
+					Legend:   "cpu.utilization {{k8s.node.name}}",
+					Operator: "<",
+					Warning:  ptr(70.0),
+					Critical: ptr(90.0),
+				}, nil
+			},
+
+			wants: func() MonitorModel {
+				m := emptyMonitorModel("m2", "query")
+				m.QueryMonitor = &MonitorQueryModel{
+					Query:    types.StringValue("container.cpu.utilization{k8s.deployment.name=\"nginx\"}"),
+					Legend:   types.StringValue("cpu.utilization {{k8s.node.name}}"),
+					Operator: types.StringValue("<"),
+					Warning:  typeutil.NewFloatStringValue("70"),
+					Critical: typeutil.NewFloatStringValue("90"),
+				}
+				return m
+			}(),
+		},
+		"not found": {
+			inID: "m3",
+			inClient: func(id string) (mackerel.Monitor, error) {
+				return nil, &APIError{StatusCode: 404}
+			},
+
+			wantErr: true,
+		},
+	}
+
+	ctx := context.Background()
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := readMonitorInner(ctx, tt.inClient, tt.inID)
+			if (err != nil) != tt.wantErr {
+				if tt.wantErr {
+					t.Errorf("expect error, but got no error")
+				} else {
+					t.Errorf("unexpected error: %+v", err)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(m, tt.wants); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+type monitorGetterFunc func(string) (mackerel.Monitor, error)
+
+func (f monitorGetterFunc) GetMonitor(id string) (mackerel.Monitor, error) {
+	return f(id)
+}
+
+func Test_Monitor_Create(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		in   MonitorModel
+		inID string
+
+		wantErr bool
+		wantReq mackerel.Monitor
+	}{
+		"service metric": {
+			in: func() MonitorModel {
+				m := emptyMonitorModel("", "service metric")
+				m.ID = types.StringUnknown()
+				m.ServiceMetricMonitor = &MonitorServiceMetricModel{
+					Service:                 types.StringValue("service"),
+					Metric:                  types.StringValue("custom.metric"),
+					Operator:                types.StringValue(">"),
+					Warning:                 typeutil.NewFloatStringValue("1.5"),
+					Critical:                typeutil.NewFloatStringNull(),
+					Duration:                types.Int64Value(5),
+					MaxCheckAttempts:        types.Int64Value(1),
+					MissingDurationWarning:  types.Int64Value(0),
+					MissingDurationCritical: types.Int64Value(60),
+				}
+				return m
+			}(),
+			inID: "m0",
+
+			wantReq: &mackerel.MonitorServiceMetric{
+				Name:                    "service metric",
+				Type:                    "service",
+				Service:                 "service",
+				Metric:                  "custom.metric",
+				Operator:                ">",
+				Warning:                 ptr(1.5),
+				Duration:                5,
+				MaxCheckAttempts:        1,
+				MissingDurationCritical: 60,
+			},
+		},
+		"connectivity": {
+			in: func() MonitorModel {
+				m := emptyMonitorModel("", "connectivity")
+				m.ID = types.StringUnknown()
+				m.ConnectivityMonitor = &MonitorConnectivityModel{
+					Scopes:            []types.String{types.StringValue("service")},
+					ExcludeScopes:     []types.String{},
+					AlertStatusOnGone: types.StringValue("WARNING"),
+				}
+				return m
+			}(),
+			inID: "m1",
+
+			wantReq: &mackerel.MonitorConnectivity{
+				Name:              "connectivity",
+				Type:              "connectivity",
+				Scopes:            []string{"service"},
+				ExcludeScopes:     []string{},
+				AlertStatusOnGone: "WARNING",
+			},
+		},
+		"no monitor type": {
+			in: emptyMonitorModel("", "empty"),
+
+			wantErr: true,
+		},
+	}
+
+	ctx := context.Background()
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			m := tt.in
+			client := &monitorCreatorTester{ID: tt.inID}
+			err := m.createInner(ctx, client)
+			if (err != nil) != tt.wantErr {
+				if tt.wantErr {
+					t.Errorf("expect error, but got no error")
+				} else {
+					t.Errorf("unexpected error: %+v", err)
+				}
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if diff := cmp.Diff(client.Request, tt.wantReq); diff != "" {
+				t.Errorf("invalid request:\n%s", diff)
+			}
+			if m.ID.ValueString() != tt.inID {
+				t.Errorf("expected ID to be %s, but got %s", tt.inID, m.ID)
+			}
+		})
+	}
+}
+
+type monitorCreatorTester struct {
+	ID string
+
+	Request mackerel.Monitor
+}
+
+func (ct *monitorCreatorTester) CreateMonitor(param mackerel.Monitor) (mackerel.Monitor, error) {
+	ct.Request = param
+	switch m := param.(type) {
+	case *mackerel.MonitorServiceMetric:
+		data := *m
+		data.ID = ct.ID
+		return &data, nil
+	case *mackerel.MonitorConnectivity:
+		data := *m
+		data.ID = ct.ID
+		return &data, nil
+	default:
+		return param, nil
+	}
+}
+
+func Test_MonitorModelV0_Upgrade(t *testing.T) {
+	t.Parallel()
+
+	connectivity := MonitorConnectivityModel{
+		Scopes:            []types.String{types.StringValue("service")},
+		ExcludeScopes:     []types.String{},
+		AlertStatusOnGone: types.StringValue("CRITICAL"),
+	}
+	in := MonitorModelV0{
+		ID:                      types.StringValue("m0"),
+		Name:                    types.StringValue("connectivity"),
+		Memo:                    types.StringValue(""),
+		IsMute:                  types.BoolValue(false),
+		NotificationInterval:    types.Int64Value(0),
+		HostMetricMonitor:       []MonitorHostMetricModel{},
+		ConnectivityMonitor:     []MonitorConnectivityModel{connectivity},
+		ServiceMetricMonitor:    []MonitorServiceMetricModel{},
+		ExternalMonitor:         []MonitorExternalModel{},
+		ExpressionMonitor:       []MonitorExpressionModel{},
+		AnomalyDetectionMonitor: []MonitorAnomalyDetectionModel{},
+		QueryMonitor:            []MonitorQueryModel{},
+	}
+
+	wants := emptyMonitorModel("m0", "connectivity")
+	wants.ConnectivityMonitor = &connectivity
+
+	if diff := cmp.Diff(in.Upgrade(), wants); diff != "" {
+		t.Error(diff)
+	}
+}
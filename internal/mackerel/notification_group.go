@@ -37,7 +37,7 @@ func NotificationLevelValidator() validator.String {
 
 // Reads a notification group by `id`
 func ReadNotificationGroup(ctx context.Context, client *Client, id string) (NotificationGroupModel, error) {
-	return readNotificationGroupInner(ctx, client, id)
+	return readNotificationGroupInner(ctx, WithContext(ctx, client), id)
 }
 
 type notificationGroupFinder interface {
@@ -54,7 +54,7 @@ func readNotificationGroupInner(_ context.Context, client notificationGroupFinde
 		return ng.ID == id
 	})
 	if ngIdx < 0 {
-		return NotificationGroupModel{}, fmt.Errorf("the ID '%s' does not match any notification group in mackerel.io", id)
+		return NotificationGroupModel{}, newNotFoundError("the ID '%s' does not match any notification group in mackerel.io", id)
 	}
 
 	return newNotificationGroupModel(*ngs[ngIdx]), nil
@@ -62,7 +62,7 @@ func readNotificationGroupInner(_ context.Context, client notificationGroupFinde
 
 // Creates a notification group
 func (m *NotificationGroupModel) Create(ctx context.Context, client *Client) error {
-	return m.createInner(ctx, client)
+	return m.createInner(ctx, WithContext(ctx, client))
 }
 
 type notificationGroupCreator interface {
@@ -110,17 +110,17 @@ func (m *NotificationGroupModel) Read(ctx context.Context, client *Client) error
 }
 
 // Updates the notification group
-func (m *NotificationGroupModel) Update(_ context.Context, client *Client) error {
+func (m *NotificationGroupModel) Update(ctx context.Context, client *Client) error {
 	param := m.mackerelNotificationGroup()
-	if _, err := client.UpdateNotificationGroup(m.ID.ValueString(), &param); err != nil {
+	if _, err := WithContext(ctx, client).UpdateNotificationGroup(m.ID.ValueString(), &param); err != nil {
 		return err
 	}
 	return nil
 }
 
 // Deletes the notification group
-func (m *NotificationGroupModel) Delete(_ context.Context, client *Client) error {
-	if _, err := client.DeleteNotificationGroup(m.ID.ValueString()); err != nil {
+func (m *NotificationGroupModel) Delete(ctx context.Context, client *Client) error {
+	if _, err := WithContext(ctx, client).DeleteNotificationGroup(m.ID.ValueString()); err != nil {
 		return err
 	}
 	return nil
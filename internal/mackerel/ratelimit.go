@@ -0,0 +1,49 @@
+package mackerel
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Rate limiters are shared by all clients in the process which talk to the same organization,
+// so that the SDK and the framework providers in the muxed server consume a single budget.
+var rateLimiters sync.Map // map[sharedKey]*rate.Limiter
+
+type sharedKey struct {
+	apiBase string
+	apiKey  string
+}
+
+// Returns the shared limiter for the pair of API base and API key.
+// Non-positive requestsPerMinute means unlimited.
+func sharedRateLimiter(apiBase, apiKey string, requestsPerMinute int) *rate.Limiter {
+	limit, burst := rate.Inf, 0
+	if requestsPerMinute > 0 {
+		limit = rate.Every(time.Minute / time.Duration(requestsPerMinute))
+		burst = max(requestsPerMinute/60, 1)
+	}
+
+	key := sharedKey{apiBase: apiBase, apiKey: apiKey}
+	l, loaded := rateLimiters.LoadOrStore(key, rate.NewLimiter(limit, burst))
+	limiter := l.(*rate.Limiter)
+	if loaded {
+		limiter.SetLimit(limit)
+		limiter.SetBurst(burst)
+	}
+	return limiter
+}
+
+type rateLimitTransport struct {
+	base    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,94 @@
+package mackerel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mackerelio/mackerel-client-go"
+	"golang.org/x/time/rate"
+)
+
+func Test_sharedRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	l1 := sharedRateLimiter("https://example.com/", "Test_sharedRateLimiter", 60)
+	l2 := sharedRateLimiter("https://example.com/", "Test_sharedRateLimiter", 120)
+	if l1 != l2 {
+		t.Error("expected to share the limiter for the same API key")
+	}
+	if l1.Limit() != rate.Limit(2) || l1.Burst() != 2 {
+		t.Errorf("expected to update the limit, but got (%v, %d)", l1.Limit(), l1.Burst())
+	}
+
+	l3 := sharedRateLimiter("https://example.com/", "Test_sharedRateLimiter_other", 60)
+	if l1 == l3 {
+		t.Error("expected not to share the limiter between API keys")
+	}
+
+	l4 := sharedRateLimiter("https://example.com/", "Test_sharedRateLimiter_unlimited", 0)
+	if l4.Limit() != rate.Inf {
+		t.Errorf("expected to be unlimited, but got %v", l4.Limit())
+	}
+}
+
+func Test_SetupTransport_sharedRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	limiterOf := func(c *Client) *rate.Limiter {
+		retry := c.HTTPClient.Transport.(*apiErrorTransport).base.(*listCacheTransport).base.(*tracingTransport).base.(*retryTransport)
+		return retry.base.(*rateLimitTransport).limiter
+	}
+
+	c1 := mackerel.NewClient("Test_SetupTransport_sharedRateLimiter")
+	SetupTransport(c1, TransportOptions{RequestsPerMinute: 60})
+	c2 := mackerel.NewClient("Test_SetupTransport_sharedRateLimiter")
+	SetupTransport(c2, TransportOptions{RequestsPerMinute: 60})
+
+	if limiterOf(c1) != limiterOf(c2) {
+		t.Error("expected clients for the same organization to share the limiter")
+	}
+}
+
+func Test_rateLimitTransport(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	transport := &rateLimitTransport{
+		base:    http.DefaultTransport,
+		limiter: rate.NewLimiter(rate.Every(100*time.Millisecond), 1),
+	}
+	do := func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	ctx := context.Background()
+	start := time.Now()
+	for range 3 {
+		if err := do(ctx); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("expected requests to be throttled, but took only %s", elapsed)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := do(canceled); err == nil || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected to be canceled, but got: %v", err)
+	}
+}
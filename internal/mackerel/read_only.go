@@ -0,0 +1,70 @@
+package mackerel
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+)
+
+const readOnlyEnv = "MACKEREL_READ_ONLY"
+
+// ErrReadOnly indicates that a request which modifies objects was blocked by the read-only mode.
+var ErrReadOnly = errors.New("read-only mode")
+
+type readOnlyError struct {
+	method string
+	path   string
+}
+
+func (e *readOnlyError) Error() string {
+	return fmt.Sprintf("%s %s was blocked because the provider is in read-only mode (read_only or %s)", e.method, e.path, readOnlyEnv)
+}
+
+func (e *readOnlyError) Is(target error) bool {
+	return target == ErrReadOnly
+}
+
+// AsReadOnlyError finds the error of a request blocked by the read-only mode in err.
+// The error is wrapped by *url.Error, whose message includes the whole URL, so callers use this to report it plainly.
+func AsReadOnlyError(err error) (error, bool) {
+	var roErr *readOnlyError
+	if errors.As(err, &roErr) {
+		return roErr, true
+	}
+	return nil, false
+}
+
+// readOnlyTransport rejects every request other than GET, HEAD and OPTIONS before sending it.
+type readOnlyTransport struct {
+	base http.RoundTripper
+}
+
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.base.RoundTrip(req)
+	default:
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, &readOnlyError{method: req.Method, path: req.URL.Path}
+	}
+}
+
+// Returns whether the read-only mode is enabled, by the provider configuration or else by the environment variable.
+func (m *ClientConfigModel) readOnly() (bool, error) {
+	if !m.ReadOnly.IsNull() && !m.ReadOnly.IsUnknown() {
+		return m.ReadOnly.ValueBool(), nil
+	}
+	v := os.Getenv(readOnlyEnv)
+	if v == "" {
+		return false, nil
+	}
+	readOnly, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s: %q is not a boolean", readOnlyEnv, v)
+	}
+	return readOnly, nil
+}
@@ -0,0 +1,82 @@
+package mackerel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio/mackerel-client-go"
+)
+
+func Test_readOnlyTransport(t *testing.T) {
+	t.Parallel()
+
+	var mutations atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			mutations.Add(1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"services":[{"name":"service0","memo":"","roles":[]}]}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	client, err := mackerel.NewClientWithOptions("Test_readOnlyTransport", ts.URL, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SetupTransport(client, TransportOptions{ReadOnly: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if _, err := ReadService(ctx, client, "service0"); err != nil {
+		t.Errorf("expected GET to be allowed, but got: %+v", err)
+	}
+
+	cases := map[string]func() error{
+		"POST": func() error {
+			_, err := client.CreateService(&mackerel.CreateServiceParam{Name: "service1"})
+			return err
+		},
+		"PUT": func() error {
+			return client.PutServiceMetaData("service0", "namespace", map[string]string{})
+		},
+		"DELETE": func() error {
+			return ServiceModel{Name: "service0"}.Delete(ctx, client)
+		},
+	}
+	for method, call := range cases {
+		err := call()
+		if !errors.Is(err, ErrReadOnly) {
+			t.Errorf("%s: expected ErrReadOnly, but got: %+v", method, err)
+			continue
+		}
+		roErr, ok := AsReadOnlyError(err)
+		if !ok || !strings.HasPrefix(roErr.Error(), method+" /api/v0/services") {
+			t.Errorf("%s: unexpected error: %v", method, roErr)
+		}
+	}
+	if n := mutations.Load(); n != 0 {
+		t.Errorf("expected no mutating request to reach the server, but got %d", n)
+	}
+
+	d := NewErrorDiagnostic("Unable to create service", cases["POST"]())
+	if d.Detail() != "POST /api/v0/services was blocked because the provider is in read-only mode (read_only or MACKEREL_READ_ONLY)" {
+		t.Errorf("unexpected detail: %s", d.Detail())
+	}
+}
+
+func Test_ClientConfigModel_readOnly_invalidEnv(t *testing.T) {
+	t.Setenv("MACKEREL_READ_ONLY", "yes")
+
+	m := ClientConfigModel{APIKey: types.StringValue("key")}
+	if _, err := m.Resolve(); err == nil || !strings.Contains(err.Error(), "invalid MACKEREL_READ_ONLY") {
+		t.Errorf("expected an error for the invalid value, but got: %+v", err)
+	}
+}
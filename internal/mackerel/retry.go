@@ -0,0 +1,160 @@
+package mackerel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+)
+
+const (
+	DefaultMaxRetries   = 3
+	DefaultRetryMaxWait = 30 * time.Second
+
+	retryBaseWait = 1 * time.Second
+	// Same as the timeout of mackerel-client-go, but applied to every attempt.
+	requestTimeout = 30 * time.Second
+)
+
+// Returns a transport which retries requests that failed
+// with 429 Too Many Requests or a transient 5xx error.
+// A POST request is retried only on 429 because it is not idempotent.
+func newRetryTransport(base http.RoundTripper, maxRetries int, maxWait time.Duration) http.RoundTripper {
+	return &retryTransport{
+		base:       base,
+		maxRetries: max(maxRetries, 0),
+		maxWait:    maxWait,
+		baseWait:   retryBaseWait,
+		timeout:    requestTimeout,
+	}
+}
+
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	maxWait    time.Duration
+	baseWait   time.Duration
+	timeout    time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		r := req
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(ctx)
+			r.Body = body
+		}
+
+		resp, err := t.roundTrip(r)
+		if attempt >= t.maxRetries || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				wait = min(retryAfter, t.maxWait)
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		tflog.SubsystemDebug(tflog.NewSubsystem(ctx, logSubsystem), logSubsystem, "Retrying HTTP Request", map[string]any{
+			logging.FieldHttpRequestMethod: req.Method,
+			logging.FieldHttpRequestUri:    req.URL.RequestURI(),
+			"wait":                         wait.String(),
+			"attempt":                      attempt + 1,
+			"max_retries":                  t.maxRetries,
+		})
+		recordRetry(ctx, attempt+1)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (t *retryTransport) roundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Body != nil && req.GetBody == nil {
+		// cannot replay the body
+		return false
+	}
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	idempotent := req.Method != http.MethodPost && req.Method != http.MethodPatch
+	if err != nil {
+		return idempotent && !errors.Is(err, context.Canceled)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	default:
+		return false
+	}
+}
+
+// Exponential backoff with equal jitter.
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	wait := t.baseWait << attempt
+	if wait <= 0 || wait > t.maxWait {
+		wait = t.maxWait
+	}
+	half := wait / 2
+	if half <= 0 {
+		return wait
+	}
+	return half + rand.N(half)
+}
+
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
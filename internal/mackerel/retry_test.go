@@ -0,0 +1,253 @@
+package mackerel
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func Test_RetryTransport(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		method     string
+		body       string
+		statuses   []int
+		maxRetries int
+
+		wantStatus   int
+		wantAttempts int32
+	}{
+		"success": {
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusOK},
+			maxRetries: 3,
+
+			wantStatus:   http.StatusOK,
+			wantAttempts: 1,
+		},
+		"retry 429": {
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK},
+			maxRetries: 3,
+
+			wantStatus:   http.StatusOK,
+			wantAttempts: 3,
+		},
+		"retry 503": {
+			method:     http.MethodDelete,
+			statuses:   []int{http.StatusServiceUnavailable, http.StatusOK},
+			maxRetries: 3,
+
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		"replay body": {
+			method:     http.MethodPut,
+			body:       `{"name":"foo"}`,
+			statuses:   []int{http.StatusBadGateway, http.StatusOK},
+			maxRetries: 3,
+
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		"retry POST on 429": {
+			method:     http.MethodPost,
+			body:       `{"name":"foo"}`,
+			statuses:   []int{http.StatusTooManyRequests, http.StatusOK},
+			maxRetries: 3,
+
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		"no retry POST on 5xx": {
+			method:     http.MethodPost,
+			body:       `{"name":"foo"}`,
+			statuses:   []int{http.StatusInternalServerError, http.StatusOK},
+			maxRetries: 3,
+
+			wantStatus:   http.StatusInternalServerError,
+			wantAttempts: 1,
+		},
+		"no retry 4xx": {
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusBadRequest, http.StatusOK},
+			maxRetries: 3,
+
+			wantStatus:   http.StatusBadRequest,
+			wantAttempts: 1,
+		},
+		"give up": {
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests},
+			maxRetries: 2,
+
+			wantStatus:   http.StatusTooManyRequests,
+			wantAttempts: 3,
+		},
+		"disabled": {
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusTooManyRequests, http.StatusOK},
+			maxRetries: 0,
+
+			wantStatus:   http.StatusTooManyRequests,
+			wantAttempts: 1,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts atomic.Int32
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := attempts.Add(1)
+				if tt.body != "" {
+					b, _ := io.ReadAll(r.Body)
+					if string(b) != tt.body {
+						t.Errorf("attempt %d: expected body to be '%s', but got '%s'", n, tt.body, string(b))
+					}
+				}
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(tt.statuses[int(n)-1])
+			}))
+			defer ts.Close()
+
+			transport := newRetryTransport(http.DefaultTransport, tt.maxRetries, time.Millisecond)
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req, err := http.NewRequest(tt.method, ts.URL, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("expected status to be %d, but got %d", tt.wantStatus, resp.StatusCode)
+			}
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("expected %d attempts, but got %d", tt.wantAttempts, got)
+			}
+		})
+	}
+}
+
+func Test_RetryTransport_backoff(t *testing.T) {
+	t.Parallel()
+
+	transport := &retryTransport{
+		baseWait: time.Second,
+		maxWait:  10 * time.Second,
+	}
+	for attempt, want := range []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		got := transport.backoff(attempt)
+		if got < want/2 || got > want {
+			t.Errorf("attempt %d: expected to be in [%s, %s], but got %s", attempt, want/2, want, got)
+		}
+	}
+	if got := transport.backoff(100); got < 5*time.Second || got > 10*time.Second {
+		t.Errorf("expected not to overflow, but got %s", got)
+	}
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		in     string
+		want   time.Duration
+		wantOK bool
+	}{
+		"empty": {
+			in: "",
+		},
+		"seconds": {
+			in:     "120",
+			want:   120 * time.Second,
+			wantOK: true,
+		},
+		"past date": {
+			in:     "Wed, 21 Oct 2015 07:28:00 GMT",
+			want:   0,
+			wantOK: true,
+		},
+		"invalid": {
+			in: "soon",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := parseRetryAfter(tt.in)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("expected to be (%s, %t), but got (%s, %t)", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+}
+
+func Test_RetryTransport_logging(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	var out bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &out)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/api/v0/hosts?name=foo", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := newRetryTransport(http.DefaultTransport, 1, time.Millisecond).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	resp.Body.Close()
+
+	entries, err := tflogtest.MultilineJSONDecode(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected a retry to be logged, but got: %+v", entries)
+	}
+	entry := entries[0]
+	for key, want := range map[string]any{
+		"@level":             "debug",
+		"@module":            "provider.mackerel",
+		"@message":           "Retrying HTTP Request",
+		"tf_http_req_method": "GET",
+		"tf_http_req_uri":    "/api/v0/hosts?name=foo",
+		"attempt":            float64(1),
+		"max_retries":        float64(1),
+	} {
+		if entry[key] != want {
+			t.Errorf("expected %s to be %v, but got %v", key, want, entry[key])
+		}
+	}
+}
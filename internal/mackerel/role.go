@@ -32,6 +32,16 @@ func parseRoleID(id string) (serviceName, roleName string, err error) {
 	return serviceName, roleName, nil
 }
 
+// Returns the fullname of the role (`<service>:<role>`), which is also the ID of the role.
+func RoleFullname(serviceName, roleName string) string {
+	return roleID(serviceName, roleName)
+}
+
+// Splits the fullname of the role into the service name and the role name.
+func ParseRoleFullname(fullname string) (serviceName, roleName string, err error) {
+	return parseRoleID(fullname)
+}
+
 var roleNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9-_]+$`)
 
 func RoleNameValidator() validator.String {
@@ -45,7 +55,7 @@ func RoleNameValidator() validator.String {
 }
 
 func ReadRole(ctx context.Context, client *Client, serviceName, roleName string) (RoleModel, error) {
-	return readRoleInner(ctx, client, serviceName, roleName)
+	return readRoleInner(ctx, WithContext(ctx, client), serviceName, roleName)
 }
 
 type roleFinder interface {
@@ -62,7 +72,7 @@ func readRoleInner(_ context.Context, client roleFinder, serviceName, roleName s
 		return r.Name == roleName
 	})
 	if roleIdx < 0 {
-		return RoleModel{}, fmt.Errorf("the name '%s' does not match any role in mackerel.io", roleName)
+		return RoleModel{}, newNotFoundError("the name '%s' does not match any role in mackerel.io", roleName)
 	}
 
 	role := roles[roleIdx]
@@ -74,9 +84,9 @@ func readRoleInner(_ context.Context, client roleFinder, serviceName, roleName s
 	}, nil
 }
 
-func (m *RoleModel) Create(_ context.Context, client *Client) error {
+func (m *RoleModel) Create(ctx context.Context, client *Client) error {
 	serviceName := m.ServiceName.ValueString()
-	if _, err := client.CreateRole(serviceName, &mackerel.CreateRoleParam{
+	if _, err := WithContext(ctx, client).CreateRole(serviceName, &mackerel.CreateRoleParam{
 		Name: m.RoleName.ValueString(),
 		Memo: m.Memo.ValueString(),
 	}); err != nil {
@@ -89,7 +99,7 @@ func (m *RoleModel) Create(_ context.Context, client *Client) error {
 }
 
 func (m *RoleModel) Read(ctx context.Context, client *Client) error {
-	return m.readInner(ctx, client)
+	return m.readInner(ctx, WithContext(ctx, client))
 }
 func (m *RoleModel) readInner(ctx context.Context, client roleFinder) error {
 	// In ImportState, attributes other than `id` are unset.
@@ -118,8 +128,8 @@ func (m *RoleModel) readInner(ctx context.Context, client roleFinder) error {
 	return nil
 }
 
-func (m *RoleModel) Delete(_ context.Context, client *Client) error {
-	if _, err := client.DeleteRole(
+func (m *RoleModel) Delete(ctx context.Context, client *Client) error {
+	if _, err := WithContext(ctx, client).DeleteRole(
 		m.ServiceName.ValueString(),
 		m.RoleName.ValueString(),
 	); err != nil {
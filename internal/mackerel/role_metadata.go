@@ -23,6 +23,11 @@ func roleMetadataID(serviceName, roleName, namespace string) string {
 	return fmt.Sprintf("%s:%s/%s", serviceName, roleName, namespace)
 }
 
+// Returns the ID of the role metadata (`<service>:<role>/<namespace>`).
+func RoleMetadataID(serviceName, roleName, namespace string) string {
+	return roleMetadataID(serviceName, roleName, namespace)
+}
+
 func parseRoleMetadataID(id string) (serviceName, roleName, namespace string, err error) {
 	sn, rest, foundColon := strings.Cut(id, ":")
 	rn, ns, foundSlash := strings.Cut(rest, "/")
@@ -33,7 +38,7 @@ func parseRoleMetadataID(id string) (serviceName, roleName, namespace string, er
 }
 
 func ReadRoleMetadata(ctx context.Context, client *Client, serviceName, roleName, namespace string) (RoleMetadataModel, error) {
-	return readRoleMetadata(client, serviceName, roleName, namespace)
+	return readRoleMetadata(WithContext(ctx, client), serviceName, roleName, namespace)
 }
 
 type roleMetadataReader interface {
@@ -75,7 +80,7 @@ func ImportRoleMetadata(id string) (RoleMetadataModel, error) {
 }
 
 func (m *RoleMetadataModel) Create(ctx context.Context, client *Client) error {
-	return m.create(client)
+	return m.create(WithContext(ctx, client))
 }
 
 func (m *RoleMetadataModel) create(client roleMetadataUpdator) error {
@@ -92,7 +97,7 @@ func (m *RoleMetadataModel) create(client roleMetadataUpdator) error {
 
 func (m *RoleMetadataModel) Read(ctx context.Context, client *Client) error {
 	data, err := readRoleMetadata(
-		client,
+		WithContext(ctx, client),
 		m.ServiceName.ValueString(),
 		m.RoleName.ValueString(),
 		m.Namespace.ValueString(),
@@ -107,7 +112,7 @@ func (m *RoleMetadataModel) Read(ctx context.Context, client *Client) error {
 }
 
 func (m RoleMetadataModel) Update(ctx context.Context, client *Client) error {
-	return m.update(client)
+	return m.update(WithContext(ctx, client))
 }
 
 type roleMetadataUpdator interface {
@@ -130,8 +135,8 @@ func (m *RoleMetadataModel) update(client roleMetadataUpdator) error {
 	return nil
 }
 
-func (m RoleMetadataModel) Delete(_ context.Context, client *Client) error {
-	if err := client.DeleteRoleMetaData(
+func (m RoleMetadataModel) Delete(ctx context.Context, client *Client) error {
+	if err := WithContext(ctx, client).DeleteRoleMetaData(
 		m.ServiceName.ValueString(),
 		m.RoleName.ValueString(),
 		m.Namespace.ValueString(),
@@ -24,6 +24,11 @@ func serviceMetadataID(serviceName, namespace string) string {
 	return strings.Join([]string{serviceName, namespace}, "/")
 }
 
+// Returns the ID of the service metadata (`<service>/<namespace>`).
+func ServiceMetadataID(serviceName, namespace string) string {
+	return serviceMetadataID(serviceName, namespace)
+}
+
 func parseServiceMetadataID(id string) (serviceName, namespace string, err error) {
 	first, last, ok := strings.Cut(id, "/")
 	if !ok {
@@ -33,7 +38,7 @@ func parseServiceMetadataID(id string) (serviceName, namespace string, err error
 }
 
 func ReadServiceMetadata(ctx context.Context, client *Client, data ServiceMetadataModel) (ServiceMetadataModel, error) {
-	return readServiceMetadataInner(ctx, client, data)
+	return readServiceMetadataInner(ctx, WithContext(ctx, client), data)
 }
 
 type serviceMetadataGetter interface {
@@ -106,7 +111,7 @@ func (m *ServiceMetadataModel) Validate(base path.Path) (diags diag.Diagnostics)
 	return
 }
 
-func (m *ServiceMetadataModel) CreateOrUpdateMetadata(_ context.Context, client *Client) error {
+func (m *ServiceMetadataModel) CreateOrUpdateMetadata(ctx context.Context, client *Client) error {
 	serviceName, namespace, err := m.getID()
 	if err != nil {
 		return err
@@ -120,7 +125,7 @@ func (m *ServiceMetadataModel) CreateOrUpdateMetadata(_ context.Context, client
 		return fmt.Errorf("failed to unmarshal metadata: %w", err)
 	}
 
-	if err := client.PutServiceMetaData(serviceName, namespace, metadata); err != nil {
+	if err := WithContext(ctx, client).PutServiceMetaData(serviceName, namespace, metadata); err != nil {
 		return err
 	}
 
@@ -128,13 +133,13 @@ func (m *ServiceMetadataModel) CreateOrUpdateMetadata(_ context.Context, client
 	return nil
 }
 
-func (m *ServiceMetadataModel) Delete(_ context.Context, client *Client) error {
+func (m *ServiceMetadataModel) Delete(ctx context.Context, client *Client) error {
 	serviceName, namespace, err := m.getID()
 	if err != nil {
 		return err
 	}
 
-	if err := client.DeleteServiceMetaData(serviceName, namespace); err != nil {
+	if err := WithContext(ctx, client).DeleteServiceMetaData(serviceName, namespace); err != nil {
 		return err
 	}
 
@@ -15,7 +15,7 @@ type ServiceMetricNamesModel struct {
 }
 
 func ReadServiceMetricNames(ctx context.Context, client *Client, state ServiceMetricNamesModel) (ServiceMetricNamesModel, error) {
-	return readServiceMetricNamesInner(ctx, client, state)
+	return readServiceMetricNamesInner(ctx, WithContext(ctx, client), state)
 }
 
 type serviceMetricNamesReader interface {
@@ -0,0 +1,117 @@
+package mackerel
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName = "github.com/mackerelio-labs/terraform-provider-mackerel"
+
+	attrRetryCount = attribute.Key("mackerel.retry_count")
+)
+
+// SetupTracing exports traces with OTLP over HTTP if OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT is set.
+// The exporter is configured by the standard OTEL_EXPORTER_OTLP_* environment variables.
+// The returned function flushes the remaining spans, and must be called before the process exits.
+func SetupTracing(ctx context.Context) (shutdown func(context.Context) error, err error) {
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName("terraform-provider-mackerel")))
+	if err != nil {
+		return nil, err
+	}
+	tp := sdktrace.NewTracerProvider(
+		// Terraform may kill the provider soon after the last operation.
+		sdktrace.WithBatcher(exporter, sdktrace.WithBatchTimeout(time.Second)),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// StartSpan starts the span of an operation of a resource or a data source, e.g. "mackerel_service.Create".
+// The tracer is looked up on each call, so this is no-op unless SetupTracing enabled tracing.
+func StartSpan(ctx context.Context, typeName, operation string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, typeName+"."+operation, trace.WithAttributes(
+		attribute.String("terraform.type_name", typeName),
+		attribute.String("terraform.operation", operation),
+	))
+}
+
+// EndSpan ends span, recording the error if the operation failed.
+func EndSpan(span trace.Span, errMessage string) {
+	if errMessage != "" {
+		span.SetStatus(codes.Error, errMessage)
+	}
+	span.End()
+}
+
+// tracingTransport makes a span of each API call, which includes the retries.
+type tracingTransport struct {
+	base http.RoundTripper
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	route := pathTemplate(req.URL.Path)
+	ctx, span := otel.Tracer(tracerName).Start(req.Context(), req.Method+" "+route,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.HTTPRoute(route),
+			semconv.ServerAddress(req.URL.Hostname()),
+			attrRetryCount.Int(0),
+		),
+	)
+	defer span.End()
+
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+	return resp, nil
+}
+
+// Records the number of the retries on the span of the API call.
+func recordRetry(ctx context.Context, retries int) {
+	trace.SpanFromContext(ctx).SetAttributes(attrRetryCount.Int(retries))
+}
+
+// Replaces the names and the IDs in path, so that the spans of the same endpoint are grouped,
+// e.g. "/api/v0/services/foo/roles/bar" becomes "/api/v0/services/{id}/roles/{id}".
+// The paths of the API consist of pairs of a collection and an ID following "/api/v0".
+func pathTemplate(path string) string {
+	rest, ok := strings.CutPrefix(path, "/api/v0/")
+	if !ok {
+		return path
+	}
+	segments := strings.Split(rest, "/")
+	for i := 1; i < len(segments); i += 2 {
+		segments[i] = "{id}"
+	}
+	return "/api/v0/" + strings.Join(segments, "/")
+}
@@ -0,0 +1,187 @@
+package mackerel
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio/mackerel-client-go"
+	"go.opentelemetry.io/otel"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Stands in for an OpenTelemetry collector receiving OTLP over HTTP.
+func newCollector(t *testing.T) (ts *httptest.Server, spans func() []*tracepb.Span) {
+	t.Helper()
+
+	var (
+		mu       sync.Mutex
+		received []*tracepb.Span
+	)
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var req coltracepb.ExportTraceServiceRequest
+		if err := proto.Unmarshal(body, &req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				received = append(received, ss.Spans...)
+			}
+		}
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		b, _ := proto.Marshal(&coltracepb.ExportTraceServiceResponse{})
+		_, _ = w.Write(b)
+	}))
+	t.Cleanup(ts.Close)
+	return ts, func() []*tracepb.Span {
+		mu.Lock()
+		defer mu.Unlock()
+		return received
+	}
+}
+
+func spanAttributes(span *tracepb.Span) map[string]any {
+	attrs := make(map[string]any, len(span.Attributes))
+	for _, kv := range span.Attributes {
+		switch v := kv.Value.Value.(type) {
+		case *commonpb.AnyValue_StringValue:
+			attrs[kv.Key] = v.StringValue
+		case *commonpb.AnyValue_IntValue:
+			attrs[kv.Key] = v.IntValue
+		}
+	}
+	return attrs
+}
+
+func Test_SetupTracing(t *testing.T) {
+	collector, spans := newCollector(t)
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", collector.URL)
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	prev := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	// Rejects the first request, so that it is retried once.
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		_, _ = w.Write([]byte(`{"key":"value"}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	ctx := context.Background()
+	shutdown, err := SetupTracing(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := mackerel.NewClientWithOptions("Test_SetupTracing", ts.URL, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SetupTransport(client, TransportOptions{MaxRetries: 1, RetryMaxWait: DefaultRetryMaxWait}); err != nil {
+		t.Fatal(err)
+	}
+
+	spanCtx, span := StartSpan(ctx, "mackerel_service_metadata", "Read")
+	if _, err := ReadServiceMetadata(spanCtx, client, ServiceMetadataModel{
+		ID: types.StringValue("service0/namespace0"),
+	}); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	EndSpan(span, "")
+
+	if err := shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	byName := make(map[string]*tracepb.Span)
+	for _, s := range spans() {
+		byName[s.Name] = s
+	}
+	op, ok := byName["mackerel_service_metadata.Read"]
+	if !ok {
+		t.Fatalf("expected the span of the operation, but got: %+v", byName)
+	}
+	call, ok := byName["GET /api/v0/services/{id}/metadata/{id}"]
+	if !ok {
+		t.Fatalf("expected the span of the API call, but got: %+v", byName)
+	}
+	if string(call.ParentSpanId) != string(op.SpanId) {
+		t.Errorf("expected the API call to be a child of the operation")
+	}
+	want := map[string]any{
+		"http.request.method":       "GET",
+		"http.route":                "/api/v0/services/{id}/metadata/{id}",
+		"http.response.status_code": int64(200),
+		"mackerel.retry_count":      int64(1),
+	}
+	got := spanAttributes(call)
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("expected %s to be %v, but got %v", k, v, got[k])
+		}
+	}
+}
+
+func Test_SetupTracing_disabled(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	prev := otel.GetTracerProvider()
+
+	shutdown, err := SetupTracing(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if otel.GetTracerProvider() != prev {
+		t.Error("expected the tracer provider not to be replaced")
+	}
+}
+
+func Test_pathTemplate(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"/api/v0/org":          "/api/v0/org",
+		"/api/v0/services":     "/api/v0/services",
+		"/api/v0/services/foo": "/api/v0/services/{id}",
+		"/api/v0/services/foo/roles/bar/metadata/ns": "/api/v0/services/{id}/roles/{id}/metadata/{id}",
+		"/api/v0/services/foo/metric-names":          "/api/v0/services/{id}/metric-names",
+		"/api/v0/monitors/3Ja3HG3bTwq":               "/api/v0/monitors/{id}",
+		"/other":                                     "/other",
+	}
+	for path, want := range cases {
+		if got := pathTemplate(path); got != want {
+			t.Errorf("pathTemplate(%q): expected %q, but got %q", path, want, got)
+		}
+	}
+}
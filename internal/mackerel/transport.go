@@ -0,0 +1,148 @@
+package mackerel
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+type TransportOptions struct {
+	// The number of retries after the first attempt. Zero disables retrying.
+	MaxRetries int
+	// The upper bound of the wait between attempts, including `Retry-After`.
+	RetryMaxWait time.Duration
+	// Non-positive value means unlimited.
+	RequestsPerMinute int
+
+	// PEM encoded CA certificates to verify the server, in addition to the system ones.
+	CACertFile string
+	// PEM encoded certificate and key to present to the server. Both must be set to use.
+	ClientCertFile string
+	ClientKeyFile  string
+	// Defaults to the proxy from the environment variables (HTTPS_PROXY, etc.).
+	ProxyURL           string
+	InsecureSkipVerify bool
+
+	// Rejects requests which modify objects, such as POST, PUT and DELETE.
+	ReadOnly bool
+
+	// Overrides the API key of each request if set.
+	credentials *credentialProcess
+}
+
+// Installs the HTTP transport used by both the SDK and the framework providers.
+func SetupTransport(client *Client, opts TransportOptions) error {
+	base, err := newBaseTransport(opts)
+	if err != nil {
+		return err
+	}
+
+	var transport http.RoundTripper = &loggingTransport{base: base}
+	if opts.credentials != nil {
+		transport = &credentialTransport{base: transport, source: opts.credentials}
+	}
+	transport = &rateLimitTransport{
+		base:    transport,
+		limiter: sharedRateLimiter(client.BaseURL.String(), client.APIKey, opts.RequestsPerMinute),
+	}
+	transport = newRetryTransport(transport, opts.MaxRetries, opts.RetryMaxWait)
+	transport = &tracingTransport{base: transport}
+	transport = &listCacheTransport{
+		base:  transport,
+		cache: sharedListCache(client.BaseURL.String(), client.APIKey),
+	}
+	if opts.ReadOnly {
+		transport = &readOnlyTransport{base: transport}
+	}
+	transport = &apiErrorTransport{base: transport}
+
+	// The timeout is applied to each attempt by the retry transport.
+	client.HTTPClient.Timeout = 0
+	client.HTTPClient.Transport = transport
+	return nil
+}
+
+// Returns http.DefaultTransport if no TLS or proxy settings are given.
+func newBaseTransport(opts TransportOptions) (http.RoundTripper, error) {
+	if opts.CACertFile == "" && opts.ClientCertFile == "" && opts.ClientKeyFile == "" && opts.ProxyURL == "" && !opts.InsecureSkipVerify {
+		return http.DefaultTransport, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// #nosec G402 -- explicitly requested by the user
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	if opts.CACertFile != "" {
+		pem, err := os.ReadFile(opts.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ca_cert_file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("ca_cert_file '%s' contains no PEM encoded certificates", opts.CACertFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if opts.ClientCertFile != "" || opts.ClientKeyFile != "" {
+		if opts.ClientCertFile == "" || opts.ClientKeyFile == "" {
+			return nil, errors.New("both client_cert_file and client_key_file must be set")
+		}
+		cert, err := tls.LoadX509KeyPair(opts.ClientCertFile, opts.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if opts.ProxyURL != "" {
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy_url: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return transport, nil
+}
+
+// WithContext returns a shallow copy of the client whose requests are bound to ctx,
+// since mackerel-client-go does not take contexts.
+// Canceling ctx aborts the in-flight request as well as waits for retries and rate limiting.
+func WithContext(ctx context.Context, client *Client) *Client {
+	httpClient := *client.HTTPClient
+	httpClient.Transport = &contextTransport{
+		ctx:  ctx,
+		base: client.HTTPClient.Transport,
+	}
+
+	c := *client
+	c.HTTPClient = &httpClient
+	return &c
+}
+
+type contextTransport struct {
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req.WithContext(t.ctx))
+}
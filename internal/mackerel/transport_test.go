@@ -0,0 +1,294 @@
+package mackerel
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mackerelio/mackerel-client-go"
+)
+
+func newSlowServer(t *testing.T, delay time.Duration) *httptest.Server {
+	t.Helper()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(delay):
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"services":[]}`))
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func Test_WithContext(t *testing.T) {
+	t.Parallel()
+
+	ts := newSlowServer(t, 10*time.Second)
+	client, err := mackerel.NewClientWithOptions("Test_WithContext", ts.URL, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetupTransport(client, TransportOptions{MaxRetries: DefaultMaxRetries, RetryMaxWait: DefaultRetryMaxWait})
+
+	cases := map[string]func(context.Context) error{
+		"ReadService": func(ctx context.Context) error {
+			_, err := ReadService(ctx, client, "service0")
+			return err
+		},
+		"ServiceModel.Delete": func(ctx context.Context) error {
+			return ServiceModel{Name: "service0"}.Delete(ctx, client)
+		},
+		"ReadRole": func(ctx context.Context) error {
+			_, err := ReadRole(ctx, client, "service0", "role0")
+			return err
+		},
+	}
+
+	for name, call := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			err := call(ctx)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("expected deadline exceeded, but got: %+v", err)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("expected to be canceled promptly, but took %s", elapsed)
+			}
+		})
+	}
+}
+
+func Test_WithContext_original(t *testing.T) {
+	t.Parallel()
+
+	ts := newSlowServer(t, 0)
+	client, err := mackerel.NewClientWithOptions("Test_WithContext_original", ts.URL, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := WithContext(ctx, client).FindServices(); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected to be canceled, but got: %+v", err)
+	}
+	if _, err := client.FindServices(); err != nil {
+		t.Errorf("expected the original client not to be affected, but got: %+v", err)
+	}
+}
+
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// Returns the paths of a self-signed client certificate and its key, and the pool to verify it.
+func writeClientCert(t *testing.T) (certFile, keyFile string, pool *x509.CertPool) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform-provider-mackerel"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool = x509.NewCertPool()
+	pool.AddCert(cert)
+	return writePEM(t, "client.crt", "CERTIFICATE", der), writePEM(t, "client.key", "EC PRIVATE KEY", keyDER), pool
+}
+
+func newServicesHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"services":[]}`))
+	})
+}
+
+func Test_SetupTransport_tls(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewTLSServer(newServicesHandler())
+	t.Cleanup(ts.Close)
+	caCertFile := writePEM(t, "ca.crt", "CERTIFICATE", ts.Certificate().Raw)
+
+	clientCertFile, clientKeyFile, clientCAs := writeClientCert(t)
+	mtls := httptest.NewUnstartedServer(newServicesHandler())
+	mtls.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	mtls.StartTLS()
+	t.Cleanup(mtls.Close)
+	mtlsCACertFile := writePEM(t, "mtls-ca.crt", "CERTIFICATE", mtls.Certificate().Raw)
+
+	cases := map[string]struct {
+		url     string
+		opts    TransportOptions
+		wantErr bool
+	}{
+		"untrusted": {
+			url:     ts.URL,
+			wantErr: true,
+		},
+		"ca_cert_file": {
+			url:  ts.URL,
+			opts: TransportOptions{CACertFile: caCertFile},
+		},
+		"insecure_skip_verify": {
+			url:  ts.URL,
+			opts: TransportOptions{InsecureSkipVerify: true},
+		},
+		"no client cert": {
+			url:     mtls.URL,
+			opts:    TransportOptions{CACertFile: mtlsCACertFile},
+			wantErr: true,
+		},
+		"client cert": {
+			url: mtls.URL,
+			opts: TransportOptions{
+				CACertFile:     mtlsCACertFile,
+				ClientCertFile: clientCertFile,
+				ClientKeyFile:  clientKeyFile,
+			},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := mackerel.NewClientWithOptions("Test_SetupTransport_tls/"+name, tt.url, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := SetupTransport(client, tt.opts); err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+
+			_, err = client.FindServices()
+			if tt.wantErr != (err != nil) {
+				t.Errorf("expected error: %v, got: %+v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func Test_SetupTransport_proxy(t *testing.T) {
+	t.Parallel()
+
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		newServicesHandler().ServeHTTP(w, r)
+	}))
+	t.Cleanup(proxy.Close)
+
+	client, err := mackerel.NewClientWithOptions("Test_SetupTransport_proxy", "http://mackerel.invalid", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SetupTransport(client, TransportOptions{ProxyURL: proxy.URL}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.FindServices(); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if want := "http://mackerel.invalid/api/v0/services"; proxied != want {
+		t.Errorf("expected the proxy to receive %s, got: %s", want, proxied)
+	}
+}
+
+func Test_SetupTransport_invalid(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	notPEM := filepath.Join(dir, "ca.crt")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		opts    TransportOptions
+		wantErr string
+	}{
+		"missing ca_cert_file": {
+			opts:    TransportOptions{CACertFile: filepath.Join(dir, "missing.crt")},
+			wantErr: "failed to read ca_cert_file",
+		},
+		"no certificates in ca_cert_file": {
+			opts:    TransportOptions{CACertFile: notPEM},
+			wantErr: "contains no PEM encoded certificates",
+		},
+		"client_cert_file without key": {
+			opts:    TransportOptions{ClientCertFile: notPEM},
+			wantErr: "both client_cert_file and client_key_file must be set",
+		},
+		"invalid client cert": {
+			opts:    TransportOptions{ClientCertFile: notPEM, ClientKeyFile: notPEM},
+			wantErr: "failed to load client certificate",
+		},
+		"invalid proxy_url": {
+			opts:    TransportOptions{ProxyURL: "http://[::1"},
+			wantErr: "invalid proxy_url",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := mackerel.NewClientWithOptions("Test_SetupTransport_invalid", "https://api.mackerelio.com", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = SetupTransport(client, tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got: %+v", tt.wantErr, err)
+			}
+		})
+	}
+}
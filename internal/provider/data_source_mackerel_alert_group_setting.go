@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
+)
+
+var (
+	_ datasource.DataSource              = (*mackerelAlertGroupSettingDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*mackerelAlertGroupSettingDataSource)(nil)
+)
+
+func NewMackerelAlertGroupSettingDataSource() datasource.DataSource {
+	return &mackerelAlertGroupSettingDataSource{}
+}
+
+type mackerelAlertGroupSettingDataSource struct {
+	Client *mackerel.Client
+}
+
+func (d *mackerelAlertGroupSettingDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alert_group_setting"
+}
+
+func (d *mackerelAlertGroupSettingDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source allows access to details of a specific alert group setting.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the alert group setting",
+
+				Required: true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the alert group setting",
+
+				Computed: true,
+			},
+			"memo": schema.StringAttribute{
+				Description: "The notes for the alert group setting",
+
+				Computed: true,
+			},
+			"service_scopes": alertGroupSettingDataSourceScopesAttribute("A set of service names whose alerts are grouped"),
+			"role_scopes":    alertGroupSettingDataSourceScopesAttribute("A set of role fullnames whose alerts are grouped"),
+			"monitor_scopes": alertGroupSettingDataSourceScopesAttribute("A set of monitor IDs whose alerts are grouped"),
+			"notification_interval": schema.Int64Attribute{
+				Description: "The time interval for re-sending notifications in minutes",
+
+				Computed: true,
+			},
+		},
+	}
+}
+
+func alertGroupSettingDataSourceScopesAttribute(description string) schema.SetAttribute {
+	return schema.SetAttribute{
+		Description: description,
+
+		ElementType: types.StringType,
+		Computed:    true,
+	}
+}
+
+func (d *mackerelAlertGroupSettingDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	client, diags := retrieveClient(ctx, req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+	d.Client = client
+}
+
+func (d *mackerelAlertGroupSettingDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_alert_group_setting", "Read")
+	defer endSpan(&resp.Diagnostics)
+
+	var id types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("id"), &id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, err := mackerel.ReadAlertGroupSetting(ctx, d.Client, id.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
+			"Unable to read Alert Group Setting.",
+			err,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
+)
+
+var (
+	_ datasource.DataSource              = (*mackerelChannelDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*mackerelChannelDataSource)(nil)
+)
+
+func NewMackerelChannelDataSource() datasource.DataSource {
+	return &mackerelChannelDataSource{}
+}
+
+type mackerelChannelDataSource struct {
+	Client *mackerel.Client
+}
+
+func (d *mackerelChannelDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_channel"
+}
+
+func (d *mackerelChannelDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source allows access to details of a specific notification channel.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the channel",
+
+				Required: true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the channel",
+
+				Computed: true,
+			},
+			"email": channelDataSourceAttribute("The settings of the email channel", map[string]schema.Attribute{
+				"emails":   channelDataSourceStringSetAttribute("A set of email addresses to receive notifications"),
+				"user_ids": channelDataSourceStringSetAttribute("A set of user IDs to receive notifications"),
+				"events":   channelDataSourceStringSetAttribute("A set of events to be notified"),
+			}),
+			"slack": channelDataSourceAttribute("The settings of the Slack channel", map[string]schema.Attribute{
+				"url": schema.StringAttribute{
+					Description: "The incoming webhook URL of Slack",
+
+					Computed: true,
+				},
+				"mentions": schema.MapAttribute{
+					Description: "The mentions for each alert status",
+
+					ElementType: types.StringType,
+					Computed:    true,
+				},
+				"enabled_graph_image": schema.BoolAttribute{
+					Description: "Whether to post the graph image of the alert",
+
+					Computed: true,
+				},
+				"events": channelDataSourceStringSetAttribute("A set of events to be notified"),
+			}),
+			"webhook": channelDataSourceAttribute("The settings of the webhook channel", map[string]schema.Attribute{
+				"url": schema.StringAttribute{
+					Description: "The URL to receive HTTP requests",
+
+					Computed: true,
+				},
+				"events": channelDataSourceStringSetAttribute("A set of events to be notified"),
+			}),
+		},
+	}
+}
+
+func channelDataSourceAttribute(description string, attrs map[string]schema.Attribute) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: description,
+
+		Attributes: attrs,
+		Computed:   true,
+	}
+}
+
+func channelDataSourceStringSetAttribute(description string) schema.SetAttribute {
+	return schema.SetAttribute{
+		Description: description,
+
+		ElementType: types.StringType,
+		Computed:    true,
+	}
+}
+
+func (d *mackerelChannelDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	client, diags := retrieveClient(ctx, req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+	d.Client = client
+}
+
+func (d *mackerelChannelDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_channel", "Read")
+	defer endSpan(&resp.Diagnostics)
+
+	var id types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("id"), &id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, err := mackerel.ReadChannel(ctx, d.Client, id.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
+			"Unable to read Channel.",
+			err,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
+)
+
+var (
+	_ datasource.DataSource              = (*mackerelDowntimeDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*mackerelDowntimeDataSource)(nil)
+)
+
+func NewMackerelDowntimeDataSource() datasource.DataSource {
+	return &mackerelDowntimeDataSource{}
+}
+
+type mackerelDowntimeDataSource struct {
+	Client *mackerel.Client
+}
+
+func (d *mackerelDowntimeDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_downtime"
+}
+
+func (d *mackerelDowntimeDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source allows access to details of a specific downtime.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the downtime",
+
+				Required: true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the downtime",
+
+				Computed: true,
+			},
+			"memo": schema.StringAttribute{
+				Description: "The notes for the downtime",
+
+				Computed: true,
+			},
+			"start": schema.Int64Attribute{
+				Description: "The start time of the downtime in epoch seconds",
+
+				Computed: true,
+			},
+			"duration": schema.Int64Attribute{
+				Description: "The duration of the downtime in minutes",
+
+				Computed: true,
+			},
+			"service_scopes":         downtimeDataSourceScopesAttribute("A set of service names targeted by the downtime"),
+			"service_exclude_scopes": downtimeDataSourceScopesAttribute("A set of service names excluded from the downtime"),
+			"role_scopes":            downtimeDataSourceScopesAttribute("A set of role fullnames targeted by the downtime"),
+			"role_exclude_scopes":    downtimeDataSourceScopesAttribute("A set of role fullnames excluded from the downtime"),
+			"monitor_scopes":         downtimeDataSourceScopesAttribute("A set of monitor IDs targeted by the downtime"),
+			"monitor_exclude_scopes": downtimeDataSourceScopesAttribute("A set of monitor IDs excluded from the downtime"),
+			"recurrence": schema.SingleNestedAttribute{
+				Description: "The recurrence settings of the downtime",
+
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Description: "The recurrence unit",
+
+						Computed: true,
+					},
+					"interval": schema.Int64Attribute{
+						Description: "The recurrence interval in the recurrence unit",
+
+						Computed: true,
+					},
+					"weekdays": schema.SetAttribute{
+						Description: "A set of the days of the week on which the downtime recurs",
+
+						ElementType: types.StringType,
+						Computed:    true,
+					},
+					"until": schema.Int64Attribute{
+						Description: "The end time of the recurrence in epoch seconds",
+
+						Computed: true,
+					},
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func downtimeDataSourceScopesAttribute(description string) schema.SetAttribute {
+	return schema.SetAttribute{
+		Description: description,
+
+		ElementType: types.StringType,
+		Computed:    true,
+	}
+}
+
+func (d *mackerelDowntimeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	client, diags := retrieveClient(ctx, req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+	d.Client = client
+}
+
+func (d *mackerelDowntimeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_downtime", "Read")
+	defer endSpan(&resp.Diagnostics)
+
+	var id types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("id"), &id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, err := mackerel.ReadDowntime(ctx, d.Client, id.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
+			"Unable to read Downtime.",
+			err,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
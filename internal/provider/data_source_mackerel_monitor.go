@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/typeutil"
+)
+
+var (
+	_ datasource.DataSource              = (*mackerelMonitorDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*mackerelMonitorDataSource)(nil)
+)
+
+func NewMackerelMonitorDataSource() datasource.DataSource {
+	return &mackerelMonitorDataSource{}
+}
+
+type mackerelMonitorDataSource struct {
+	Client *mackerel.Client
+}
+
+func (d *mackerelMonitorDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_monitor"
+}
+
+func (d *mackerelMonitorDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source allows access to details of a specific monitor.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the monitor",
+
+				Required: true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the monitor",
+
+				Computed: true,
+			},
+			"memo": schema.StringAttribute{
+				Description: "The notes for the monitoring configuration",
+
+				Computed: true,
+			},
+			"is_mute": schema.BoolAttribute{
+				Description: "Whether monitoring is muted or not",
+
+				Computed: true,
+			},
+			"notification_interval": schema.Int64Attribute{
+				Description: "The time interval for re-sending notifications in minutes",
+
+				Computed: true,
+			},
+			"host_metric": monitorDataSourceAttribute("The settings of the host metric monitor", map[string]schema.Attribute{
+				"metric":             monitorDataSourceStringAttribute("The name of the host metric targeted by monitoring"),
+				"operator":           monitorDataSourceStringAttribute("The comparison operator"),
+				"warning":            monitorDataSourceThresholdAttribute("The threshold to generate a warning alert"),
+				"critical":           monitorDataSourceThresholdAttribute("The threshold to generate a critical alert"),
+				"duration":           monitorDataSourceInt64Attribute("The duration of the monitor in minutes"),
+				"max_check_attempts": monitorDataSourceInt64Attribute("The number of consecutive warning/critical counts before an alert is made"),
+				"scopes":             monitorDataSourceScopesAttribute("A set of service names or role fullnames targeted by monitoring"),
+				"exclude_scopes":     monitorDataSourceScopesAttribute("A set of service names or role fullnames excluded from monitoring"),
+			}),
+			"connectivity": monitorDataSourceAttribute("The settings of the host connectivity monitor", map[string]schema.Attribute{
+				"scopes":               monitorDataSourceScopesAttribute("A set of service names or role fullnames targeted by monitoring"),
+				"exclude_scopes":       monitorDataSourceScopesAttribute("A set of service names or role fullnames excluded from monitoring"),
+				"alert_status_on_gone": monitorDataSourceStringAttribute("The alert status when the monitoring target is gone"),
+			}),
+			"service_metric": monitorDataSourceAttribute("The settings of the service metric monitor", map[string]schema.Attribute{
+				"service":                   monitorDataSourceStringAttribute("The name of the service targeted by monitoring"),
+				"metric":                    monitorDataSourceStringAttribute("The name of the service metric targeted by monitoring"),
+				"operator":                  monitorDataSourceStringAttribute("The comparison operator"),
+				"warning":                   monitorDataSourceThresholdAttribute("The threshold to generate a warning alert"),
+				"critical":                  monitorDataSourceThresholdAttribute("The threshold to generate a critical alert"),
+				"duration":                  monitorDataSourceInt64Attribute("The number of the points of the metric to be averaged"),
+				"max_check_attempts":        monitorDataSourceInt64Attribute("The number of consecutive warning/critical counts before an alert is made"),
+				"missing_duration_warning":  monitorDataSourceInt64Attribute("The duration in minutes of interruption to generate a warning alert"),
+				"missing_duration_critical": monitorDataSourceInt64Attribute("The duration in minutes of interruption to generate a critical alert"),
+			}),
+			"external": monitorDataSourceAttribute("The settings of the external HTTP monitor", map[string]schema.Attribute{
+				"method":             monitorDataSourceStringAttribute("The request method"),
+				"url":                monitorDataSourceStringAttribute("The URL targeted by monitoring"),
+				"max_check_attempts": monitorDataSourceInt64Attribute("The number of consecutive warning/critical counts before an alert is made"),
+				"service":            monitorDataSourceStringAttribute("The name of the service which the response time is graphed as a service metric of"),
+				"response_time_critical": schema.Float64Attribute{
+					Description: "The response time threshold in milliseconds to generate a critical alert",
+
+					Computed: true,
+				},
+				"response_time_warning": schema.Float64Attribute{
+					Description: "The response time threshold in milliseconds to generate a warning alert",
+
+					Computed: true,
+				},
+				"response_time_duration":            monitorDataSourceInt64Attribute("The duration in minutes to monitor the average of the response time"),
+				"request_body":                      monitorDataSourceStringAttribute("The HTTP request body"),
+				"contains_string":                   monitorDataSourceStringAttribute("The string which should be contained by the response body"),
+				"certification_expiration_critical": monitorDataSourceInt64Attribute("The number of days remaining until the certificate expires to generate a critical alert"),
+				"certification_expiration_warning":  monitorDataSourceInt64Attribute("The number of days remaining until the certificate expires to generate a warning alert"),
+				"skip_certificate_verification": schema.BoolAttribute{
+					Description: "Whether to skip the verification of the certificate",
+
+					Computed: true,
+				},
+				"headers": schema.MapAttribute{
+					Description: "The HTTP request headers",
+
+					ElementType: types.StringType,
+					Computed:    true,
+					Sensitive:   true,
+				},
+				"follow_redirect": schema.BoolAttribute{
+					Description: "Whether to follow redirects and evaluate the response of the destination",
+
+					Computed: true,
+				},
+			}),
+			"expression": monitorDataSourceAttribute("The settings of the expression monitor", map[string]schema.Attribute{
+				"expression": monitorDataSourceStringAttribute("The expression of the metric targeted by monitoring"),
+				"operator":   monitorDataSourceStringAttribute("The comparison operator"),
+				"warning":    monitorDataSourceThresholdAttribute("The threshold to generate a warning alert"),
+				"critical":   monitorDataSourceThresholdAttribute("The threshold to generate a critical alert"),
+			}),
+			"anomaly_detection": monitorDataSourceAttribute("The settings of the anomaly detection monitor for roles", map[string]schema.Attribute{
+				"warning_sensitivity":  monitorDataSourceStringAttribute("The sensitivity to generate a warning alert"),
+				"critical_sensitivity": monitorDataSourceStringAttribute("The sensitivity to generate a critical alert"),
+				"max_check_attempts":   monitorDataSourceInt64Attribute("The number of consecutive warning/critical counts before an alert is made"),
+				"training_period_from": monitorDataSourceInt64Attribute("The epoch seconds from which the monitor learns the metrics"),
+				"scopes":               monitorDataSourceScopesAttribute("A set of role fullnames targeted by monitoring"),
+			}),
+			"query": monitorDataSourceAttribute("The settings of the query monitor", map[string]schema.Attribute{
+				"query":    monitorDataSourceStringAttribute("The PromQL-style query"),
+				"legend":   monitorDataSourceStringAttribute("The legend of the query"),
+				"operator": monitorDataSourceStringAttribute("The comparison operator"),
+				"warning":  monitorDataSourceThresholdAttribute("The threshold to generate a warning alert"),
+				"critical": monitorDataSourceThresholdAttribute("The threshold to generate a critical alert"),
+			}),
+		},
+	}
+}
+
+func monitorDataSourceAttribute(description string, attrs map[string]schema.Attribute) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: description,
+
+		Attributes: attrs,
+		Computed:   true,
+	}
+}
+
+func monitorDataSourceStringAttribute(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: description,
+
+		Computed: true,
+	}
+}
+
+func monitorDataSourceThresholdAttribute(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: description,
+
+		CustomType: typeutil.FloatStringType{},
+		Computed:   true,
+	}
+}
+
+func monitorDataSourceInt64Attribute(description string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Description: description,
+
+		Computed: true,
+	}
+}
+
+func monitorDataSourceScopesAttribute(description string) schema.SetAttribute {
+	return schema.SetAttribute{
+		Description: description,
+
+		ElementType: types.StringType,
+		Computed:    true,
+	}
+}
+
+func (d *mackerelMonitorDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	client, diags := retrieveClient(ctx, req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+	d.Client = client
+}
+
+func (d *mackerelMonitorDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_monitor", "Read")
+	defer endSpan(&resp.Diagnostics)
+
+	var id types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("id"), &id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, err := mackerel.ReadMonitor(ctx, d.Client, id.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
+			"Unable to read Monitor.",
+			err,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
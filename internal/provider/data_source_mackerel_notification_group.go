@@ -58,13 +58,11 @@ func (d *mackerelNotificationGroupDataSource) Schema(_ context.Context, _ dataso
 				ElementType: types.StringType,
 				Computed:    true,
 			},
-		},
-		// TODO: migrate to nested attributes (terraform plugin protocol v6 is required)
-		Blocks: map[string]schema.Block{
-			"monitor": schema.SetNestedBlock{
+			"monitor": schema.SetNestedAttribute{
 				Description: "A set of notification target monitor rules",
 
-				NestedObject: schema.NestedBlockObject{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"id": schema.StringAttribute{
 							Description: "The monitor rule ID",
@@ -79,10 +77,11 @@ func (d *mackerelNotificationGroupDataSource) Schema(_ context.Context, _ dataso
 					},
 				},
 			},
-			"service": schema.SetNestedBlock{
+			"service": schema.SetNestedAttribute{
 				Description: "A set of notification target services",
 
-				NestedObject: schema.NestedBlockObject{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"name": schema.StringAttribute{
 							Description: "the name of the service",
@@ -106,6 +105,9 @@ func (d *mackerelNotificationGroupDataSource) Configure(ctx context.Context, req
 }
 
 func (d *mackerelNotificationGroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_notification_group", "Read")
+	defer endSpan(&resp.Diagnostics)
+
 	var config mackerel.NotificationGroupModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
@@ -114,10 +116,10 @@ func (d *mackerelNotificationGroupDataSource) Read(ctx context.Context, req data
 
 	data, err := mackerel.ReadNotificationGroup(ctx, d.Client, config.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
 			"Unable to read Notification Group.",
-			err.Error(),
-		)
+			err,
+		))
 		return
 	}
 
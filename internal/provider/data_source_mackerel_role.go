@@ -61,6 +61,9 @@ func (d *mackerelRoleDataSource) Configure(ctx context.Context, req datasource.C
 }
 
 func (d *mackerelRoleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_role", "Read")
+	defer endSpan(&resp.Diagnostics)
+
 	var config mackerel.RoleModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
@@ -69,10 +72,10 @@ func (d *mackerelRoleDataSource) Read(ctx context.Context, req datasource.ReadRe
 
 	data, err := mackerel.ReadRole(ctx, d.Client, config.ServiceName.ValueString(), config.RoleName.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
 			"Unable to read Role",
-			err.Error(),
-		)
+			err,
+		))
 		return
 	}
 
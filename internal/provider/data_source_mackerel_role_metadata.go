@@ -67,6 +67,9 @@ func (d *mackerelRoleMetadataDataSource) Configure(ctx context.Context, req data
 }
 
 func (d *mackerelRoleMetadataDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_role_metadata", "Read")
+	defer endSpan(&resp.Diagnostics)
+
 	var config mackerel.RoleMetadataModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
@@ -78,10 +81,10 @@ func (d *mackerelRoleMetadataDataSource) Read(ctx context.Context, req datasourc
 	namespace := config.Namespace.ValueString()
 	data, err := mackerel.ReadRoleMetadata(ctx, d.Client, serviceName, roleName, namespace)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
 			fmt.Sprintf("Unable to read Role Metadata: service=%s role=%s namespace=%s", serviceName, roleName, namespace),
-			err.Error(),
-		)
+			err,
+		))
 		return
 	}
 
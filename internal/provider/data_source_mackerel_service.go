@@ -56,6 +56,9 @@ func (d *mackerelServiceDataSource) Configure(ctx context.Context, req datasourc
 }
 
 func (d *mackerelServiceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_service", "Read")
+	defer endSpan(&resp.Diagnostics)
+
 	var config mackerel.ServiceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
@@ -64,10 +67,10 @@ func (d *mackerelServiceDataSource) Read(ctx context.Context, req datasource.Rea
 
 	data, err := mackerel.ReadService(ctx, d.Client, config.Name)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
 			"Unable to read Service",
-			err.Error(),
-		)
+			err,
+		))
 		return
 	}
 
@@ -66,6 +66,9 @@ func (d *mackerelServiceMetadataDataSource) Configure(ctx context.Context, req d
 }
 
 func (d *mackerelServiceMetadataDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_service_metadata", "Read")
+	defer endSpan(&resp.Diagnostics)
+
 	var data mackerel.ServiceMetadataModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -74,10 +77,10 @@ func (d *mackerelServiceMetadataDataSource) Read(ctx context.Context, req dataso
 
 	remoteData, err := mackerel.ReadServiceMetadata(ctx, d.Client, data)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
 			fmt.Sprintf("Unable to read Service Metadata: %s/%s", data.ServiceName.ValueString(), data.Namespace.ValueString()),
-			err.Error(),
-		)
+			err,
+		))
 		return
 	}
 
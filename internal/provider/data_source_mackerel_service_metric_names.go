@@ -66,6 +66,9 @@ func (d *mackerelServiceMetricNamesDataSource) Configure(ctx context.Context, re
 }
 
 func (d *mackerelServiceMetricNamesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_service_metric_names", "Read")
+	defer endSpan(&resp.Diagnostics)
+
 	var config mackerel.ServiceMetricNamesModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
@@ -74,10 +77,10 @@ func (d *mackerelServiceMetricNamesDataSource) Read(ctx context.Context, req dat
 
 	data, err := mackerel.ReadServiceMetricNames(ctx, d.Client, config)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
 			fmt.Sprintf("Unable to read Service Metric Names from Service: %s", config.Name.ValueString()),
-			err.Error(),
-		)
+			err,
+		))
 		return
 	}
 
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/expression"
+)
+
+var _ function.Function = (*metricExpressionFunction)(nil)
+
+func NewMetricExpressionFunction() function.Function {
+	return &metricExpressionFunction{}
+}
+
+type metricExpressionFunction struct{}
+
+func (f *metricExpressionFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "metric_expression"
+}
+
+func (f *metricExpressionFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Validate a metric expression",
+		MarkdownDescription: "Returns the metric expression as it is after checking its syntax and the kinds of the arguments of known functions, so that mistakes in expressions built outside of the monitor or dashboard attributes fail at plan time. Unknown functions and unexpected numbers of arguments are accepted.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "expression",
+				Description: "The metric expression",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *metricExpressionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var expr string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &expr))
+	if resp.Error != nil {
+		return
+	}
+
+	// Functions cannot return warnings, so unknown functions and unexpected numbers of arguments are accepted.
+	if _, err := expression.Validate(expr); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, expr))
+}
@@ -0,0 +1,63 @@
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/provider"
+)
+
+func Test_MetricExpressionFunction(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		in      string
+		wantErr bool
+	}{
+		"valid": {
+			in: "avg(role(foo:bar, loadavg5))",
+		},
+		"unknown function": {
+			in: "avrg(role(foo:bar, loadavg5))",
+		},
+		"too many arguments": {
+			in: "avg(role(foo:bar, loadavg5), role(foo:baz, loadavg5))",
+		},
+		"value for metrics": {
+			in:      "avg(loadavg5)",
+			wantErr: true,
+		},
+	}
+
+	ctx := context.Background()
+	f := provider.NewMetricExpressionFunction()
+
+	defResp := function.DefinitionResponse{}
+	f.Definition(ctx, function.DefinitionRequest{}, &defResp)
+	if defResp.Diagnostics.HasError() {
+		t.Fatalf("definition: %+v", defResp.Diagnostics)
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+			f.Run(ctx, function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tt.in)}),
+			}, &resp)
+			if (resp.Error != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %+v", resp.Error)
+			}
+			if resp.Error != nil {
+				return
+			}
+			if want := types.StringValue(tt.in); !resp.Result.Value().Equal(want) {
+				t.Errorf("expected %s, but got %s", want, resp.Result.Value())
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/validatorutil"
+)
+
+var _ function.Function = (*parseRoleFullnameFunction)(nil)
+
+func NewParseRoleFullnameFunction() function.Function {
+	return &parseRoleFullnameFunction{}
+}
+
+type parseRoleFullnameFunction struct{}
+
+var roleFullnameAttrTypes = map[string]attr.Type{
+	"service": types.StringType,
+	"role":    types.StringType,
+}
+
+func (f *parseRoleFullnameFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_role_fullname"
+}
+
+func (f *parseRoleFullnameFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Split the fullname of a role",
+		MarkdownDescription: "Splits the fullname of the role (`<service>:<role>`) into an object with `service` and `role` attributes.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "fullname",
+				Description: "The fullname of the role",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: roleFullnameAttrTypes,
+		},
+	}
+}
+
+func (f *parseRoleFullnameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var fullname string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &fullname))
+	if resp.Error != nil {
+		return
+	}
+
+	serviceName, roleName, err := mackerel.ParseRoleFullname(fullname)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	// Validates the parts with the same rules as the role_fullname function.
+	for _, part := range []struct {
+		name      string
+		value     string
+		validator function.StringParameterValidator
+	}{
+		{"service", serviceName, validatorutil.StringParameter("service", mackerel.ServiceNameValidator())},
+		{"role", roleName, validatorutil.StringParameter("role", mackerel.RoleNameValidator())},
+	} {
+		vresp := &function.StringParameterValidatorResponse{}
+		part.validator.ValidateParameterString(ctx, function.StringParameterValidatorRequest{
+			ArgumentPosition: 0,
+			Value:            types.StringValue(part.value),
+		}, vresp)
+		resp.Error = function.ConcatFuncErrors(resp.Error, vresp.Error)
+	}
+	if resp.Error != nil {
+		return
+	}
+
+	result, diags := types.ObjectValue(roleFullnameAttrTypes, map[string]attr.Value{
+		"service": types.StringValue(serviceName),
+		"role":    types.StringValue(roleName),
+	})
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,78 @@
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/provider"
+)
+
+func Test_ParseRoleFullnameFunction(t *testing.T) {
+	t.Parallel()
+
+	attrTypes := map[string]attr.Type{
+		"service": types.StringType,
+		"role":    types.StringType,
+	}
+
+	cases := map[string]struct {
+		in      string
+		wants   attr.Value
+		wantErr bool
+	}{
+		"valid": {
+			in: "service:role",
+			wants: types.ObjectValueMust(attrTypes, map[string]attr.Value{
+				"service": types.StringValue("service"),
+				"role":    types.StringValue("role"),
+			}),
+		},
+		"no colon": {
+			in:      "service",
+			wantErr: true,
+		},
+		"invalid role": {
+			in:      "service:-role",
+			wantErr: true,
+		},
+		"empty service": {
+			in:      ":role",
+			wantErr: true,
+		},
+	}
+
+	ctx := context.Background()
+	f := provider.NewParseRoleFullnameFunction()
+
+	defResp := function.DefinitionResponse{}
+	f.Definition(ctx, function.DefinitionRequest{}, &defResp)
+	if defResp.Diagnostics.HasError() {
+		t.Fatalf("definition: %+v", defResp.Diagnostics)
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(attrTypes))}
+			f.Run(ctx, function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tt.in)}),
+			}, &resp)
+			if (resp.Error != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %+v", resp.Error)
+			}
+			if resp.Error != nil {
+				if resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != 0 {
+					t.Errorf("expected the error on the argument 0, but got: %+v", resp.Error)
+				}
+				return
+			}
+			if !resp.Result.Value().Equal(tt.wants) {
+				t.Errorf("expected %s, but got %s", tt.wants, resp.Result.Value())
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/validatorutil"
+)
+
+var _ function.Function = (*roleFullnameFunction)(nil)
+
+func NewRoleFullnameFunction() function.Function {
+	return &roleFullnameFunction{}
+}
+
+type roleFullnameFunction struct{}
+
+func (f *roleFullnameFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "role_fullname"
+}
+
+func (f *roleFullnameFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build the fullname of a role",
+		MarkdownDescription: "Returns the fullname of the role (`<service>:<role>`), which is used for scopes and the ID of `mackerel_role`.",
+
+		Parameters: []function.Parameter{
+			serviceNameParameter(),
+			roleNameParameter(),
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *roleFullnameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var serviceName, roleName string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &serviceName, &roleName))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, mackerel.RoleFullname(serviceName, roleName)))
+}
+
+func serviceNameParameter() function.StringParameter {
+	return function.StringParameter{
+		Name:        "service",
+		Description: "The name of the service",
+		Validators: []function.StringParameterValidator{
+			validatorutil.StringParameter("service", mackerel.ServiceNameValidator()),
+		},
+	}
+}
+
+func roleNameParameter() function.StringParameter {
+	return function.StringParameter{
+		Name:        "role",
+		Description: "The name of the role",
+		Validators: []function.StringParameterValidator{
+			validatorutil.StringParameter("role", mackerel.RoleNameValidator()),
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/provider"
+)
+
+func Test_RoleFullnameFunction(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	f := provider.NewRoleFullnameFunction()
+
+	defResp := function.DefinitionResponse{}
+	f.Definition(ctx, function.DefinitionRequest{}, &defResp)
+	if defResp.Diagnostics.HasError() {
+		t.Fatalf("definition: %+v", defResp.Diagnostics)
+	}
+
+	resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	f.Run(ctx, function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("service"), types.StringValue("role")}),
+	}, &resp)
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %+v", resp.Error)
+	}
+	if want := types.StringValue("service:role"); !resp.Result.Value().Equal(want) {
+		t.Errorf("expected %s, but got %s", want, resp.Result.Value())
+	}
+}
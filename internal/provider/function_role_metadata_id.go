@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
+)
+
+var _ function.Function = (*roleMetadataIDFunction)(nil)
+
+func NewRoleMetadataIDFunction() function.Function {
+	return &roleMetadataIDFunction{}
+}
+
+type roleMetadataIDFunction struct{}
+
+func (f *roleMetadataIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "role_metadata_id"
+}
+
+func (f *roleMetadataIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build the ID of a role metadata",
+		MarkdownDescription: "Returns the ID of the role metadata (`<service>:<role>/<namespace>`), which is used to import `mackerel_role_metadata`.",
+
+		Parameters: []function.Parameter{
+			serviceNameParameter(),
+			roleNameParameter(),
+			metadataNamespaceParameter(),
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *roleMetadataIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var serviceName, roleName, namespace string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &serviceName, &roleName, &namespace))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, mackerel.RoleMetadataID(serviceName, roleName, namespace)))
+}
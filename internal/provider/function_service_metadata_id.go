@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
+)
+
+var _ function.Function = (*serviceMetadataIDFunction)(nil)
+
+func NewServiceMetadataIDFunction() function.Function {
+	return &serviceMetadataIDFunction{}
+}
+
+type serviceMetadataIDFunction struct{}
+
+func (f *serviceMetadataIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "service_metadata_id"
+}
+
+func (f *serviceMetadataIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build the ID of a service metadata",
+		MarkdownDescription: "Returns the ID of the service metadata (`<service>/<namespace>`), which is used to import `mackerel_service_metadata`.",
+
+		Parameters: []function.Parameter{
+			serviceNameParameter(),
+			metadataNamespaceParameter(),
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *serviceMetadataIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var serviceName, namespace string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &serviceName, &namespace))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, mackerel.ServiceMetadataID(serviceName, namespace)))
+}
+
+func metadataNamespaceParameter() function.StringParameter {
+	return function.StringParameter{
+		Name:        "namespace",
+		Description: "The namespace of the metadata",
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -15,16 +17,34 @@ import (
 	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/validatorutil"
 )
 
-type mackerelProvider struct{}
+const providerTypeName = "mackerel"
 
-var _ provider.Provider = (*mackerelProvider)(nil)
+type mackerelProvider struct {
+	// The type names of the resources and data sources to serve. nil serves all of them.
+	typeNames map[string]bool
+}
+
+var (
+	_ provider.Provider              = (*mackerelProvider)(nil)
+	_ provider.ProviderWithFunctions = (*mackerelProvider)(nil)
+)
 
 func New() provider.Provider {
 	return &mackerelProvider{}
 }
 
+// Returns the provider which serves only the resources and data sources of typeNames,
+// leaving the others to the SDK provider.
+func NewWithTypeNames(typeNames []string) provider.Provider {
+	m := &mackerelProvider{typeNames: make(map[string]bool, len(typeNames))}
+	for _, name := range typeNames {
+		m.typeNames[name] = true
+	}
+	return m
+}
+
 func (m *mackerelProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
-	resp.TypeName = "mackerel"
+	resp.TypeName = providerTypeName
 }
 
 func (m *mackerelProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
@@ -41,6 +61,62 @@ func (m *mackerelProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 				Sensitive:   true,
 				Validators:  []validator.String{validatorutil.IsURLWithHTTPorHTTPS()},
 			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Maximum number of retries for rate-limited (429) or transient 5xx responses",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				Description: "Maximum seconds to wait between retries",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"requests_per_minute": schema.Int64Attribute{
+				Description: "Maximum number of API requests per minute, shared by all resources",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"api_key_file": schema.StringAttribute{
+				Description: "Path to a file containing only the Mackerel API Key",
+				Optional:    true,
+			},
+			"config_file": schema.StringAttribute{
+				Description: "Path to a mackerel-agent.conf formatted file to read apikey and apibase from",
+				Optional:    true,
+			},
+			"credential_process": schema.StringAttribute{
+				Description: "Command to obtain the Mackerel API Key, which prints JSON with api_key and optional expiration",
+				Optional:    true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path to a PEM encoded CA certificate bundle to verify the API server, in addition to the system ones",
+				Optional:    true,
+			},
+			"client_cert_file": schema.StringAttribute{
+				Description: "Path to a PEM encoded client certificate to present to the API server",
+				Optional:    true,
+			},
+			"client_key_file": schema.StringAttribute{
+				Description: "Path to the PEM encoded private key of client_cert_file",
+				Optional:    true,
+			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of the proxy to access the API through. Defaults to the HTTPS_PROXY or HTTP_PROXY environment variables",
+				Optional:    true,
+				Validators:  []validator.String{validatorutil.IsURLWithHTTPorHTTPS()},
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Skip verification of the API server certificate. Do not use in production",
+				Optional:    true,
+			},
+			"read_only": schema.BoolAttribute{
+				Description: "Reject API requests which modify objects, for plan-only use such as drift detection",
+				Optional:    true,
+			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				Description: "Skip validating the API key with the API when configuring the provider",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -52,16 +128,7 @@ func (m *mackerelProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
-	config := mackerel.NewClientConfigFromEnv()
-	// merge config
-	if config.APIKey.IsUnknown() {
-		config.APIKey = schemaConfig.APIKey
-	}
-	if config.APIBase.IsUnknown() {
-		config.APIBase = schemaConfig.APIBase
-	}
-
-	client, err := config.NewClient()
+	config, err := schemaConfig.Resolve()
 	if err != nil {
 		if errors.Is(err, mackerel.ErrNoAPIKey) {
 			resp.Diagnostics.AddError(
@@ -76,23 +143,107 @@ func (m *mackerelProvider) Configure(ctx context.Context, req provider.Configure
 		}
 		return
 	}
+	client, err := mackerel.NewClient(config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create Mackerel Client",
+			err.Error(),
+		)
+		return
+	}
+
+	if !schemaConfig.SkipCredentialsValidation.ValueBool() {
+		if _, err := mackerel.ValidateCredentials(ctx, client); err != nil {
+			resp.Diagnostics.AddError(mackerel.DescribeCredentialsError(err))
+			return
+		}
+	}
 
 	resp.ResourceData = client
 	resp.DataSourceData = client
 }
 
-func (m *mackerelProvider) Resources(context.Context) []func() resource.Resource {
-	return []func() resource.Resource{
+func (m *mackerelProvider) Resources(ctx context.Context) []func() resource.Resource {
+	if m.typeNames == nil {
+		return resources
+	}
+	var rs []func() resource.Resource
+	for _, r := range resources {
+		if m.typeNames[resourceTypeName(ctx, r)] {
+			rs = append(rs, r)
+		}
+	}
+	return rs
+}
+
+func (m *mackerelProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	if m.typeNames == nil {
+		return dataSources
+	}
+	var ds []func() datasource.DataSource
+	for _, d := range dataSources {
+		if m.typeNames[dataSourceTypeName(ctx, d)] {
+			ds = append(ds, d)
+		}
+	}
+	return ds
+}
+
+// Functions are served regardless of typeNames since the SDK provider cannot serve them.
+func (m *mackerelProvider) Functions(context.Context) []func() function.Function {
+	return functions
+}
+
+// Returns the type names of all the resources implemented with the framework.
+func ResourceTypeNames(ctx context.Context) []string {
+	names := make([]string, 0, len(resources))
+	for _, r := range resources {
+		names = append(names, resourceTypeName(ctx, r))
+	}
+	return names
+}
+
+// Returns the type names of all the data sources implemented with the framework.
+func DataSourceTypeNames(ctx context.Context) []string {
+	names := make([]string, 0, len(dataSources))
+	for _, d := range dataSources {
+		names = append(names, dataSourceTypeName(ctx, d))
+	}
+	return names
+}
+
+func resourceTypeName(ctx context.Context, r func() resource.Resource) string {
+	resp := &resource.MetadataResponse{}
+	r().Metadata(ctx, resource.MetadataRequest{ProviderTypeName: providerTypeName}, resp)
+	return resp.TypeName
+}
+
+func dataSourceTypeName(ctx context.Context, d func() datasource.DataSource) string {
+	resp := &datasource.MetadataResponse{}
+	d().Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: providerTypeName}, resp)
+	return resp.TypeName
+}
+
+var (
+	resources = []func() resource.Resource{
+		NewMackerelAlertGroupSettingResource,
+		NewMackerelAWSIntegrationResource,
+		NewMackerelChannelResource,
+		NewMackerelDashboardResource,
+		NewMackerelDowntimeResource,
+		NewMackerelMonitorResource,
 		NewMackerelNotificationGroupResource,
 		NewMackerelRoleResource,
 		NewMackerelRoleMetadataResource,
 		NewMackerelServiceResource,
 		NewMackerelServiceMetadataResource,
 	}
-}
 
-func (m *mackerelProvider) DataSources(context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{
+	dataSources = []func() datasource.DataSource{
+		NewMackerelAlertGroupSettingDataSource,
+		NewMackerelChannelDataSource,
+		NewMackerelDowntimeDataSource,
+		NewMackerelMonitorDataSource,
 		NewMackerelNotificationGroupDataSource,
 		NewMackerelRoleDataSource,
 		NewMackerelRoleMetadataDataSource,
@@ -100,7 +251,15 @@ func (m *mackerelProvider) DataSources(context.Context) []func() datasource.Data
 		NewMackerelServiceMetadataDataSource,
 		NewMackerelServiceMetricNamesDataSource,
 	}
-}
+
+	functions = []func() function.Function{
+		NewMetricExpressionFunction,
+		NewParseRoleFullnameFunction,
+		NewRoleFullnameFunction,
+		NewRoleMetadataIDFunction,
+		NewServiceMetadataIDFunction,
+	}
+)
 
 func retrieveClient(_ context.Context, providerData any) (client *mackerel.Client, diags diag.Diagnostics) {
 	if /* ConfigureProvider RPC is not called */ providerData == nil {
@@ -24,3 +24,26 @@ func TestMackerelProvider_schema(t *testing.T) {
 		t.Fatalf("Schema validation: %+v", diags)
 	}
 }
+
+func TestMackerelProvider_typeNames(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	p := provider.NewWithTypeNames([]string{"mackerel_service", "mackerel_dashboard"})
+	if got := len(p.Resources(ctx)); got != 2 {
+		t.Errorf("expected 2 resources, but got %d", got)
+	}
+	// mackerel_dashboard data source is not implemented
+	if got := len(p.DataSources(ctx)); got != 1 {
+		t.Errorf("expected 1 data source, but got %d", got)
+	}
+
+	all := provider.New()
+	if got, want := len(all.Resources(ctx)), len(provider.ResourceTypeNames(ctx)); got != want {
+		t.Errorf("expected %d resources, but got %d", want, got)
+	}
+	if got, want := len(all.DataSources(ctx)), len(provider.DataSourceTypeNames(ctx)); got != want {
+		t.Errorf("expected %d data sources, but got %d", want, got)
+	}
+}
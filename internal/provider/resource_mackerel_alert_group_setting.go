@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
+)
+
+var (
+	_ resource.Resource                = (*mackerelAlertGroupSettingResource)(nil)
+	_ resource.ResourceWithConfigure   = (*mackerelAlertGroupSettingResource)(nil)
+	_ resource.ResourceWithImportState = (*mackerelAlertGroupSettingResource)(nil)
+)
+
+func NewMackerelAlertGroupSettingResource() resource.Resource {
+	return &mackerelAlertGroupSettingResource{}
+}
+
+type mackerelAlertGroupSettingResource struct {
+	Client *mackerel.Client
+}
+
+type mackerelAlertGroupSettingResourceModel struct {
+	mackerel.AlertGroupSettingModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *mackerelAlertGroupSettingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alert_group_setting"
+}
+
+func (r *mackerelAlertGroupSettingResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource allows creating and management of alert group settings.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the alert group setting",
+
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the alert group setting",
+
+				Required: true,
+			},
+			"memo": schema.StringAttribute{
+				Description: "The notes for the alert group setting",
+
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+			"service_scopes": alertGroupSettingResourceScopesAttribute("A set of service names whose alerts are grouped"),
+			"role_scopes":    alertGroupSettingResourceScopesAttribute("A set of role fullnames whose alerts are grouped"),
+			"monitor_scopes": alertGroupSettingResourceScopesAttribute("A set of monitor IDs whose alerts are grouped"),
+			"notification_interval": schema.Int64Attribute{
+				Description: "The time interval for re-sending notifications in minutes. If 0, notifications will not be re-sent.",
+
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func alertGroupSettingResourceScopesAttribute(description string) schema.SetAttribute {
+	return schema.SetAttribute{
+		Description: description,
+
+		ElementType: types.StringType,
+		Optional:    true,
+		Computed:    true,
+		Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+	}
+}
+
+func (r *mackerelAlertGroupSettingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	client, diags := retrieveClient(ctx, req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+	r.Client = client
+}
+
+func (r *mackerelAlertGroupSettingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_alert_group_setting", "Create")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelAlertGroupSettingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Create(ctx, r.Client); err != nil {
+		resp.Diagnostics.Append(mackerel.NewAttributeErrorDiagnostic(
+			ctx,
+			"Unable to create Alert Group Setting",
+			err,
+			req.Plan.Schema,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *mackerelAlertGroupSettingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_alert_group_setting", "Read")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelAlertGroupSettingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Read)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Read(ctx, r.Client); err != nil {
+		if mackerel.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
+			"Unable to read Alert Group Setting",
+			err,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *mackerelAlertGroupSettingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_alert_group_setting", "Update")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelAlertGroupSettingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Update)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Update(ctx, r.Client); err != nil {
+		resp.Diagnostics.Append(mackerel.NewAttributeErrorDiagnostic(
+			ctx,
+			"Unable to update Alert Group Setting",
+			err,
+			req.Plan.Schema,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *mackerelAlertGroupSettingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_alert_group_setting", "Delete")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelAlertGroupSettingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Delete(ctx, r.Client); err != nil {
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
+			"Unable to delete Alert Group Setting",
+			err,
+		))
+		return
+	}
+}
+
+func (r *mackerelAlertGroupSettingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,371 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
+)
+
+var (
+	_ resource.Resource                 = (*mackerelAWSIntegrationResource)(nil)
+	_ resource.ResourceWithConfigure    = (*mackerelAWSIntegrationResource)(nil)
+	_ resource.ResourceWithImportState  = (*mackerelAWSIntegrationResource)(nil)
+	_ resource.ResourceWithUpgradeState = (*mackerelAWSIntegrationResource)(nil)
+)
+
+func NewMackerelAWSIntegrationResource() resource.Resource {
+	return &mackerelAWSIntegrationResource{}
+}
+
+type mackerelAWSIntegrationResource struct {
+	Client *mackerel.Client
+}
+
+type mackerelAWSIntegrationResourceModel struct {
+	mackerel.AWSIntegrationModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+type mackerelAWSIntegrationResourceModelV0 struct {
+	mackerel.AWSIntegrationModelV0
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *mackerelAWSIntegrationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_aws_integration"
+}
+
+func (r *mackerelAWSIntegrationResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	service := schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"enable": schema.BoolAttribute{
+				Description: "Whether the integration is enabled or not",
+
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"role": schema.StringAttribute{
+				Description: "The role fullname which the hosts are assigned to",
+
+				Optional: true,
+			},
+			"excluded_metrics": schema.ListAttribute{
+				Description: "A list of metric names not to be retrieved",
+
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default:     listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+			},
+			"retire_automatically": schema.BoolAttribute{
+				MarkdownDescription: "Whether to retire the hosts automatically when the AWS resources are deleted. Only supported by `ec2`, `rds` and `elasticache`.",
+
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				Validators: []validator.Bool{
+					mackerel.AWSIntegrationRetireAutomaticallyValidator(),
+				},
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "This resource allows creating and management of AWS integrations.",
+		// Version 0 is the schema with a block for each service, which is compatible with the SDK resource.
+		Version: 1,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the AWS integration",
+
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the AWS integration",
+
+				Required: true,
+			},
+			"memo":          awsIntegrationResourceStringAttribute("The notes for the AWS integration", false),
+			"key":           awsIntegrationResourceStringAttribute("The access key ID of the IAM user", true),
+			"secret_key":    awsIntegrationResourceStringAttribute("The secret access key of the IAM user", true),
+			"role_arn":      awsIntegrationResourceStringAttribute("The ARN of the IAM role", false),
+			"external_id":   awsIntegrationResourceStringAttribute("The external ID to assume the IAM role", true),
+			"region":        awsIntegrationResourceStringAttribute("The AWS region", false),
+			"included_tags": awsIntegrationResourceStringAttribute("The tags of the AWS resources to be integrated, e.g. `Name:staging-server,Environment:staging`", false),
+			"excluded_tags": awsIntegrationResourceStringAttribute("The tags of the AWS resources to be excluded from the integration", false),
+			"services": schema.MapNestedAttribute{
+				MarkdownDescription: "The integration settings keyed by the AWS service names, e.g. `ec2` and `alb`",
+
+				NestedObject: service,
+				Optional:     true,
+				Computed:     true,
+				Default:      mapdefault.StaticValue(types.MapValueMust(service.Type(), map[string]attr.Value{})),
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.OneOf(mackerel.AWSIntegrationServiceNames()...)),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func awsIntegrationResourceStringAttribute(description string, sensitive bool) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: description,
+
+		Optional:  true,
+		Computed:  true,
+		Default:   stringdefault.StaticString(""),
+		Sensitive: sensitive,
+	}
+}
+
+func (r *mackerelAWSIntegrationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	client, diags := retrieveClient(ctx, req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+	r.Client = client
+}
+
+func (r *mackerelAWSIntegrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_aws_integration", "Create")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelAWSIntegrationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Create(ctx, r.Client); err != nil {
+		resp.Diagnostics.Append(mackerel.NewAttributeErrorDiagnostic(
+			ctx,
+			"Unable to create AWS Integration",
+			err,
+			req.Plan.Schema,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *mackerelAWSIntegrationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_aws_integration", "Read")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelAWSIntegrationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Read)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Read(ctx, r.Client); err != nil {
+		if mackerel.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
+			"Unable to read AWS Integration",
+			err,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *mackerelAWSIntegrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_aws_integration", "Update")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelAWSIntegrationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Update)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Update(ctx, r.Client); err != nil {
+		resp.Diagnostics.Append(mackerel.NewAttributeErrorDiagnostic(
+			ctx,
+			"Unable to update AWS Integration",
+			err,
+			req.Plan.Schema,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *mackerelAWSIntegrationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_aws_integration", "Delete")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelAWSIntegrationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Delete(ctx, r.Client); err != nil {
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
+			"Unable to delete AWS Integration",
+			err,
+		))
+		return
+	}
+}
+
+func (r *mackerelAWSIntegrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *mackerelAWSIntegrationResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := awsIntegrationResourceSchemaV0(ctx)
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior mackerelAWSIntegrationResourceModelV0
+				resp.Diagnostics.Append(prior.get(ctx, req.State)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &mackerelAWSIntegrationResourceModel{
+					AWSIntegrationModel: prior.Upgrade(),
+					Timeouts:            prior.Timeouts,
+				})...)
+			},
+		},
+	}
+}
+
+// The service blocks are keyed by their names in the model, which the framework cannot map,
+// so the state of version 0 is read attribute by attribute.
+func (m *mackerelAWSIntegrationResourceModelV0) get(ctx context.Context, state *tfsdk.State) (diags diag.Diagnostics) {
+	attributes := map[string]any{
+		"id":            &m.ID,
+		"name":          &m.Name,
+		"memo":          &m.Memo,
+		"key":           &m.Key,
+		"secret_key":    &m.SecretKey,
+		"role_arn":      &m.RoleArn,
+		"external_id":   &m.ExternalID,
+		"region":        &m.Region,
+		"included_tags": &m.IncludedTags,
+		"excluded_tags": &m.ExcludedTags,
+		"timeouts":      &m.Timeouts,
+	}
+	for name, target := range attributes {
+		diags.Append(state.GetAttribute(ctx, path.Root(name), target)...)
+	}
+	m.Services = make(map[string][]mackerel.AWSIntegrationServiceModel, len(mackerel.AWSIntegrationServiceKeys))
+	for name := range mackerel.AWSIntegrationServiceKeys {
+		var service []mackerel.AWSIntegrationServiceModel
+		diags.Append(state.GetAttribute(ctx, path.Root(name), &service)...)
+		m.Services[name] = service
+	}
+	return diags
+}
+
+// The schema with a block for each service, which is a set of at most one element in the SDK resource.
+func awsIntegrationResourceSchemaV0(ctx context.Context) schema.Schema {
+	str := schema.StringAttribute{Optional: true}
+	blocks := map[string]schema.Block{
+		"timeouts": timeouts.Block(ctx, timeouts.Opts{
+			Create: true,
+			Read:   true,
+			Update: true,
+			Delete: true,
+		}),
+	}
+	for name := range mackerel.AWSIntegrationServiceKeys {
+		blocks[name] = schema.SetNestedBlock{
+			NestedObject: schema.NestedBlockObject{
+				Attributes: map[string]schema.Attribute{
+					"enable":               schema.BoolAttribute{Optional: true},
+					"role":                 str,
+					"excluded_metrics":     schema.ListAttribute{ElementType: types.StringType, Optional: true},
+					"retire_automatically": schema.BoolAttribute{Optional: true},
+				},
+			},
+		}
+	}
+
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":            schema.StringAttribute{Computed: true},
+			"name":          schema.StringAttribute{Required: true},
+			"memo":          str,
+			"key":           str,
+			"secret_key":    str,
+			"role_arn":      str,
+			"external_id":   str,
+			"region":        str,
+			"included_tags": str,
+			"excluded_tags": str,
+		},
+		Blocks: blocks,
+	}
+}
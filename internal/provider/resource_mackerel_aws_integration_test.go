@@ -0,0 +1,108 @@
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/provider"
+)
+
+func Test_MackerelAWSIntegrationResource_schema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	r := provider.NewMackerelAWSIntegrationResource()
+	req := fwresource.SchemaRequest{}
+	resp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("schema method diagnostics: %+v", resp.Diagnostics)
+	}
+
+	if diags := resp.Schema.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("schema validation diagnostics: %+v", diags)
+	}
+
+	upgraders := r.(fwresource.ResourceWithUpgradeState).UpgradeState(ctx)
+	for version, upgrader := range upgraders {
+		if version >= resp.Schema.Version {
+			t.Errorf("upgrader from version %d is not older than the schema version %d", version, resp.Schema.Version)
+		}
+		if diags := upgrader.PriorSchema.ValidateImplementation(ctx); diags.HasError() {
+			t.Errorf("prior schema (version %d) validation diagnostics: %+v", version, diags)
+		}
+	}
+}
+
+// Upgrades the state written by the SDK resource, which has no retire_automatically for the unsupported services.
+func Test_MackerelAWSIntegrationResource_upgradeSDKState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := provider.NewMackerelAWSIntegrationResource()
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	upgrader := r.(fwresource.ResourceWithUpgradeState).UpgradeState(ctx)[0]
+
+	priorState := tfsdk.State{
+		Schema: *upgrader.PriorSchema,
+		Raw:    tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(ctx), nil),
+	}
+	var diags diag.Diagnostics
+	diags.Append(priorState.SetAttribute(ctx, path.Root("id"), "aws0")...)
+	diags.Append(priorState.SetAttribute(ctx, path.Root("name"), "aws")...)
+	diags.Append(priorState.SetAttribute(ctx, path.Root("ec2"), []mackerel.AWSIntegrationServiceModel{{
+		Enable:              types.BoolValue(true),
+		Role:                types.StringNull(),
+		ExcludedMetrics:     []types.String{},
+		RetireAutomatically: types.BoolValue(true),
+	}})...)
+	diags.Append(priorState.SetAttribute(ctx, path.Root("alb"), []mackerel.AWSIntegrationServiceModel{{
+		Enable:              types.BoolValue(true),
+		Role:                types.StringNull(),
+		ExcludedMetrics:     []types.String{types.StringValue("b"), types.StringValue("a")},
+		RetireAutomatically: types.BoolNull(),
+	}})...)
+	if diags.HasError() {
+		t.Fatalf("state diagnostics: %+v", diags)
+	}
+
+	resp := fwresource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	upgrader.StateUpgrader(ctx, fwresource.UpgradeStateRequest{State: &priorState}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("upgrade diagnostics: %+v", resp.Diagnostics)
+	}
+
+	var services map[string]mackerel.AWSIntegrationServiceModel
+	diags.Append(resp.State.GetAttribute(ctx, path.Root("services"), &services)...)
+	if diags.HasError() {
+		t.Fatalf("get diagnostics: %+v", diags)
+	}
+	if len(services) != 2 {
+		t.Errorf("expected only ec2 and alb to be upgraded, but got: %+v", services)
+	}
+	if ec2 := services["ec2"]; !ec2.RetireAutomatically.Equal(types.BoolValue(true)) {
+		t.Errorf("expected retire_automatically of ec2 to be true, but got: %+v", ec2)
+	}
+	alb := services["alb"]
+	if !alb.RetireAutomatically.Equal(types.BoolValue(false)) {
+		t.Errorf("expected retire_automatically of alb to be false, but got: %+v", alb)
+	}
+	if len(alb.ExcludedMetrics) != 2 || alb.ExcludedMetrics[0].ValueString() != "b" {
+		t.Errorf("expected the order of excluded_metrics to be kept, but got: %+v", alb.ExcludedMetrics)
+	}
+}
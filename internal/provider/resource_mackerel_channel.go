@@ -0,0 +1,348 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
+)
+
+var (
+	_ resource.Resource                     = (*mackerelChannelResource)(nil)
+	_ resource.ResourceWithConfigure        = (*mackerelChannelResource)(nil)
+	_ resource.ResourceWithImportState      = (*mackerelChannelResource)(nil)
+	_ resource.ResourceWithConfigValidators = (*mackerelChannelResource)(nil)
+	_ resource.ResourceWithUpgradeState     = (*mackerelChannelResource)(nil)
+)
+
+func NewMackerelChannelResource() resource.Resource {
+	return &mackerelChannelResource{}
+}
+
+type mackerelChannelResource struct {
+	Client *mackerel.Client
+}
+
+type mackerelChannelResourceModel struct {
+	mackerel.ChannelModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+type mackerelChannelResourceModelV0 struct {
+	mackerel.ChannelModelV0
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *mackerelChannelResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_channel"
+}
+
+func (r *mackerelChannelResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource allows creating and management of notification channels. Every change replaces the channel since channels cannot be updated.",
+		// Version 0 is the schema with blocks, which is compatible with the SDK resource.
+		Version: 1,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the channel",
+
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the channel",
+
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"email": channelResourceAttribute("Configuration block of an email channel", map[string]schema.Attribute{
+				"emails":   channelResourceStringSetAttribute("A set of email addresses to receive notifications"),
+				"user_ids": channelResourceStringSetAttribute("A set of user IDs to receive notifications"),
+				"events":   channelResourceEventsAttribute("`alert` or `alertGroup`", mackerel.ChannelEmailEventValidator()),
+			}),
+			"slack": channelResourceAttribute("Configuration block of a Slack channel", map[string]schema.Attribute{
+				"url": schema.StringAttribute{
+					Description: "The incoming webhook URL of Slack",
+
+					Required: true,
+				},
+				"mentions": schema.MapAttribute{
+					MarkdownDescription: "The mentions for each alert status (`ok`, `warning` or `critical`)",
+
+					ElementType: types.StringType,
+					Optional:    true,
+					Computed:    true,
+					Default:     mapdefault.StaticValue(types.MapValueMust(types.StringType, map[string]attr.Value{})),
+					Validators: []validator.Map{
+						mapvalidator.KeysAre(mackerel.ChannelMentionValidator()),
+					},
+				},
+				"enabled_graph_image": schema.BoolAttribute{
+					Description: "Whether to post the graph image of the alert",
+
+					Optional: true,
+					Computed: true,
+					Default:  booldefault.StaticBool(false),
+				},
+				"events": channelResourceEventsAttribute("`alert`, `alertGroup`, `hostStatus`, `hostRegister`, `hostRetire` or `monitor`", mackerel.ChannelEventValidator()),
+			}),
+			"webhook": channelResourceAttribute("Configuration block of a webhook channel", map[string]schema.Attribute{
+				"url": schema.StringAttribute{
+					Description: "The URL to receive HTTP requests",
+
+					Required: true,
+				},
+				"events": channelResourceEventsAttribute("`alert`, `alertGroup`, `hostStatus`, `hostRegister`, `hostRetire` or `monitor`", mackerel.ChannelEventValidator()),
+			}),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func channelResourceAttribute(description string, attrs map[string]schema.Attribute) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: description,
+
+		Attributes: attrs,
+		Optional:   true,
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+func channelResourceStringSetAttribute(description string) schema.SetAttribute {
+	return schema.SetAttribute{
+		Description: description,
+
+		ElementType: types.StringType,
+		Optional:    true,
+		Computed:    true,
+		Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+	}
+}
+
+func channelResourceEventsAttribute(events string, eventValidator validator.String) schema.SetAttribute {
+	return schema.SetAttribute{
+		MarkdownDescription: fmt.Sprintf("A set of events to be notified (%s)", events),
+
+		ElementType: types.StringType,
+		Optional:    true,
+		Computed:    true,
+		Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+		Validators: []validator.Set{
+			setvalidator.ValueStringsAre(eventValidator),
+		},
+	}
+}
+
+func (r *mackerelChannelResource) ConfigValidators(context.Context) []resource.ConfigValidator {
+	paths := make([]path.Expression, 0, len(mackerel.ChannelTypes))
+	for _, name := range mackerel.ChannelTypes {
+		paths = append(paths, path.MatchRoot(name))
+	}
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(paths...),
+	}
+}
+
+func (r *mackerelChannelResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	client, diags := retrieveClient(ctx, req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+	r.Client = client
+}
+
+func (r *mackerelChannelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_channel", "Create")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelChannelResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Create(ctx, r.Client); err != nil {
+		resp.Diagnostics.Append(mackerel.NewAttributeErrorDiagnostic(
+			ctx,
+			"Unable to create Channel",
+			err,
+			req.Plan.Schema,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *mackerelChannelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_channel", "Read")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelChannelResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Read)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Read(ctx, r.Client); err != nil {
+		if mackerel.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
+			"Unable to read Channel",
+			err,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Every attribute except timeouts requires replacement, so there is nothing to send to the API.
+func (r *mackerelChannelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data mackerelChannelResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *mackerelChannelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_channel", "Delete")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelChannelResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Delete(ctx, r.Client); err != nil {
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
+			"Unable to delete Channel",
+			err,
+		))
+		return
+	}
+}
+
+func (r *mackerelChannelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *mackerelChannelResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := channelResourceSchemaV0(ctx)
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior mackerelChannelResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &mackerelChannelResourceModel{
+					ChannelModel: prior.Upgrade(),
+					Timeouts:     prior.Timeouts,
+				})...)
+			},
+		},
+	}
+}
+
+// The schema with blocks, where each channel kind is a list of at most one element.
+func channelResourceSchemaV0(ctx context.Context) schema.Schema {
+	block := func(attrs map[string]schema.Attribute) schema.ListNestedBlock {
+		return schema.ListNestedBlock{
+			NestedObject: schema.NestedBlockObject{
+				Attributes: attrs,
+			},
+		}
+	}
+	stringSet := schema.SetAttribute{ElementType: types.StringType, Optional: true}
+
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":   schema.StringAttribute{Computed: true},
+			"name": schema.StringAttribute{Required: true},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Delete: true,
+			}),
+			"email": block(map[string]schema.Attribute{
+				"emails":   stringSet,
+				"user_ids": stringSet,
+				"events":   stringSet,
+			}),
+			"slack": block(map[string]schema.Attribute{
+				"url":                 schema.StringAttribute{Required: true},
+				"mentions":            schema.MapAttribute{ElementType: types.StringType, Optional: true},
+				"enabled_graph_image": schema.BoolAttribute{Optional: true},
+				"events":              stringSet,
+			}),
+			"webhook": block(map[string]schema.Attribute{
+				"url":    schema.StringAttribute{Required: true},
+				"events": stringSet,
+			}),
+		},
+	}
+}
@@ -0,0 +1,695 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/validatorutil"
+)
+
+var (
+	_ resource.Resource                 = (*mackerelDashboardResource)(nil)
+	_ resource.ResourceWithConfigure    = (*mackerelDashboardResource)(nil)
+	_ resource.ResourceWithImportState  = (*mackerelDashboardResource)(nil)
+	_ resource.ResourceWithUpgradeState = (*mackerelDashboardResource)(nil)
+)
+
+func NewMackerelDashboardResource() resource.Resource {
+	return &mackerelDashboardResource{}
+}
+
+type mackerelDashboardResource struct {
+	Client *mackerel.Client
+}
+
+type mackerelDashboardResourceModel struct {
+	mackerel.DashboardModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+type mackerelDashboardResourceModelV0 struct {
+	mackerel.DashboardModelV0
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *mackerelDashboardResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dashboard"
+}
+
+func (r *mackerelDashboardResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource allows creating and management of dashboards.",
+		// Version 0 is the schema of the SDK resource.
+		Version: 1,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the dashboard",
+
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"title": schema.StringAttribute{
+				Description: "The title of the dashboard",
+
+				Required: true,
+			},
+			"memo": schema.StringAttribute{
+				Description: "The notes for the dashboard",
+
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+			"url_path": schema.StringAttribute{
+				Description: "The path of the URL of the dashboard",
+
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"graph": dashboardResourceWidgetsAttribute("A list of graph widgets", map[string]schema.Attribute{
+				"title": schema.StringAttribute{
+					Description: "The title of the widget",
+
+					Required: true,
+				},
+				"host":       dashboardResourceHostAttribute("role", "service", "expression", "query"),
+				"role":       dashboardResourceRoleAttribute(),
+				"service":    dashboardResourceServiceAttribute(),
+				"expression": dashboardResourceExpressionAttribute(),
+				"query":      dashboardResourceQueryAttribute(),
+				"range":      dashboardResourceRangeAttribute(),
+				"layout":     dashboardResourceLayoutAttribute(),
+			}),
+			"value": dashboardResourceWidgetsAttribute("A list of value widgets", map[string]schema.Attribute{
+				"title": schema.StringAttribute{
+					Description: "The title of the widget",
+
+					Required: true,
+				},
+				"fraction_size": schema.Int64Attribute{
+					Description: "The number of decimal places to display",
+
+					Optional: true,
+					Computed: true,
+					Default:  int64default.StaticInt64(0),
+				},
+				"suffix": schema.StringAttribute{
+					Description: "The suffix of the value",
+
+					Required: true,
+				},
+				"metric": schema.SingleNestedAttribute{
+					Description: "The metric to display",
+
+					Required: true,
+					Attributes: map[string]schema.Attribute{
+						"host":       dashboardResourceHostAttribute("service", "expression", "query"),
+						"service":    dashboardResourceServiceAttribute(),
+						"expression": dashboardResourceExpressionAttribute(),
+						"query":      dashboardResourceQueryAttribute(),
+					},
+				},
+				"layout": dashboardResourceLayoutAttribute(),
+			}),
+			"markdown": dashboardResourceWidgetsAttribute("A list of markdown widgets", map[string]schema.Attribute{
+				"title": schema.StringAttribute{
+					Description: "The title of the widget",
+
+					Required: true,
+				},
+				"markdown": schema.StringAttribute{
+					Description: "The content of the widget in markdown",
+
+					Required: true,
+				},
+				"layout": dashboardResourceLayoutAttribute(),
+			}),
+			"alert_status": dashboardResourceWidgetsAttribute("A list of alert status widgets", map[string]schema.Attribute{
+				"title": schema.StringAttribute{
+					Description: "The title of the widget",
+
+					Required: true,
+				},
+				"role_fullname": schema.StringAttribute{
+					Description: "The fullname of the role whose alerts are displayed",
+
+					Required: true,
+				},
+				"layout": dashboardResourceLayoutAttribute(),
+			}),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Widgets default to an empty list to keep the state of the former blocks, which are never null.
+func dashboardResourceWidgetsAttribute(description string, attrs map[string]schema.Attribute) schema.ListNestedAttribute {
+	nestedObject := schema.NestedAttributeObject{
+		Attributes: attrs,
+	}
+	return schema.ListNestedAttribute{
+		Description: description,
+
+		NestedObject: nestedObject,
+		Optional:     true,
+		Computed:     true,
+		Default:      listdefault.StaticValue(types.ListValueMust(nestedObject.Type(), []attr.Value{})),
+	}
+}
+
+// Graph and metric kinds are mutually exclusive, which is validated at the host attribute
+// since it is the first kind in both graph and value widgets.
+func dashboardResourceHostAttribute(otherKinds ...string) schema.SingleNestedAttribute {
+	otherPaths := make([]path.Expression, 0, len(otherKinds))
+	for _, kind := range otherKinds {
+		otherPaths = append(otherPaths, path.MatchRelative().AtParent().AtName(kind))
+	}
+	return schema.SingleNestedAttribute{
+		Description: "The host metric",
+
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"host_id": schema.StringAttribute{
+				Description: "The ID of the host",
+
+				Required: true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the metric",
+
+				Required: true,
+			},
+		},
+		Validators: []validator.Object{
+			objectvalidator.ExactlyOneOf(otherPaths...),
+		},
+	}
+}
+
+func dashboardResourceRoleAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "The role metric",
+
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"role_fullname": schema.StringAttribute{
+				Description: "The fullname of the role",
+
+				Required: true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the metric",
+
+				Required: true,
+			},
+			"is_stacked": schema.BoolAttribute{
+				Description: "Whether the graph is stacked",
+
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func dashboardResourceServiceAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "The service metric",
+
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"service_name": schema.StringAttribute{
+				Description: "The name of the service",
+
+				Required: true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the metric",
+
+				Required: true,
+			},
+		},
+	}
+}
+
+func dashboardResourceExpressionAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "The expression",
+
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"expression": schema.StringAttribute{
+				Description: "The expression of the metric",
+
+				Required: true,
+				Validators: []validator.String{
+					validatorutil.IsMetricExpression(),
+				},
+			},
+		},
+	}
+}
+
+func dashboardResourceQueryAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "The query",
+
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"query": schema.StringAttribute{
+				Description: "The PromQL-style query",
+
+				Required: true,
+				Validators: []validator.String{
+					validatorutil.IsMetricQuery(),
+				},
+			},
+			"legend": schema.StringAttribute{
+				Description: "The legend of the query",
+
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+		},
+	}
+}
+
+func dashboardResourceRangeAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "The time range of the graph",
+
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"relative": schema.SingleNestedAttribute{
+				Description: "The time range relative to the current time",
+
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"period": schema.Int64Attribute{
+						Description: "The length of the range in seconds",
+
+						Required: true,
+					},
+					"offset": schema.Int64Attribute{
+						Description: "The offset of the end of the range from the current time in seconds",
+
+						Required: true,
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(
+						path.MatchRelative().AtParent().AtName("absolute"),
+					),
+				},
+			},
+			"absolute": schema.SingleNestedAttribute{
+				Description: "The absolute time range",
+
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"start": schema.Int64Attribute{
+						Description: "The start of the range in epoch seconds",
+
+						Required: true,
+					},
+					"end": schema.Int64Attribute{
+						Description: "The end of the range in epoch seconds",
+
+						Required: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func dashboardResourceLayoutAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "The position and the size of the widget",
+
+		Required: true,
+		Attributes: map[string]schema.Attribute{
+			"x": schema.Int64Attribute{
+				Description: "The horizontal position",
+
+				Required: true,
+			},
+			"y": schema.Int64Attribute{
+				Description: "The vertical position",
+
+				Required: true,
+			},
+			"width": schema.Int64Attribute{
+				Description: "The width",
+
+				Required: true,
+			},
+			"height": schema.Int64Attribute{
+				Description: "The height",
+
+				Required: true,
+			},
+		},
+	}
+}
+
+func (r *mackerelDashboardResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := dashboardResourceSchemaV0(ctx)
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior mackerelDashboardResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgraded, err := prior.Upgrade()
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Unable to upgrade Dashboard state",
+						err.Error(),
+					)
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &mackerelDashboardResourceModel{
+					DashboardModel: upgraded,
+					Timeouts:       prior.Timeouts,
+				})...)
+			},
+		},
+	}
+}
+
+// The schema of the SDK resource, where single objects are lists of an element.
+func dashboardResourceSchemaV0(ctx context.Context) schema.Schema {
+	layout := schema.ListNestedBlock{
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"x":      schema.Int64Attribute{Required: true},
+				"y":      schema.Int64Attribute{Required: true},
+				"width":  schema.Int64Attribute{Required: true},
+				"height": schema.Int64Attribute{Required: true},
+			},
+		},
+	}
+	host := schema.ListNestedBlock{
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"host_id": schema.StringAttribute{Required: true},
+				"name":    schema.StringAttribute{Required: true},
+			},
+		},
+	}
+	service := schema.ListNestedBlock{
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"service_name": schema.StringAttribute{Required: true},
+				"name":         schema.StringAttribute{Required: true},
+			},
+		},
+	}
+	expression := schema.ListNestedBlock{
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"expression": schema.StringAttribute{Required: true},
+			},
+		},
+	}
+	query := schema.ListNestedBlock{
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"query":  schema.StringAttribute{Required: true},
+				"legend": schema.StringAttribute{Optional: true},
+			},
+		},
+	}
+
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":       schema.StringAttribute{Computed: true},
+			"title":    schema.StringAttribute{Required: true},
+			"memo":     schema.StringAttribute{Optional: true},
+			"url_path": schema.StringAttribute{Optional: true},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+			"graph": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"title": schema.StringAttribute{Required: true},
+					},
+					Blocks: map[string]schema.Block{
+						"host": host,
+						"role": schema.ListNestedBlock{
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"role_fullname": schema.StringAttribute{Required: true},
+									"name":          schema.StringAttribute{Required: true},
+									"is_stacked":    schema.BoolAttribute{Optional: true},
+								},
+							},
+						},
+						"service":    service,
+						"expression": expression,
+						"query":      query,
+						"range": schema.ListNestedBlock{
+							NestedObject: schema.NestedBlockObject{
+								Blocks: map[string]schema.Block{
+									"relative": schema.ListNestedBlock{
+										NestedObject: schema.NestedBlockObject{
+											Attributes: map[string]schema.Attribute{
+												"period": schema.Int64Attribute{Required: true},
+												"offset": schema.Int64Attribute{Required: true},
+											},
+										},
+									},
+									"absolute": schema.ListNestedBlock{
+										NestedObject: schema.NestedBlockObject{
+											Attributes: map[string]schema.Attribute{
+												"start": schema.Int64Attribute{Required: true},
+												"end":   schema.Int64Attribute{Required: true},
+											},
+										},
+									},
+								},
+							},
+						},
+						"layout": layout,
+					},
+				},
+			},
+			"value": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"title":         schema.StringAttribute{Required: true},
+						"fraction_size": schema.Int64Attribute{Optional: true},
+						"suffix":        schema.StringAttribute{Required: true},
+					},
+					Blocks: map[string]schema.Block{
+						"metric": schema.ListNestedBlock{
+							NestedObject: schema.NestedBlockObject{
+								Blocks: map[string]schema.Block{
+									"host":       host,
+									"service":    service,
+									"expression": expression,
+									"query":      query,
+								},
+							},
+						},
+						"layout": layout,
+					},
+				},
+			},
+			"markdown": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"title":    schema.StringAttribute{Required: true},
+						"markdown": schema.StringAttribute{Required: true},
+					},
+					Blocks: map[string]schema.Block{
+						"layout": layout,
+					},
+				},
+			},
+			"alert_status": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"title":         schema.StringAttribute{Required: true},
+						"role_fullname": schema.StringAttribute{Required: true},
+					},
+					Blocks: map[string]schema.Block{
+						"layout": layout,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *mackerelDashboardResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	client, diags := retrieveClient(ctx, req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+	r.Client = client
+}
+
+func (r *mackerelDashboardResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_dashboard", "Create")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelDashboardResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Create(ctx, r.Client); err != nil {
+		resp.Diagnostics.Append(mackerel.NewAttributeErrorDiagnostic(
+			ctx,
+			"Unable to create Dashboard",
+			err,
+			req.Plan.Schema,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *mackerelDashboardResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_dashboard", "Read")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelDashboardResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Read)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Read(ctx, r.Client); err != nil {
+		if mackerel.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
+			"Unable to read Dashboard",
+			err,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *mackerelDashboardResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_dashboard", "Update")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelDashboardResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Update)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Update(ctx, r.Client); err != nil {
+		resp.Diagnostics.Append(mackerel.NewAttributeErrorDiagnostic(
+			ctx,
+			"Unable to update Dashboard",
+			err,
+			req.Plan.Schema,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *mackerelDashboardResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_dashboard", "Delete")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelDashboardResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Delete(ctx, r.Client); err != nil {
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
+			"Unable to delete Dashboard",
+			err,
+		))
+		return
+	}
+}
+
+func (r *mackerelDashboardResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
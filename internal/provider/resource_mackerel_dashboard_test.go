@@ -0,0 +1,37 @@
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/provider"
+)
+
+func Test_MackerelDashboardResource_schema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	r := provider.NewMackerelDashboardResource()
+	req := fwresource.SchemaRequest{}
+	resp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("schema method diagnostics: %+v", resp.Diagnostics)
+	}
+
+	if diags := resp.Schema.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("schema validation diagnostics: %+v", diags)
+	}
+
+	upgraders := r.(fwresource.ResourceWithUpgradeState).UpgradeState(ctx)
+	for version, upgrader := range upgraders {
+		if version >= resp.Schema.Version {
+			t.Errorf("upgrader from version %d is not older than the schema version %d", version, resp.Schema.Version)
+		}
+		if diags := upgrader.PriorSchema.ValidateImplementation(ctx); diags.HasError() {
+			t.Errorf("prior schema (version %d) validation diagnostics: %+v", version, diags)
+		}
+	}
+}
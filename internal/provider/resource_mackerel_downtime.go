@@ -0,0 +1,351 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
+)
+
+var (
+	_ resource.Resource                 = (*mackerelDowntimeResource)(nil)
+	_ resource.ResourceWithConfigure    = (*mackerelDowntimeResource)(nil)
+	_ resource.ResourceWithImportState  = (*mackerelDowntimeResource)(nil)
+	_ resource.ResourceWithUpgradeState = (*mackerelDowntimeResource)(nil)
+)
+
+func NewMackerelDowntimeResource() resource.Resource {
+	return &mackerelDowntimeResource{}
+}
+
+type mackerelDowntimeResource struct {
+	Client *mackerel.Client
+}
+
+type mackerelDowntimeResourceModel struct {
+	mackerel.DowntimeModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+type mackerelDowntimeResourceModelV0 struct {
+	mackerel.DowntimeModelV0
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *mackerelDowntimeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_downtime"
+}
+
+func (r *mackerelDowntimeResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource allows creating and management of scheduled downtimes.",
+		// Version 0 is the schema with blocks, which is compatible with the SDK resource.
+		Version: 1,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the downtime",
+
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the downtime",
+
+				Required: true,
+			},
+			"memo": schema.StringAttribute{
+				Description: "The notes for the downtime",
+
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+			"start": schema.Int64Attribute{
+				Description: "The start time of the downtime in epoch seconds",
+
+				Required: true,
+			},
+			"duration": schema.Int64Attribute{
+				Description: "The duration of the downtime in minutes",
+
+				Required: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"service_scopes":         downtimeResourceScopesAttribute("A set of service names targeted by the downtime"),
+			"service_exclude_scopes": downtimeResourceScopesAttribute("A set of service names excluded from the downtime"),
+			"role_scopes":            downtimeResourceScopesAttribute("A set of role fullnames targeted by the downtime"),
+			"role_exclude_scopes":    downtimeResourceScopesAttribute("A set of role fullnames excluded from the downtime"),
+			"monitor_scopes":         downtimeResourceScopesAttribute("A set of monitor IDs targeted by the downtime"),
+			"monitor_exclude_scopes": downtimeResourceScopesAttribute("A set of monitor IDs excluded from the downtime"),
+			"recurrence": schema.SingleNestedAttribute{
+				Description: "The recurrence settings of the downtime",
+
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						MarkdownDescription: "The recurrence unit (`hourly`, `daily`, `weekly`, `monthly` or `yearly`)",
+
+						Required: true,
+						Validators: []validator.String{
+							mackerel.DowntimeRecurrenceTypeValidator(),
+						},
+					},
+					"interval": schema.Int64Attribute{
+						Description: "The recurrence interval in the recurrence unit",
+
+						Required: true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"weekdays": schema.SetAttribute{
+						MarkdownDescription: "A set of the days of the week on which the downtime recurs (`Sunday`, `Monday`, ...). Only valid for the `weekly` recurrence.",
+
+						ElementType: types.StringType,
+						Optional:    true,
+						Computed:    true,
+						Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+						Validators: []validator.Set{
+							setvalidator.ValueStringsAre(mackerel.DowntimeWeekdayValidator()),
+						},
+					},
+					"until": schema.Int64Attribute{
+						Description: "The end time of the recurrence in epoch seconds. If 0, the downtime recurs indefinitely.",
+
+						Optional: true,
+						Computed: true,
+						Default:  int64default.StaticInt64(0),
+					},
+				},
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func downtimeResourceScopesAttribute(description string) schema.SetAttribute {
+	return schema.SetAttribute{
+		Description: description,
+
+		ElementType: types.StringType,
+		Optional:    true,
+		Computed:    true,
+		Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+	}
+}
+
+func (r *mackerelDowntimeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	client, diags := retrieveClient(ctx, req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+	r.Client = client
+}
+
+func (r *mackerelDowntimeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_downtime", "Create")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelDowntimeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Create(ctx, r.Client); err != nil {
+		resp.Diagnostics.Append(mackerel.NewAttributeErrorDiagnostic(
+			ctx,
+			"Unable to create Downtime",
+			err,
+			req.Plan.Schema,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *mackerelDowntimeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_downtime", "Read")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelDowntimeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Read)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Read(ctx, r.Client); err != nil {
+		if mackerel.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
+			"Unable to read Downtime",
+			err,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *mackerelDowntimeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_downtime", "Update")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelDowntimeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Update)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Update(ctx, r.Client); err != nil {
+		resp.Diagnostics.Append(mackerel.NewAttributeErrorDiagnostic(
+			ctx,
+			"Unable to update Downtime",
+			err,
+			req.Plan.Schema,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *mackerelDowntimeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_downtime", "Delete")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelDowntimeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Delete(ctx, r.Client); err != nil {
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
+			"Unable to delete Downtime",
+			err,
+		))
+		return
+	}
+}
+
+func (r *mackerelDowntimeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *mackerelDowntimeResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := downtimeResourceSchemaV0(ctx)
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior mackerelDowntimeResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &mackerelDowntimeResourceModel{
+					DowntimeModel: prior.Upgrade(),
+					Timeouts:      prior.Timeouts,
+				})...)
+			},
+		},
+	}
+}
+
+// The schema with blocks, where the recurrence is a list of at most one element.
+func downtimeResourceSchemaV0(ctx context.Context) schema.Schema {
+	scopes := schema.SetAttribute{ElementType: types.StringType, Optional: true}
+
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                     schema.StringAttribute{Computed: true},
+			"name":                   schema.StringAttribute{Required: true},
+			"memo":                   schema.StringAttribute{Optional: true},
+			"start":                  schema.Int64Attribute{Required: true},
+			"duration":               schema.Int64Attribute{Required: true},
+			"service_scopes":         scopes,
+			"service_exclude_scopes": scopes,
+			"role_scopes":            scopes,
+			"role_exclude_scopes":    scopes,
+			"monitor_scopes":         scopes,
+			"monitor_exclude_scopes": scopes,
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+			"recurrence": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"type":     schema.StringAttribute{Required: true},
+						"interval": schema.Int64Attribute{Required: true},
+						"weekdays": schema.SetAttribute{ElementType: types.StringType, Optional: true},
+						"until":    schema.Int64Attribute{Optional: true},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,691 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/typeutil"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/validatorutil"
+)
+
+var (
+	_ resource.Resource                     = (*mackerelMonitorResource)(nil)
+	_ resource.ResourceWithConfigure        = (*mackerelMonitorResource)(nil)
+	_ resource.ResourceWithImportState      = (*mackerelMonitorResource)(nil)
+	_ resource.ResourceWithConfigValidators = (*mackerelMonitorResource)(nil)
+	_ resource.ResourceWithValidateConfig   = (*mackerelMonitorResource)(nil)
+	_ resource.ResourceWithUpgradeState     = (*mackerelMonitorResource)(nil)
+)
+
+func NewMackerelMonitorResource() resource.Resource {
+	return &mackerelMonitorResource{}
+}
+
+type mackerelMonitorResource struct {
+	Client *mackerel.Client
+}
+
+type mackerelMonitorResourceModel struct {
+	mackerel.MonitorModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+type mackerelMonitorResourceModelV0 struct {
+	mackerel.MonitorModelV0
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *mackerelMonitorResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_monitor"
+}
+
+func (r *mackerelMonitorResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource allows creating and management of monitors.",
+		// Version 0 is the schema with blocks, which is compatible with the SDK resource.
+		Version: 1,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the monitor",
+
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the monitor",
+
+				Required: true,
+			},
+			"memo": schema.StringAttribute{
+				Description: "The notes for the monitoring configuration",
+
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+			"is_mute": schema.BoolAttribute{
+				Description: "Whether monitoring is muted or not",
+
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"notification_interval": schema.Int64Attribute{
+				Description: "The time interval for re-sending notifications in minutes. If 0, notifications will not be re-sent.",
+
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
+			},
+			"host_metric": monitorResourceAttribute("The settings of a host metric monitor", map[string]schema.Attribute{
+				"metric": schema.StringAttribute{
+					Description: "The name of the host metric targeted by monitoring",
+
+					Required: true,
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.RequiresReplace(),
+					},
+				},
+				"operator": monitorResourceOperatorAttribute(),
+				"warning":  monitorResourceThresholdAttribute("warning", "critical"),
+				"critical": monitorResourceThresholdAttribute("critical", "warning"),
+				"duration": schema.Int64Attribute{
+					Description: "The duration of the monitor in minutes",
+
+					Required: true,
+					Validators: []validator.Int64{
+						int64validator.Between(1, 10),
+					},
+				},
+				"max_check_attempts": monitorResourceMaxCheckAttemptsAttribute(1),
+				"scopes":             monitorResourceScopesAttribute("A set of service names or role fullnames targeted by monitoring"),
+				"exclude_scopes":     monitorResourceScopesAttribute("A set of service names or role fullnames excluded from monitoring"),
+			}),
+			"connectivity": monitorResourceAttribute("The settings of a host connectivity monitor", map[string]schema.Attribute{
+				"scopes":         monitorResourceScopesAttribute("A set of service names or role fullnames targeted by monitoring"),
+				"exclude_scopes": monitorResourceScopesAttribute("A set of service names or role fullnames excluded from monitoring"),
+				"alert_status_on_gone": schema.StringAttribute{
+					MarkdownDescription: "The alert status when the monitoring target is gone (`CRITICAL` or `WARNING`)",
+
+					Optional: true,
+					Computed: true,
+					Default:  stringdefault.StaticString("CRITICAL"),
+					Validators: []validator.String{
+						stringvalidator.OneOf("CRITICAL", "WARNING"),
+					},
+				},
+			}),
+			"service_metric": monitorResourceAttribute("The settings of a service metric monitor", map[string]schema.Attribute{
+				"service": schema.StringAttribute{
+					Description: "The name of the service targeted by monitoring",
+
+					Required: true,
+				},
+				"metric": schema.StringAttribute{
+					Description: "The name of the service metric targeted by monitoring",
+
+					Required: true,
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.RequiresReplace(),
+					},
+				},
+				"operator": monitorResourceOperatorAttribute(),
+				"warning":  monitorResourceThresholdAttribute("warning", "critical"),
+				"critical": monitorResourceThresholdAttribute("critical", "warning"),
+				"duration": schema.Int64Attribute{
+					Description: "The number of the points of the metric to be averaged",
+
+					Required: true,
+				},
+				"max_check_attempts":        monitorResourceMaxCheckAttemptsAttribute(1),
+				"missing_duration_warning":  monitorResourceMissingDurationAttribute("The duration in minutes of interruption to generate a warning alert"),
+				"missing_duration_critical": monitorResourceMissingDurationAttribute("The duration in minutes of interruption to generate a critical alert"),
+			}),
+			"external": monitorResourceAttribute("The settings of an external HTTP monitor", map[string]schema.Attribute{
+				"method": schema.StringAttribute{
+					MarkdownDescription: "The request method (`GET`, `POST`, `PUT` or `DELETE`)",
+
+					Required: true,
+					Validators: []validator.String{
+						stringvalidator.OneOf("GET", "POST", "PUT", "DELETE"),
+					},
+				},
+				"url": schema.StringAttribute{
+					Description: "The URL targeted by monitoring",
+
+					Required: true,
+					Validators: []validator.String{
+						validatorutil.IsURLWithHTTPorHTTPS(),
+					},
+				},
+				"max_check_attempts": monitorResourceMaxCheckAttemptsAttribute(1),
+				"service": schema.StringAttribute{
+					Description: "The name of the service which the response time is graphed as a service metric of",
+
+					Optional: true,
+					Computed: true,
+					Default:  stringdefault.StaticString(""),
+				},
+				"response_time_critical": schema.Float64Attribute{
+					Description: "The response time threshold in milliseconds to generate a critical alert",
+
+					Optional: true,
+					Validators: []validator.Float64{
+						float64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("service")),
+					},
+				},
+				"response_time_warning": schema.Float64Attribute{
+					Description: "The response time threshold in milliseconds to generate a warning alert",
+
+					Optional: true,
+					Validators: []validator.Float64{
+						float64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("service")),
+					},
+				},
+				"response_time_duration": schema.Int64Attribute{
+					Description: "The duration in minutes to monitor the average of the response time",
+
+					Optional: true,
+					Validators: []validator.Int64{
+						int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("service")),
+						int64validator.Between(1, 10),
+					},
+				},
+				"request_body": schema.StringAttribute{
+					Description: "The HTTP request body",
+
+					Optional: true,
+					Computed: true,
+					Default:  stringdefault.StaticString(""),
+				},
+				"contains_string": schema.StringAttribute{
+					Description: "The string which should be contained by the response body",
+
+					Optional: true,
+					Computed: true,
+					Default:  stringdefault.StaticString(""),
+				},
+				"certification_expiration_critical": schema.Int64Attribute{
+					Description: "The number of days remaining until the certificate expires to generate a critical alert",
+
+					Optional: true,
+				},
+				"certification_expiration_warning": schema.Int64Attribute{
+					Description: "The number of days remaining until the certificate expires to generate a warning alert",
+
+					Optional: true,
+				},
+				"skip_certificate_verification": schema.BoolAttribute{
+					Description: "Whether to skip the verification of the certificate",
+
+					Optional: true,
+					Computed: true,
+					Default:  booldefault.StaticBool(false),
+				},
+				"headers": schema.MapAttribute{
+					Description: "The HTTP request headers",
+
+					ElementType: types.StringType,
+					Optional:    true,
+					Computed:    true,
+					Sensitive:   true,
+					Default:     mapdefault.StaticValue(types.MapValueMust(types.StringType, map[string]attr.Value{})),
+				},
+				"follow_redirect": schema.BoolAttribute{
+					Description: "Whether to follow redirects and evaluate the response of the destination",
+
+					Optional: true,
+					Computed: true,
+					Default:  booldefault.StaticBool(false),
+				},
+			}),
+			"expression": monitorResourceAttribute("The settings of an expression monitor", map[string]schema.Attribute{
+				"expression": schema.StringAttribute{
+					Description: "The expression of the metric targeted by monitoring",
+
+					Required: true,
+					Validators: []validator.String{
+						validatorutil.IsMetricExpression(),
+					},
+				},
+				"operator": monitorResourceOperatorAttribute(),
+				"warning":  monitorResourceThresholdAttribute("warning", "critical"),
+				"critical": monitorResourceThresholdAttribute("critical", "warning"),
+			}),
+			"anomaly_detection": monitorResourceAttribute("The settings of an anomaly detection monitor for roles", map[string]schema.Attribute{
+				"warning_sensitivity":  monitorResourceSensitivityAttribute("warning", "critical"),
+				"critical_sensitivity": monitorResourceSensitivityAttribute("critical", "warning"),
+				"max_check_attempts":   monitorResourceMaxCheckAttemptsAttribute(3),
+				"training_period_from": schema.Int64Attribute{
+					Description: "The epoch seconds from which the monitor learns the metrics",
+
+					Optional: true,
+					Computed: true,
+					Default:  int64default.StaticInt64(0),
+				},
+				"scopes": schema.SetAttribute{
+					Description: "A set of role fullnames targeted by monitoring",
+
+					ElementType: types.StringType,
+					Required:    true,
+				},
+			}),
+			"query": monitorResourceAttribute("The settings of a query monitor", map[string]schema.Attribute{
+				"query": schema.StringAttribute{
+					Description: "The PromQL-style query",
+
+					Required: true,
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.RequiresReplace(),
+					},
+					Validators: []validator.String{
+						validatorutil.IsMetricQuery(),
+					},
+				},
+				"legend": schema.StringAttribute{
+					Description: "The legend of the query",
+
+					Required: true,
+				},
+				"operator": monitorResourceOperatorAttribute(),
+				"warning":  monitorResourceThresholdAttribute("warning", "critical"),
+				"critical": monitorResourceThresholdAttribute("critical", "warning"),
+			}),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func monitorResourceAttribute(description string, attrs map[string]schema.Attribute) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: description,
+
+		Attributes: attrs,
+		Optional:   true,
+	}
+}
+
+func monitorResourceOperatorAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "The comparison operator to determine whether the observed value (on the left) is bigger or smaller than the threshold (`>` or `<`)",
+
+		Required: true,
+		Validators: []validator.String{
+			mackerel.MonitorOperatorValidator(),
+		},
+	}
+}
+
+func monitorResourceThresholdAttribute(level, other string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: fmt.Sprintf("The threshold to generate a %s alert", level),
+
+		CustomType: typeutil.FloatStringType{},
+		Optional:   true,
+		Validators: []validator.String{
+			stringvalidator.AtLeastOneOf(path.MatchRelative().AtParent().AtName(other)),
+		},
+	}
+}
+
+func monitorResourceSensitivityAttribute(level, other string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("The sensitivity to generate a %s alert (`insensitive`, `normal` or `sensitive`)", level),
+
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString(""),
+		Validators: []validator.String{
+			stringvalidator.AtLeastOneOf(path.MatchRelative().AtParent().AtName(other + "_sensitivity")),
+			mackerel.MonitorSensitivityValidator(),
+		},
+	}
+}
+
+func monitorResourceMaxCheckAttemptsAttribute(defaultValue int64) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Description: "The number of consecutive warning/critical counts before an alert is made",
+
+		Optional: true,
+		Computed: true,
+		Default:  int64default.StaticInt64(defaultValue),
+		Validators: []validator.Int64{
+			int64validator.Between(1, 10),
+		},
+	}
+}
+
+func monitorResourceMissingDurationAttribute(description string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Description: description,
+
+		Optional: true,
+		Computed: true,
+		Default:  int64default.StaticInt64(0),
+		Validators: []validator.Int64{
+			int64validator.Between(10, 7*24*60),
+			validatorutil.IntDivisibleBy(10),
+		},
+	}
+}
+
+func monitorResourceScopesAttribute(description string) schema.SetAttribute {
+	return schema.SetAttribute{
+		Description: description,
+
+		ElementType: types.StringType,
+		Optional:    true,
+		Computed:    true,
+		Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+	}
+}
+
+func (r *mackerelMonitorResource) ConfigValidators(context.Context) []resource.ConfigValidator {
+	paths := make([]path.Expression, 0, len(mackerel.MonitorTypes))
+	for _, name := range mackerel.MonitorTypes {
+		paths = append(paths, path.MatchRoot(name))
+	}
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(paths...),
+	}
+}
+
+func (r *mackerelMonitorResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	for _, name := range mackerel.ThresholdMonitorTypes {
+		var operator types.String
+		var warning, critical typeutil.FloatString
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name).AtName("operator"), &operator)...)
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name).AtName("warning"), &warning)...)
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name).AtName("critical"), &critical)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Invalid thresholds are reported by the type validation.
+		w, c := warning.ValueFloat64Pointer(), critical.ValueFloat64Pointer()
+		if operator.IsUnknown() || w == nil || c == nil {
+			continue
+		}
+		if err := mackerel.ValidateMonitorThresholdOrder(operator.ValueString(), *w, *c); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name).AtName("warning"),
+				"Invalid Threshold Order",
+				err.Error(),
+			)
+		}
+	}
+}
+
+func (r *mackerelMonitorResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := monitorResourceSchemaV0(ctx)
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior mackerelMonitorResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &mackerelMonitorResourceModel{
+					MonitorModel: prior.Upgrade(),
+					Timeouts:     prior.Timeouts,
+				})...)
+			},
+		},
+	}
+}
+
+// The schema with blocks, where each monitor type is a list of at most one element.
+func monitorResourceSchemaV0(ctx context.Context) schema.Schema {
+	block := func(attrs map[string]schema.Attribute) schema.ListNestedBlock {
+		return schema.ListNestedBlock{
+			NestedObject: schema.NestedBlockObject{
+				Attributes: attrs,
+			},
+		}
+	}
+	threshold := schema.StringAttribute{CustomType: typeutil.FloatStringType{}, Optional: true}
+	scopes := schema.SetAttribute{ElementType: types.StringType, Optional: true}
+
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                    schema.StringAttribute{Computed: true},
+			"name":                  schema.StringAttribute{Required: true},
+			"memo":                  schema.StringAttribute{Optional: true},
+			"is_mute":               schema.BoolAttribute{Optional: true},
+			"notification_interval": schema.Int64Attribute{Optional: true},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+			"host_metric": block(map[string]schema.Attribute{
+				"metric":             schema.StringAttribute{Required: true},
+				"operator":           schema.StringAttribute{Required: true},
+				"warning":            threshold,
+				"critical":           threshold,
+				"duration":           schema.Int64Attribute{Required: true},
+				"max_check_attempts": schema.Int64Attribute{Optional: true},
+				"scopes":             scopes,
+				"exclude_scopes":     scopes,
+			}),
+			"connectivity": block(map[string]schema.Attribute{
+				"scopes":               scopes,
+				"exclude_scopes":       scopes,
+				"alert_status_on_gone": schema.StringAttribute{Optional: true},
+			}),
+			"service_metric": block(map[string]schema.Attribute{
+				"service":                   schema.StringAttribute{Required: true},
+				"metric":                    schema.StringAttribute{Required: true},
+				"operator":                  schema.StringAttribute{Required: true},
+				"warning":                   threshold,
+				"critical":                  threshold,
+				"duration":                  schema.Int64Attribute{Required: true},
+				"max_check_attempts":        schema.Int64Attribute{Optional: true},
+				"missing_duration_warning":  schema.Int64Attribute{Optional: true},
+				"missing_duration_critical": schema.Int64Attribute{Optional: true},
+			}),
+			"external": block(map[string]schema.Attribute{
+				"method":                            schema.StringAttribute{Required: true},
+				"url":                               schema.StringAttribute{Required: true},
+				"max_check_attempts":                schema.Int64Attribute{Optional: true},
+				"service":                           schema.StringAttribute{Optional: true},
+				"response_time_critical":            schema.Float64Attribute{Optional: true},
+				"response_time_warning":             schema.Float64Attribute{Optional: true},
+				"response_time_duration":            schema.Int64Attribute{Optional: true},
+				"request_body":                      schema.StringAttribute{Optional: true},
+				"contains_string":                   schema.StringAttribute{Optional: true},
+				"certification_expiration_critical": schema.Int64Attribute{Optional: true},
+				"certification_expiration_warning":  schema.Int64Attribute{Optional: true},
+				"skip_certificate_verification":     schema.BoolAttribute{Optional: true},
+				"headers":                           schema.MapAttribute{ElementType: types.StringType, Optional: true, Sensitive: true},
+				"follow_redirect":                   schema.BoolAttribute{Optional: true},
+			}),
+			"expression": block(map[string]schema.Attribute{
+				"expression": schema.StringAttribute{Required: true},
+				"operator":   schema.StringAttribute{Required: true},
+				"warning":    threshold,
+				"critical":   threshold,
+			}),
+			"anomaly_detection": block(map[string]schema.Attribute{
+				"warning_sensitivity":  schema.StringAttribute{Optional: true},
+				"critical_sensitivity": schema.StringAttribute{Optional: true},
+				"max_check_attempts":   schema.Int64Attribute{Optional: true},
+				"training_period_from": schema.Int64Attribute{Optional: true},
+				"scopes":               schema.SetAttribute{ElementType: types.StringType, Required: true},
+			}),
+			"query": block(map[string]schema.Attribute{
+				"query":    schema.StringAttribute{Required: true},
+				"legend":   schema.StringAttribute{Required: true},
+				"operator": schema.StringAttribute{Required: true},
+				"warning":  threshold,
+				"critical": threshold,
+			}),
+		},
+	}
+}
+
+func (r *mackerelMonitorResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	client, diags := retrieveClient(ctx, req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+	r.Client = client
+}
+
+func (r *mackerelMonitorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_monitor", "Create")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelMonitorResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Create(ctx, r.Client); err != nil {
+		resp.Diagnostics.Append(mackerel.NewAttributeErrorDiagnostic(
+			ctx,
+			"Unable to create Monitor",
+			err,
+			req.Plan.Schema,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *mackerelMonitorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_monitor", "Read")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelMonitorResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Read)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Read(ctx, r.Client); err != nil {
+		if mackerel.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
+			"Unable to read Monitor",
+			err,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *mackerelMonitorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_monitor", "Update")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelMonitorResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Update)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Update(ctx, r.Client); err != nil {
+		resp.Diagnostics.Append(mackerel.NewAttributeErrorDiagnostic(
+			ctx,
+			"Unable to update Monitor",
+			err,
+			req.Plan.Schema,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *mackerelMonitorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_monitor", "Delete")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelMonitorResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.Delete(ctx, r.Client); err != nil {
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
+			"Unable to delete Monitor",
+			err,
+		))
+		return
+	}
+}
+
+func (r *mackerelMonitorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
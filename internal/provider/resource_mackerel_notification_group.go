@@ -3,11 +3,14 @@ package provider
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -29,11 +32,16 @@ type mackerelNotificationGroupResource struct {
 	Client *mackerel.Client
 }
 
+type mackerelNotificationGroupResourceModel struct {
+	mackerel.NotificationGroupModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
 func (r *mackerelNotificationGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_notification_group"
 }
 
-func (r *mackerelNotificationGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *mackerelNotificationGroupResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "This resource allows creating and management of notification groups",
 
@@ -73,46 +81,54 @@ func (r *mackerelNotificationGroupResource) Schema(_ context.Context, _ resource
 				ElementType: types.StringType,
 				Optional:    true,
 			},
-		},
-		// TODO: migrate to nested attributes
-		Blocks: map[string]schema.Block{
-			"monitor": schema.SetNestedBlock{
-				Description: "Configuration block(s) with monitor rules",
-
-				NestedObject: schema.NestedBlockObject{
-					Attributes: map[string]schema.Attribute{
-						"id": schema.StringAttribute{
-							Description: "The monitor rule ID",
-
-							Required: true,
-						},
-						"skip_default": schema.BoolAttribute{
-							Description: "If true, send notifications to this notification group only.",
-
-							Optional: true,
-							Computed: true,
-							Default:  booldefault.StaticBool(false),
-						},
-					},
+			"monitor": notificationGroupResourceTargetsAttribute("A set of notification target monitor rules", map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Description: "The monitor rule ID",
+
+					Required: true,
 				},
-			},
-			"service": schema.SetNestedBlock{
-				Description: "Configuration block(s) with services",
-
-				NestedObject: schema.NestedBlockObject{
-					Attributes: map[string]schema.Attribute{
-						"name": schema.StringAttribute{
-							Description: "The name of the service",
-
-							Required: true,
-							Validators: []validator.String{
-								mackerel.ServiceNameValidator(),
-							},
-						},
+				"skip_default": schema.BoolAttribute{
+					Description: "If true, send notifications to this notification group only.",
+
+					Optional: true,
+					Computed: true,
+					Default:  booldefault.StaticBool(false),
+				},
+			}),
+			"service": notificationGroupResourceTargetsAttribute("A set of notification target services", map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Description: "The name of the service",
+
+					Required: true,
+					Validators: []validator.String{
+						mackerel.ServiceNameValidator(),
 					},
 				},
-			},
+			}),
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Targets default to an empty set to keep the state of the former blocks, which are never null.
+func notificationGroupResourceTargetsAttribute(description string, attrs map[string]schema.Attribute) schema.SetNestedAttribute {
+	nestedObject := schema.NestedAttributeObject{
+		Attributes: attrs,
+	}
+	return schema.SetNestedAttribute{
+		Description: description,
+
+		NestedObject: nestedObject,
+		Optional:     true,
+		Computed:     true,
+		Default:      setdefault.StaticValue(types.SetValueMust(nestedObject.Type(), []attr.Value{})),
 	}
 }
 
@@ -126,17 +142,29 @@ func (r *mackerelNotificationGroupResource) Configure(ctx context.Context, req r
 }
 
 func (r *mackerelNotificationGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data mackerel.NotificationGroupModel
+	ctx, endSpan := startSpan(ctx, "mackerel_notification_group", "Create")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelNotificationGroupResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err := data.Create(ctx, r.Client); err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(mackerel.NewAttributeErrorDiagnostic(
+			ctx,
 			"Unable to create Notification Group",
-			err.Error(),
-		)
+			err,
+			req.Plan.Schema,
+		))
 		return
 	}
 
@@ -144,17 +172,31 @@ func (r *mackerelNotificationGroupResource) Create(ctx context.Context, req reso
 }
 
 func (r *mackerelNotificationGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data mackerel.NotificationGroupModel
+	ctx, endSpan := startSpan(ctx, "mackerel_notification_group", "Read")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelNotificationGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Read)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err := data.Read(ctx, r.Client); err != nil {
-		resp.Diagnostics.AddError(
+		if mackerel.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
 			"Unable read Notification Group",
-			err.Error(),
-		)
+			err,
+		))
 		return
 	}
 
@@ -162,17 +204,29 @@ func (r *mackerelNotificationGroupResource) Read(ctx context.Context, req resour
 }
 
 func (r *mackerelNotificationGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data mackerel.NotificationGroupModel
+	ctx, endSpan := startSpan(ctx, "mackerel_notification_group", "Update")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelNotificationGroupResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Update)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err := data.Update(ctx, r.Client); err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(mackerel.NewAttributeErrorDiagnostic(
+			ctx,
 			"Unable to update Notification Group",
-			err.Error(),
-		)
+			err,
+			req.Plan.Schema,
+		))
 		return
 	}
 
@@ -180,17 +234,27 @@ func (r *mackerelNotificationGroupResource) Update(ctx context.Context, req reso
 }
 
 func (r *mackerelNotificationGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data mackerel.NotificationGroupModel
+	ctx, endSpan := startSpan(ctx, "mackerel_notification_group", "Delete")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelNotificationGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err := data.Delete(ctx, r.Client); err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
 			"Unable to delete Notification Group",
-			err.Error(),
-		)
+			err,
+		))
 		return
 	}
 
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -27,11 +28,16 @@ type mackerelRoleResource struct {
 	Client *mackerel.Client
 }
 
+type mackerelRoleResourceModel struct {
+	mackerel.RoleModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
 func (r *mackerelRoleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_role"
 }
 
-func (r *mackerelRoleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *mackerelRoleResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "This resource allows creating and management of Roles.",
 		Attributes: map[string]schema.Attribute{
@@ -71,6 +77,13 @@ func (r *mackerelRoleResource) Schema(_ context.Context, _ resource.SchemaReques
 				Default: stringdefault.StaticString(""),
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -84,17 +97,29 @@ func (r *mackerelRoleResource) Configure(ctx context.Context, req resource.Confi
 }
 
 func (r *mackerelRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data mackerel.RoleModel
+	ctx, endSpan := startSpan(ctx, "mackerel_role", "Create")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelRoleResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err := data.Create(ctx, r.Client); err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(mackerel.NewAttributeErrorDiagnostic(
+			ctx,
 			"Unable to create Role",
-			err.Error(),
-		)
+			err,
+			req.Plan.Schema,
+		))
 		return
 	}
 
@@ -102,42 +127,73 @@ func (r *mackerelRoleResource) Create(ctx context.Context, req resource.CreateRe
 }
 
 func (r *mackerelRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data mackerel.RoleModel
+	ctx, endSpan := startSpan(ctx, "mackerel_role", "Read")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelRoleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Read)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err := data.Read(ctx, r.Client); err != nil {
-		resp.Diagnostics.AddError(
+		if mackerel.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
 			"Unable to read Role",
-			err.Error(),
-		)
+			err,
+		))
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func (r *mackerelRoleResource) Update(_ context.Context, _ resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Unable to update Role",
-		"Mackerel Service Roles cannot be updated in-place. Please report this issue.",
-	)
+// Only timeouts can be updated in-place since the other attributes require replacement.
+func (r *mackerelRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_role", "Update")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelRoleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *mackerelRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data mackerel.RoleModel
+	ctx, endSpan := startSpan(ctx, "mackerel_role", "Delete")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelRoleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err := data.Delete(ctx, r.Client); err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
 			"Unable to delete Role",
-			err.Error(),
-		)
+			err,
+		))
 		return
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -27,11 +28,16 @@ type mackerelRoleMetadataResource struct {
 	Client *mackerel.Client
 }
 
+type mackerelRoleMetadataResourceModel struct {
+	mackerel.RoleMetadataModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
 func (r *mackerelRoleMetadataResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_role_metadata"
 }
 
-func (r *mackerelRoleMetadataResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *mackerelRoleMetadataResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "This resource creates and manages a Role Metadata.",
 
@@ -75,6 +81,14 @@ func (r *mackerelRoleMetadataResource) Schema(_ context.Context, _ resource.Sche
 				CustomType:  jsontypes.NormalizedType{},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -88,17 +102,29 @@ func (r *mackerelRoleMetadataResource) Configure(ctx context.Context, req resour
 }
 
 func (r *mackerelRoleMetadataResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data mackerel.RoleMetadataModel
+	ctx, endSpan := startSpan(ctx, "mackerel_role_metadata", "Create")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelRoleMetadataResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err := data.Create(ctx, r.Client); err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(mackerel.NewAttributeErrorDiagnostic(
+			ctx,
 			"Unable to create Role Metadata",
-			err.Error(),
-		)
+			err,
+			req.Plan.Schema,
+		))
 		return
 	}
 
@@ -106,17 +132,31 @@ func (r *mackerelRoleMetadataResource) Create(ctx context.Context, req resource.
 }
 
 func (r *mackerelRoleMetadataResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data mackerel.RoleMetadataModel
+	ctx, endSpan := startSpan(ctx, "mackerel_role_metadata", "Read")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelRoleMetadataResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Read)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err := data.Read(ctx, r.Client); err != nil {
-		resp.Diagnostics.AddError(
+		if mackerel.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
 			"Unable to read Role Metadata",
-			err.Error(),
-		)
+			err,
+		))
 		return
 	}
 
@@ -124,17 +164,29 @@ func (r *mackerelRoleMetadataResource) Read(ctx context.Context, req resource.Re
 }
 
 func (r *mackerelRoleMetadataResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data mackerel.RoleMetadataModel
+	ctx, endSpan := startSpan(ctx, "mackerel_role_metadata", "Update")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelRoleMetadataResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Update)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err := data.Update(ctx, r.Client); err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(mackerel.NewAttributeErrorDiagnostic(
+			ctx,
 			"Unable to update Role Metadata",
-			err.Error(),
-		)
+			err,
+			req.Plan.Schema,
+		))
 		return
 	}
 
@@ -142,17 +194,27 @@ func (r *mackerelRoleMetadataResource) Update(ctx context.Context, req resource.
 }
 
 func (r *mackerelRoleMetadataResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data mackerel.RoleMetadataModel
+	ctx, endSpan := startSpan(ctx, "mackerel_role_metadata", "Delete")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelRoleMetadataResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err := data.Delete(ctx, r.Client); err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
 			"Unable to delete Role Metadata",
-			err.Error(),
-		)
+			err,
+		))
 		return
 	}
 }
@@ -167,5 +229,12 @@ func (r *mackerelRoleMetadataResource) ImportState(ctx context.Context, req reso
 		)
 		return
 	}
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	state := mackerelRoleMetadataResourceModel{RoleMetadataModel: data}
+	// Timeouts are not configured yet, so take the null value from the empty state.
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("timeouts"), &state.Timeouts)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -4,7 +4,11 @@ import (
 	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/provider"
 )
 
@@ -23,3 +27,33 @@ func Test_MackerelRoleMetadataResource_schema(t *testing.T) {
 		t.Fatalf("schema validation diagnostics: %+v", diags)
 	}
 }
+
+func Test_MackerelRoleMetadataResource_importState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := provider.NewMackerelRoleMetadataResource()
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	req := fwresource.ImportStateRequest{ID: "service0:role0/ns0"}
+	resp := fwresource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	r.(fwresource.ResourceWithImportState).ImportState(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("import diagnostics: %+v", resp.Diagnostics)
+	}
+
+	var got timeouts.Value
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("timeouts"), &got)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("get diagnostics: %+v", resp.Diagnostics)
+	}
+	if !got.IsNull() {
+		t.Errorf("expected timeouts to be null, but got %s", got)
+	}
+}
@@ -3,6 +3,8 @@ package provider
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -26,11 +28,16 @@ type mackerelServiceResource struct {
 	Client *mackerel.Client
 }
 
+type mackerelServiceResourceModel struct {
+	mackerel.ServiceModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
 func (r *mackerelServiceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_service"
 }
 
-func (r *mackerelServiceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *mackerelServiceResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "The `mackerel_service` resource allows creating and management of Service.",
 		Attributes: map[string]schema.Attribute{
@@ -60,6 +67,13 @@ func (r *mackerelServiceResource) Schema(_ context.Context, _ resource.SchemaReq
 				Default: stringdefault.StaticString(""),
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -73,17 +87,29 @@ func (r *mackerelServiceResource) Configure(ctx context.Context, req resource.Co
 }
 
 func (r *mackerelServiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data mackerel.ServiceModel
+	ctx, endSpan := startSpan(ctx, "mackerel_service", "Create")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelServiceResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err := data.Create(ctx, r.Client); err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(mackerel.NewAttributeErrorDiagnostic(
+			ctx,
 			"Unable to create Service",
-			err.Error(),
-		)
+			err,
+			req.Plan.Schema,
+		))
 		return
 	}
 
@@ -91,42 +117,73 @@ func (r *mackerelServiceResource) Create(ctx context.Context, req resource.Creat
 }
 
 func (r *mackerelServiceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data mackerel.ServiceModel
+	ctx, endSpan := startSpan(ctx, "mackerel_service", "Read")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelServiceResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Read)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err := data.Read(ctx, r.Client); err != nil {
-		resp.Diagnostics.AddError(
+		if mackerel.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
 			"Unable to read Service",
-			err.Error(),
-		)
+			err,
+		))
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func (r *mackerelServiceResource) Update(_ context.Context, _ resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Unable to update Service",
-		"Mackerel services cannot be updated in-place. Please report this issue.",
-	)
+// Only timeouts can be updated in-place since the other attributes require replacement.
+func (r *mackerelServiceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := startSpan(ctx, "mackerel_service", "Update")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelServiceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *mackerelServiceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data mackerel.ServiceModel
+	ctx, endSpan := startSpan(ctx, "mackerel_service", "Delete")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelServiceResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err := data.Delete(ctx, r.Client); err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
 			"Unable to delete Service",
-			err.Error(),
-		)
+			err,
+		))
 		return
 	}
 }
@@ -141,5 +198,12 @@ func (r *mackerelServiceResource) ImportState(ctx context.Context, req resource.
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	state := mackerelServiceResourceModel{ServiceModel: data}
+	// Timeouts are not configured yet, so take the null value from the empty state.
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("timeouts"), &state.Timeouts)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -30,11 +31,16 @@ type mackerelServiceMetadataResource struct {
 	Client *mackerel.Client
 }
 
+type mackerelServiceMetadataResourceModel struct {
+	mackerel.ServiceMetadataModel
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
 func (r *mackerelServiceMetadataResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_service_metadata"
 }
 
-func (r *mackerelServiceMetadataResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *mackerelServiceMetadataResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "This resource allows creating and management of Service Metadata.",
 		Attributes: map[string]schema.Attribute{
@@ -70,11 +76,19 @@ func (r *mackerelServiceMetadataResource) Schema(_ context.Context, _ resource.S
 				CustomType: jsontypes.NormalizedType{},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
 func (r *mackerelServiceMetadataResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
-	var data mackerel.ServiceMetadataModel
+	var data mackerelServiceMetadataResourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -93,13 +107,23 @@ func (r *mackerelServiceMetadataResource) Configure(ctx context.Context, req res
 }
 
 func (r *mackerelServiceMetadataResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data mackerel.ServiceMetadataModel
+	ctx, endSpan := startSpan(ctx, "mackerel_service_metadata", "Create")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelServiceMetadataResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	resp.Diagnostics.Append(r.createOrUpdate(ctx, &data)...)
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.createOrUpdate(ctx, &data.ServiceMetadataModel)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -108,32 +132,57 @@ func (r *mackerelServiceMetadataResource) Create(ctx context.Context, req resour
 }
 
 func (r *mackerelServiceMetadataResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data mackerel.ServiceMetadataModel
+	ctx, endSpan := startSpan(ctx, "mackerel_service_metadata", "Read")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelServiceMetadataResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	remoteData, err := mackerel.ReadServiceMetadata(ctx, r.Client, data)
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Read)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remoteData, err := mackerel.ReadServiceMetadata(ctx, r.Client, data.ServiceMetadataModel)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		if mackerel.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
 			fmt.Sprintf("Unable to read Service Metadata: %s", data.ID.ValueString()),
-			err.Error(),
-		)
+			err,
+		))
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &remoteData)...)
+	data.ServiceMetadataModel = remoteData
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *mackerelServiceMetadataResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data mackerel.ServiceMetadataModel
+	ctx, endSpan := startSpan(ctx, "mackerel_service_metadata", "Update")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelServiceMetadataResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	resp.Diagnostics.Append(r.createOrUpdate(ctx, &data)...)
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Update)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.createOrUpdate(ctx, &data.ServiceMetadataModel)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -142,17 +191,27 @@ func (r *mackerelServiceMetadataResource) Update(ctx context.Context, req resour
 }
 
 func (r *mackerelServiceMetadataResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data mackerel.ServiceMetadataModel
+	ctx, endSpan := startSpan(ctx, "mackerel_service_metadata", "Delete")
+	defer endSpan(&resp.Diagnostics)
+
+	var data mackerelServiceMetadataResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err := data.Delete(ctx, r.Client); err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(mackerel.NewErrorDiagnostic(
 			"Unable to delete Service Metadata",
-			err.Error(),
-		)
+			err,
+		))
 		return
 	}
 }
@@ -163,19 +222,19 @@ func (r *mackerelServiceMetadataResource) ImportState(ctx context.Context, req r
 
 func (r *mackerelServiceMetadataResource) createOrUpdate(ctx context.Context, data *mackerel.ServiceMetadataModel) (diags diag.Diagnostics) {
 	if err := data.CreateOrUpdateMetadata(ctx, r.Client); err != nil {
-		diags.AddError(
+		diags.Append(mackerel.NewErrorDiagnostic(
 			"Unable to put Service Metadata",
-			err.Error(),
-		)
+			err,
+		))
 		return
 	}
 
 	remoteData, err := mackerel.ReadServiceMetadata(ctx, r.Client, *data)
 	if err != nil {
-		diags.AddError(
+		diags.Append(mackerel.NewErrorDiagnostic(
 			"Unable to refresh Service Metadata after updates",
-			err.Error(),
-		)
+			err,
+		))
 		return
 	}
 	*data = remoteData
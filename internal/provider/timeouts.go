@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
+)
+
+// Bounds ctx by the configured timeout of the operation, e.g. withTimeout(ctx, data.Timeouts.Create).
+// The returned cancel function must be called even if there are diagnostics.
+func withTimeout(
+	ctx context.Context,
+	timeout func(context.Context, time.Duration) (time.Duration, diag.Diagnostics),
+) (context.Context, context.CancelFunc, diag.Diagnostics) {
+	d, diags := timeout(ctx, mackerel.DefaultTimeout)
+	if diags.HasError() {
+		return ctx, func() {}, diags
+	}
+	ctx, cancel := context.WithTimeout(ctx, d)
+	return ctx, cancel, diags
+}
@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
+)
+
+// Starts the span of the operation of a resource or a data source.
+// The returned function ends it with the diagnostics of the operation.
+func startSpan(ctx context.Context, typeName, operation string) (context.Context, func(*diag.Diagnostics)) {
+	ctx, span := mackerel.StartSpan(ctx, typeName, operation)
+	return ctx, func(diags *diag.Diagnostics) {
+		var errMessage string
+		for _, d := range diags.Errors() {
+			errMessage = d.Summary()
+			break
+		}
+		mackerel.EndSpan(span, errMessage)
+	}
+}
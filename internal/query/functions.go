@@ -0,0 +1,220 @@
+package query
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/textutil"
+)
+
+type signature struct {
+	minArgs int
+	// -1 means that any number of arguments is accepted.
+	maxArgs int
+	// Aggregations accept the by and without clauses.
+	aggregation bool
+	// The index of the argument which must be a range vector selector, or -1.
+	rangeArg int
+}
+
+func fn(minArgs, maxArgs int) signature {
+	return signature{minArgs: minArgs, maxArgs: maxArgs, rangeArg: -1}
+}
+
+func aggregation(args int) signature {
+	return signature{minArgs: args, maxArgs: args, aggregation: true, rangeArg: -1}
+}
+
+func rangeFn(args, rangeArg int) signature {
+	return signature{minArgs: args, maxArgs: args, rangeArg: rangeArg}
+}
+
+// Calls of other functions are only warned about since Mackerel may add new functions.
+var functions = map[string]signature{
+	"sum":          aggregation(1),
+	"avg":          aggregation(1),
+	"min":          aggregation(1),
+	"max":          aggregation(1),
+	"count":        aggregation(1),
+	"group":        aggregation(1),
+	"stddev":       aggregation(1),
+	"stdvar":       aggregation(1),
+	"topk":         aggregation(2),
+	"bottomk":      aggregation(2),
+	"quantile":     aggregation(2),
+	"count_values": aggregation(2),
+
+	"rate":               rangeFn(1, 0),
+	"irate":              rangeFn(1, 0),
+	"increase":           rangeFn(1, 0),
+	"delta":              rangeFn(1, 0),
+	"idelta":             rangeFn(1, 0),
+	"deriv":              rangeFn(1, 0),
+	"changes":            rangeFn(1, 0),
+	"resets":             rangeFn(1, 0),
+	"avg_over_time":      rangeFn(1, 0),
+	"min_over_time":      rangeFn(1, 0),
+	"max_over_time":      rangeFn(1, 0),
+	"sum_over_time":      rangeFn(1, 0),
+	"count_over_time":    rangeFn(1, 0),
+	"last_over_time":     rangeFn(1, 0),
+	"stddev_over_time":   rangeFn(1, 0),
+	"stdvar_over_time":   rangeFn(1, 0),
+	"present_over_time":  rangeFn(1, 0),
+	"absent_over_time":   rangeFn(1, 0),
+	"quantile_over_time": rangeFn(2, 1),
+	"predict_linear":     rangeFn(2, 0),
+
+	"abs":                fn(1, 1),
+	"ceil":               fn(1, 1),
+	"floor":              fn(1, 1),
+	"round":              fn(1, 2),
+	"exp":                fn(1, 1),
+	"ln":                 fn(1, 1),
+	"log2":               fn(1, 1),
+	"log10":              fn(1, 1),
+	"sqrt":               fn(1, 1),
+	"sgn":                fn(1, 1),
+	"clamp":              fn(3, 3),
+	"clamp_min":          fn(2, 2),
+	"clamp_max":          fn(2, 2),
+	"absent":             fn(1, 1),
+	"scalar":             fn(1, 1),
+	"vector":             fn(1, 1),
+	"sort":               fn(1, 1),
+	"sort_desc":          fn(1, 1),
+	"timestamp":          fn(1, 1),
+	"time":               fn(0, 0),
+	"histogram_quantile": fn(2, 2),
+	"label_replace":      fn(5, 5),
+	"label_join":         fn(3, -1),
+}
+
+// Returns the names of the known functions and aggregations in order.
+func FunctionNames() []string {
+	names := make([]string, 0, len(functions))
+	for name := range functions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Parses the query and checks the number of the arguments, the groupings and the range vectors.
+// Calls of unknown functions are not errors but warnings, whose arguments are checked as far as possible.
+func Validate(q string) (warnings []error, err error) {
+	node, err := Parse(q)
+	if err != nil {
+		return nil, err
+	}
+	v := &validator{}
+	if err := v.validateNode(node); err != nil {
+		return nil, err
+	}
+	return v.warnings, nil
+}
+
+type validator struct {
+	warnings []error
+}
+
+func (v *validator) validateNode(node Node) error {
+	switch node := node.(type) {
+	case *Selector:
+		if node.Range != "" {
+			return errorf(node.Offset, "range vector %s[%s] must be an argument of a function such as rate", node.Metric, node.Range)
+		}
+	case *Subquery:
+		return errorf(node.Offset, "subquery [%s:%s] must be an argument of a function such as max_over_time", node.Range, node.Step)
+	case *Call:
+		return v.validateCall(node)
+	case *Binary:
+		if err := v.validateNode(node.LHS); err != nil {
+			return err
+		}
+		return v.validateNode(node.RHS)
+	case *Unary:
+		return v.validateNode(node.Expr)
+	case *Paren:
+		return v.validateNode(node.Expr)
+	}
+	return nil
+}
+
+// Reports whether the node is a range vector, which is a range vector selector or a subquery.
+func (v *validator) validateRange(node Node) (bool, error) {
+	switch node := node.(type) {
+	case *Selector:
+		return node.Range != "", nil
+	case *Subquery:
+		return true, v.validateNode(node.Expr)
+	}
+	return false, nil
+}
+
+func (v *validator) validateCall(call *Call) error {
+	sig, ok := functions[call.Name]
+	if !ok {
+		if name := textutil.Suggest(call.Name, FunctionNames()); name != "" {
+			v.warnings = append(v.warnings, errorf(call.Offset, "unknown function %s, did you mean %s?", call.Name, name))
+		} else {
+			v.warnings = append(v.warnings, errorf(call.Offset, "unknown function %s", call.Name))
+		}
+		// Unknown functions may take range vectors.
+		for _, arg := range call.Args {
+			ok, err := v.validateRange(arg)
+			if err == nil && !ok {
+				err = v.validateNode(arg)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if n := len(call.Args); n < sig.minArgs || sig.maxArgs >= 0 && n > sig.maxArgs {
+		return errorf(call.Offset, "%s takes %s, but got %d", call.Name, sig.describeArity(), n)
+	}
+	if call.Grouping != nil && !sig.aggregation {
+		keyword := "by"
+		if call.Grouping.Without {
+			keyword = "without"
+		}
+		return errorf(call.Offset, "%s is not an aggregation and does not accept %s", call.Name, keyword)
+	}
+
+	for i, arg := range call.Args {
+		if i == sig.rangeArg {
+			ok, err := v.validateRange(arg)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return errorf(arg.Pos(), "argument %d of %s must be a range vector such as metric[5m]", i+1, call.Name)
+			}
+			continue
+		}
+		if err := v.validateNode(arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s signature) describeArity() string {
+	plural := func(n int) string {
+		if n == 1 {
+			return "1 argument"
+		}
+		return fmt.Sprintf("%d arguments", n)
+	}
+	switch {
+	case s.maxArgs < 0:
+		return "at least " + plural(s.minArgs)
+	case s.minArgs != s.maxArgs:
+		return fmt.Sprintf("%d to %s", s.minArgs, plural(s.maxArgs))
+	default:
+		return plural(s.minArgs)
+	}
+}
@@ -0,0 +1,118 @@
+package query_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/query"
+)
+
+func Test_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		in          string
+		wantWarning string
+		wantErr     string
+	}{
+		"selector": {
+			in: `container.cpu.utilization{k8s.deployment.name="httpbin"}`,
+		},
+		"over time": {
+			in: `avg(avg_over_time(container.cpu.utilization{k8s.deployment.name="httpbin"}[1h]))`,
+		},
+		"grouping": {
+			in: `sum by (k8s.node.name) (container.cpu.utilization{k8s.deployment.name="httpbin"})`,
+		},
+		"topk": {
+			in: `topk(5, sum without (k8s.pod.name) (rate(http.server.requests[5m])))`,
+		},
+		"arithmetic": {
+			in: `sum(rate(errors[5m])) / sum(rate(requests[5m])) * 100`,
+		},
+		"vector matching": {
+			in: `sum by (host) (rate(errors[5m])) / on (host) group_left (service) sum by (host, service) (rate(requests[5m]))`,
+		},
+		"subquery": {
+			in: `max_over_time(rate(http.server.requests[5m])[1h:1m])`,
+		},
+		"at modifier": {
+			in: `avg_over_time(a[5m] @ 1609746000 offset -5m)`,
+		},
+		"syntax error": {
+			in:      `sum(rate(errors[5m])`,
+			wantErr: "unclosed parenthesis of sum at column 1",
+		},
+		"unknown function": {
+			in:          `average(a)`,
+			wantWarning: "unknown function average at column 1",
+		},
+		"typo": {
+			in:          `sum(avg_over_tim(a[5m]))`,
+			wantWarning: "unknown function avg_over_tim, did you mean avg_over_time? at column 5",
+		},
+		"range vector of unknown function": {
+			in:          `mad_over_time(a[5m:1m])`,
+			wantWarning: "unknown function mad_over_time",
+		},
+		"invalid arguments of unknown function": {
+			in:      `new_function(abs(a, b))`,
+			wantErr: "abs takes 1 argument, but got 2",
+		},
+		"too many arguments": {
+			in:      `abs(a, b)`,
+			wantErr: "abs takes 1 argument, but got 2",
+		},
+		"too few arguments": {
+			in:      `topk(a)`,
+			wantErr: "topk takes 2 arguments, but got 1",
+		},
+		"grouping of a function": {
+			in:      `rate(a[5m]) by (b)`,
+			wantErr: "rate is not an aggregation and does not accept by",
+		},
+		"instant vector for range": {
+			in:      `rate(a)`,
+			wantErr: "argument 1 of rate must be a range vector such as metric[5m]",
+		},
+		"bare range vector": {
+			in:      `a[5m]`,
+			wantErr: "range vector a[5m] must be an argument of a function such as rate",
+		},
+		"bare subquery": {
+			in:      `rate(a[5m])[1h:]`,
+			wantErr: "subquery [1h:] must be an argument of a function such as max_over_time",
+		},
+		"range vector in subquery": {
+			in:      `max_over_time(a[5m][1h:1m])`,
+			wantErr: "range vector a[5m] must be an argument of a function such as rate",
+		},
+		"nested error": {
+			in:      `1 - sum(rate(a))`,
+			wantErr: "argument 1 of rate must be a range vector such as metric[5m] at column 14",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			warnings, err := query.Validate(tt.in)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, but got: %v", tt.wantErr, err)
+			}
+
+			if tt.wantWarning == "" {
+				if len(warnings) > 0 {
+					t.Errorf("unexpected warnings: %v", warnings)
+				}
+			} else if len(warnings) != 1 || !strings.Contains(warnings[0].Error(), tt.wantWarning) {
+				t.Errorf("expected a warning containing %q, but got: %v", tt.wantWarning, warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,807 @@
+// Package query parses and validates the PromQL-style queries of Mackerel,
+// which are used by query monitors and query graphs for labeled metrics.
+// e.g. sum by (k8s.node.name) (rate(container.cpu.time{k8s.deployment.name="httpbin"}[5m]))
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Node is a node of the query, which is one of *Selector, *Subquery, *Call, *Binary, *Unary, *Paren, *Number and *String.
+type Node interface {
+	// Returns the offset of the node in bytes.
+	Pos() int
+}
+
+// Selector selects metrics by the name and the labels such as metric{label="value"}[5m].
+type Selector struct {
+	Metric   string
+	Matchers []Matcher
+	// The duration of the range vector selector such as 5m, or empty for an instant vector selector.
+	Range string
+	// The duration of the offset modifier, or empty.
+	OffsetBy string
+	// The timestamp of the @ modifier such as 1609746000 and start(), or empty.
+	At     string
+	Offset int
+}
+
+// Subquery is a range vector of an instant vector query such as rate(metric[5m])[30m:1m].
+type Subquery struct {
+	Expr  Node
+	Range string
+	// The resolution, or empty for the default.
+	Step string
+	// The duration of the offset modifier, or empty.
+	OffsetBy string
+	// The timestamp of the @ modifier such as 1609746000 and start(), or empty.
+	At     string
+	Offset int
+}
+
+// Matcher is a label matcher such as label="value".
+type Matcher struct {
+	Label string
+	// One of =, !=, =~ and !~.
+	Op     string
+	Value  string
+	Offset int
+}
+
+// Call is a function call or an aggregation such as sum by (label) (...).
+type Call struct {
+	Name     string
+	Args     []Node
+	Grouping *Grouping
+	Offset   int
+}
+
+// Grouping is the by or without clause of an aggregation.
+type Grouping struct {
+	Without bool
+	Labels  []string
+}
+
+// Binary is a binary operation such as a / b.
+type Binary struct {
+	Op       string
+	Matching *VectorMatching
+	LHS      Node
+	RHS      Node
+	Offset   int
+}
+
+// VectorMatching is the on or ignoring clause of a binary operation such as a / on (label) group_left b.
+type VectorMatching struct {
+	Ignoring bool
+	Labels   []string
+	// One of group_left and group_right, or empty.
+	Group string
+	// The labels of the other side to be included by group_left or group_right.
+	Include []string
+}
+
+// Unary is a unary operation such as -a.
+type Unary struct {
+	Op     string
+	Expr   Node
+	Offset int
+}
+
+// Paren is a parenthesized expression.
+type Paren struct {
+	Expr   Node
+	Offset int
+}
+
+// Number is a number literal.
+type Number struct {
+	Value  float64
+	Offset int
+}
+
+// String is a string literal.
+type String struct {
+	Value  string
+	Offset int
+}
+
+func (s *Selector) Pos() int { return s.Offset }
+func (s *Subquery) Pos() int { return s.Offset }
+func (c *Call) Pos() int     { return c.Offset }
+func (b *Binary) Pos() int   { return b.Offset }
+func (u *Unary) Pos() int    { return u.Offset }
+func (p *Paren) Pos() int    { return p.Offset }
+func (n *Number) Pos() int   { return n.Offset }
+func (s *String) Pos() int   { return s.Offset }
+
+// Error is an error at a position of the query.
+type Error struct {
+	// The offset in bytes.
+	Offset int
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at column %d", e.Msg, e.Offset+1)
+}
+
+func errorf(offset int, format string, args ...any) *Error {
+	return &Error{Offset: offset, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Parses the query.
+// Parse checks only the syntax; use Validate to check functions and their arguments as well.
+func Parse(q string) (Node, error) {
+	p := &parser{lexer: lexer{src: q}}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokenEOF {
+		return nil, errorf(p.tok.offset, "empty query")
+	}
+	node, err := p.parseExpr(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokenEOF {
+		return nil, p.unexpected("after the query")
+	}
+	return node, nil
+}
+
+type parser struct {
+	lexer
+	tok token
+}
+
+func (p *parser) next() error {
+	tok, err := p.lex()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) is(kind tokenKind, text string) bool {
+	return p.tok.kind == kind && p.tok.text == text
+}
+
+func (p *parser) unexpected(context string) *Error {
+	if p.tok.kind == tokenEOF {
+		return errorf(p.tok.offset, "unexpected end of the query %s", context)
+	}
+	return errorf(p.tok.offset, "unexpected %s %s", p.tok.text, context)
+}
+
+// Consumes the punctuation text, or returns an error with context.
+func (p *parser) expect(text string, context string) error {
+	if !p.is(tokenPunct, text) {
+		return p.unexpected(context)
+	}
+	return p.next()
+}
+
+var binaryPrecedences = map[string]int{
+	"or":     1,
+	"and":    2,
+	"unless": 2,
+	"==":     3,
+	"!=":     3,
+	"<":      3,
+	"<=":     3,
+	">":      3,
+	">=":     3,
+	"+":      4,
+	"-":      4,
+	"*":      5,
+	"/":      5,
+	"%":      5,
+	"atan2":  5,
+	"^":      6,
+}
+
+func (p *parser) binaryOp() (string, int, bool) {
+	switch p.tok.kind {
+	case tokenOp, tokenIdent:
+		prec, ok := binaryPrecedences[p.tok.text]
+		return p.tok.text, prec, ok
+	}
+	return "", 0, false
+}
+
+// Parses binary operations whose precedences are higher than minPrec.
+func (p *parser) parseExpr(minPrec int) (Node, error) {
+	lhs, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, prec, ok := p.binaryOp()
+		if !ok || prec <= minPrec {
+			return lhs, nil
+		}
+		offset := p.tok.offset
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.is(tokenIdent, "bool") {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		}
+		var matching *VectorMatching
+		switch {
+		case p.is(tokenIdent, "on") || p.is(tokenIdent, "ignoring"):
+			if matching, err = p.parseVectorMatching(op); err != nil {
+				return nil, err
+			}
+		case p.is(tokenIdent, "group_left") || p.is(tokenIdent, "group_right"):
+			return nil, errorf(p.tok.offset, "%s must follow on or ignoring", p.tok.text)
+		}
+		// ^ is right associative.
+		nextPrec := prec
+		if op == "^" {
+			nextPrec--
+		}
+		rhs, err := p.parseExpr(nextPrec)
+		if err != nil {
+			return nil, err
+		}
+		lhs = &Binary{Op: op, Matching: matching, LHS: lhs, RHS: rhs, Offset: offset}
+	}
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if p.tok.kind == tokenOp && (p.tok.text == "-" || p.tok.text == "+") {
+		offset, op := p.tok.offset, p.tok.text
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{Op: op, Expr: expr, Offset: offset}, nil
+	}
+	return p.parsePostfix()
+}
+
+// Parses the vector matching clause, where the current token is on or ignoring.
+func (p *parser) parseVectorMatching(op string) (*VectorMatching, error) {
+	matching := &VectorMatching{Ignoring: p.tok.text == "ignoring"}
+	keyword := p.tok.text
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	labels, err := p.parseLabels(keyword)
+	if err != nil {
+		return nil, err
+	}
+	matching.Labels = labels
+
+	if !p.is(tokenIdent, "group_left") && !p.is(tokenIdent, "group_right") {
+		return matching, nil
+	}
+	if op == "and" || op == "or" || op == "unless" {
+		return nil, errorf(p.tok.offset, "%s is not allowed for the set operator %s", p.tok.text, op)
+	}
+	matching.Group = p.tok.text
+	matching.Include = []string{}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	// The labels are optional, and parentheses right after group_left or group_right are always the labels.
+	if p.is(tokenPunct, "(") {
+		if matching.Include, err = p.parseLabels(matching.Group); err != nil {
+			return nil, err
+		}
+	}
+	return matching, nil
+}
+
+// Parses the ranges, the subqueries and the modifiers following the primary expression.
+func (p *parser) parsePostfix() (Node, error) {
+	node, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch {
+		case p.is(tokenPunct, "["):
+			node, err = p.parseRange(node)
+		case p.is(tokenIdent, "offset"):
+			err = p.parseOffset(node)
+		case p.is(tokenPunct, "@"):
+			err = p.parseAt(node)
+		default:
+			return node, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Parses the range of the selector such as [5m], or the subquery such as [30m:1m].
+func (p *parser) parseRange(node Node) (Node, error) {
+	offset := p.tok.offset
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokenDuration {
+		return nil, p.unexpected("in the range, expected a duration such as 5m")
+	}
+	rng := p.tok.text
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	var result Node
+	if p.is(tokenPunct, ":") {
+		sub := &Subquery{Expr: node, Range: rng, Offset: node.Pos()}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokenDuration {
+			sub.Step = p.tok.text
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		}
+		result = sub
+	} else {
+		sel, ok := node.(*Selector)
+		if !ok || sel.Range != "" || sel.OffsetBy != "" || sel.At != "" {
+			return nil, errorf(offset, "range is only allowed after a metric selector")
+		}
+		sel.Range = rng
+		result = sel
+	}
+
+	if !p.is(tokenPunct, "]") {
+		if p.tok.kind == tokenEOF {
+			return nil, errorf(offset, "unclosed bracket of the range")
+		}
+		return nil, p.unexpected("in the range")
+	}
+	return result, p.next()
+}
+
+// Returns the fields of the offset and @ modifiers of the selector or the subquery.
+func modifiers(node Node) (offsetBy *string, at *string, ok bool) {
+	switch node := node.(type) {
+	case *Selector:
+		return &node.OffsetBy, &node.At, true
+	case *Subquery:
+		return &node.OffsetBy, &node.At, true
+	}
+	return nil, nil, false
+}
+
+func (p *parser) parseOffset(node Node) error {
+	offsetBy, _, ok := modifiers(node)
+	if !ok {
+		return errorf(p.tok.offset, "offset is only allowed after a metric selector or a subquery")
+	}
+	if *offsetBy != "" {
+		return errorf(p.tok.offset, "offset is specified more than once")
+	}
+	if err := p.next(); err != nil {
+		return err
+	}
+	sign := ""
+	if p.tok.kind == tokenOp && p.tok.text == "-" {
+		sign = "-"
+		if err := p.next(); err != nil {
+			return err
+		}
+	}
+	if p.tok.kind != tokenDuration {
+		return p.unexpected("after offset, expected a duration such as 5m")
+	}
+	*offsetBy = sign + p.tok.text
+	return p.next()
+}
+
+func (p *parser) parseAt(node Node) error {
+	_, at, ok := modifiers(node)
+	if !ok {
+		return errorf(p.tok.offset, "@ is only allowed after a metric selector or a subquery")
+	}
+	if *at != "" {
+		return errorf(p.tok.offset, "@ is specified more than once")
+	}
+	if err := p.next(); err != nil {
+		return err
+	}
+	switch {
+	case p.tok.kind == tokenNumber:
+		*at = p.tok.text
+		return p.next()
+	case p.is(tokenIdent, "start") || p.is(tokenIdent, "end"):
+		name := p.tok.text
+		if err := p.next(); err != nil {
+			return err
+		}
+		if err := p.expect("(", fmt.Sprintf("after %s of @", name)); err != nil {
+			return err
+		}
+		if err := p.expect(")", fmt.Sprintf("in %s() of @", name)); err != nil {
+			return err
+		}
+		*at = name + "()"
+		return nil
+	}
+	return p.unexpected("after @, expected a unix timestamp, start() or end()")
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokenNumber:
+		v, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, errorf(tok.offset, "invalid number %s", tok.text)
+		}
+		return &Number{Value: v, Offset: tok.offset}, p.next()
+	case tokenString:
+		return &String{Value: tok.text, Offset: tok.offset}, p.next()
+	case tokenPunct:
+		switch tok.text {
+		case "(":
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			expr, err := p.parseExpr(0)
+			if err != nil {
+				return nil, err
+			}
+			if !p.is(tokenPunct, ")") {
+				if p.tok.kind == tokenEOF {
+					return nil, errorf(tok.offset, "unclosed parenthesis")
+				}
+				return nil, p.unexpected("in the parentheses")
+			}
+			return &Paren{Expr: expr, Offset: tok.offset}, p.next()
+		case "{":
+			sel := &Selector{Offset: tok.offset}
+			if err := p.parseMatchers(sel); err != nil {
+				return nil, err
+			}
+			if sel.Metric == "" && len(sel.Matchers) == 0 {
+				return nil, errorf(tok.offset, "selector must have a metric name or a label matcher")
+			}
+			return sel, nil
+		}
+	case tokenIdent:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		switch {
+		case p.is(tokenPunct, "("):
+			return p.parseCall(tok, nil)
+		case p.is(tokenIdent, "by") || p.is(tokenIdent, "without"):
+			grouping, err := p.parseGrouping()
+			if err != nil {
+				return nil, err
+			}
+			if !p.is(tokenPunct, "(") {
+				return nil, p.unexpected(fmt.Sprintf("after the grouping of %s", tok.text))
+			}
+			return p.parseCall(tok, grouping)
+		}
+		sel := &Selector{Metric: tok.text, Offset: tok.offset}
+		if p.is(tokenPunct, "{") {
+			if err := p.parseMatchers(sel); err != nil {
+				return nil, err
+			}
+		}
+		return sel, nil
+	}
+	return nil, p.unexpected("")
+}
+
+func (p *parser) parseCall(name token, grouping *Grouping) (*Call, error) {
+	call := &Call{Name: name.text, Args: []Node{}, Grouping: grouping, Offset: name.offset}
+	if err := p.next(); err != nil { // (
+		return nil, err
+	}
+
+	if !p.is(tokenPunct, ")") {
+		for {
+			if p.tok.kind == tokenEOF {
+				return nil, errorf(name.offset, "unclosed parenthesis of %s", name.text)
+			}
+			arg, err := p.parseExpr(0)
+			if err != nil {
+				return nil, err
+			}
+			call.Args = append(call.Args, arg)
+
+			if p.is(tokenPunct, ",") {
+				if err := p.next(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if p.is(tokenPunct, ")") {
+				break
+			}
+			if p.tok.kind == tokenEOF {
+				return nil, errorf(name.offset, "unclosed parenthesis of %s", name.text)
+			}
+			return nil, p.unexpected(fmt.Sprintf("in the arguments of %s", name.text))
+		}
+	}
+	if err := p.next(); err != nil { // )
+		return nil, err
+	}
+
+	if p.is(tokenIdent, "by") || p.is(tokenIdent, "without") {
+		if call.Grouping != nil {
+			return nil, errorf(p.tok.offset, "%s has more than one grouping", name.text)
+		}
+		grouping, err := p.parseGrouping()
+		if err != nil {
+			return nil, err
+		}
+		call.Grouping = grouping
+	}
+	return call, nil
+}
+
+func (p *parser) parseGrouping() (*Grouping, error) {
+	grouping := &Grouping{Without: p.tok.text == "without"}
+	keyword := p.tok.text
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	labels, err := p.parseLabels(keyword)
+	if err != nil {
+		return nil, err
+	}
+	grouping.Labels = labels
+	return grouping, nil
+}
+
+// Parses the parenthesized list of labels following the keyword such as by and on.
+func (p *parser) parseLabels(keyword string) ([]string, error) {
+	labels := []string{}
+	offset := p.tok.offset
+	if err := p.expect("(", fmt.Sprintf("after %s, expected a list of labels", keyword)); err != nil {
+		return nil, err
+	}
+	for !p.is(tokenPunct, ")") {
+		if p.tok.kind == tokenEOF {
+			return nil, errorf(offset, "unclosed parenthesis of the labels")
+		}
+		if p.tok.kind != tokenIdent {
+			return nil, p.unexpected(fmt.Sprintf("in the labels of %s", keyword))
+		}
+		labels = append(labels, p.tok.text)
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.is(tokenPunct, ",") {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		} else if !p.is(tokenPunct, ")") && p.tok.kind != tokenEOF {
+			return nil, p.unexpected(fmt.Sprintf("in the labels of %s", keyword))
+		}
+	}
+	return labels, p.next()
+}
+
+func (p *parser) parseMatchers(sel *Selector) error {
+	brace := p.tok.offset
+	if err := p.next(); err != nil { // {
+		return err
+	}
+	for !p.is(tokenPunct, "}") {
+		if p.tok.kind == tokenEOF {
+			return errorf(brace, "unclosed brace of the selector")
+		}
+		if p.tok.kind != tokenIdent {
+			return p.unexpected("in the selector, expected a label name")
+		}
+		m := Matcher{Label: p.tok.text, Offset: p.tok.offset}
+		if err := p.next(); err != nil {
+			return err
+		}
+		switch {
+		case p.tok.kind == tokenOp && (p.tok.text == "=" || p.tok.text == "!=" || p.tok.text == "=~" || p.tok.text == "!~"):
+			m.Op = p.tok.text
+		case p.tok.kind == tokenEOF:
+			return errorf(brace, "unclosed brace of the selector")
+		default:
+			return p.unexpected(fmt.Sprintf("after the label %s, expected one of =, !=, =~ and !~", m.Label))
+		}
+		if err := p.next(); err != nil {
+			return err
+		}
+		if p.tok.kind != tokenString {
+			if p.tok.kind == tokenEOF {
+				return errorf(brace, "unclosed brace of the selector")
+			}
+			return p.unexpected(fmt.Sprintf("after the label %s%s, expected a quoted string", m.Label, m.Op))
+		}
+		m.Value = p.tok.text
+		sel.Matchers = append(sel.Matchers, m)
+		if err := p.next(); err != nil {
+			return err
+		}
+
+		if p.is(tokenPunct, ",") {
+			if err := p.next(); err != nil {
+				return err
+			}
+		} else if !p.is(tokenPunct, "}") {
+			if p.tok.kind == tokenEOF {
+				return errorf(brace, "unclosed brace of the selector")
+			}
+			return p.unexpected("in the selector")
+		}
+	}
+	return p.next()
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenDuration
+	tokenString
+	tokenPunct
+	tokenOp
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+}
+
+type lexer struct {
+	src string
+	pos int
+}
+
+func (l *lexer) lex() (token, error) {
+	for l.pos < len(l.src) && strings.IndexByte(" \t\r\n", l.src[l.pos]) >= 0 {
+		l.pos++
+	}
+	start := l.pos
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, offset: start}, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case isIdentStart(c):
+		for l.pos < len(l.src) && isIdentChar(l.src[l.pos]) {
+			l.pos++
+		}
+		return token{kind: tokenIdent, text: l.src[start:l.pos], offset: start}, nil
+	case isDigit(c) || c == '.' && l.pos+1 < len(l.src) && isDigit(l.src[l.pos+1]):
+		return l.lexNumber()
+	case c == '"' || c == '\'' || c == '`':
+		return l.lexString()
+	case strings.IndexByte("{}()[],:@", c) >= 0:
+		l.pos++
+		return token{kind: tokenPunct, text: string(c), offset: start}, nil
+	}
+
+	for _, op := range []string{"==", "!=", "=~", "!~", "<=", ">=", "=", "<", ">", "+", "-", "*", "/", "%", "^"} {
+		if strings.HasPrefix(l.src[l.pos:], op) {
+			l.pos += len(op)
+			return token{kind: tokenOp, text: op, offset: start}, nil
+		}
+	}
+	return token{}, errorf(start, "unexpected character %q", c)
+}
+
+// Lexes a number, or a duration such as 5m and 1h30m.
+func (l *lexer) lexNumber() (token, error) {
+	start := l.pos
+	for l.pos < len(l.src) && (isDigit(l.src[l.pos]) || l.src[l.pos] == '.') {
+		l.pos++
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') && l.pos+1 < len(l.src) &&
+		(isDigit(l.src[l.pos+1]) || strings.IndexByte("+-", l.src[l.pos+1]) >= 0) {
+		l.pos += 2
+		for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+			l.pos++
+		}
+		return token{kind: tokenNumber, text: l.src[start:l.pos], offset: start}, nil
+	}
+	if l.pos >= len(l.src) || !isDurationChar(l.src[l.pos]) {
+		return token{kind: tokenNumber, text: l.src[start:l.pos], offset: start}, nil
+	}
+
+	for l.pos < len(l.src) && isDurationChar(l.src[l.pos]) {
+		l.pos++
+	}
+	text := l.src[start:l.pos]
+	if !isDuration(text) {
+		return token{}, errorf(start, "invalid number or duration %s", text)
+	}
+	return token{kind: tokenDuration, text: text, offset: start}, nil
+}
+
+func (l *lexer) lexString() (token, error) {
+	start := l.pos
+	quote := l.src[l.pos]
+	l.pos++
+
+	var b strings.Builder
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == quote:
+			l.pos++
+			return token{kind: tokenString, text: b.String(), offset: start}, nil
+		case c == '\\' && quote != '`' && l.pos+1 < len(l.src):
+			b.WriteByte(l.src[l.pos+1])
+			l.pos += 2
+		default:
+			b.WriteByte(c)
+			l.pos++
+		}
+	}
+	return token{}, errorf(start, "unterminated string")
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_'
+}
+
+// Metric names and labels of Mackerel may contain dots, e.g. k8s.deployment.name.
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || c == '.' || c == ':'
+}
+
+// Durations end at colons, which separate the range and the resolution of subqueries such as [30m:1m].
+func isDurationChar(c byte) bool {
+	return isIdentChar(c) && c != ':'
+}
+
+// Reports whether s is a sequence of integers with units such as 1h30m.
+func isDuration(s string) bool {
+	if s == "" {
+		return false
+	}
+	for s != "" {
+		i := 0
+		for i < len(s) && isDigit(s[i]) {
+			i++
+		}
+		if i == 0 {
+			return false
+		}
+		s = s[i:]
+		unit := ""
+		for _, u := range []string{"ms", "s", "m", "h", "d", "w", "y"} {
+			if strings.HasPrefix(s, u) {
+				unit = u
+				break
+			}
+		}
+		if unit == "" {
+			return false
+		}
+		s = s[len(unit):]
+	}
+	return true
+}
@@ -0,0 +1,215 @@
+package query_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/query"
+)
+
+func Test_Parse(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		in        string
+		wants     query.Node
+		wantErrAt int
+	}{
+		"selector": {
+			in: `container.cpu.utilization{k8s.deployment.name="httpbin", k8s.namespace.name!~'kube-.*',}`,
+			wants: &query.Selector{
+				Metric: "container.cpu.utilization",
+				Matchers: []query.Matcher{
+					{Label: "k8s.deployment.name", Op: "=", Value: "httpbin", Offset: 26},
+					{Label: "k8s.namespace.name", Op: "!~", Value: "kube-.*", Offset: 57},
+				},
+			},
+		},
+		"aggregation": {
+			in: `sum by (k8s.node.name) (rate(http.server.requests[5m] offset 1h))`,
+			wants: &query.Call{
+				Name:     "sum",
+				Grouping: &query.Grouping{Labels: []string{"k8s.node.name"}},
+				Args: []query.Node{
+					&query.Call{Name: "rate", Offset: 24, Args: []query.Node{
+						&query.Selector{Metric: "http.server.requests", Range: "5m", OffsetBy: "1h", Offset: 29},
+					}},
+				},
+			},
+		},
+		"trailing grouping": {
+			in: `avg(a) without (b, c)`,
+			wants: &query.Call{
+				Name:     "avg",
+				Grouping: &query.Grouping{Without: true, Labels: []string{"b", "c"}},
+				Args:     []query.Node{&query.Selector{Metric: "a", Offset: 4}},
+			},
+		},
+		"binary": {
+			in: `-a / (b + 1) * 100 > bool 0.5`,
+			wants: &query.Binary{Op: ">", Offset: 19,
+				LHS: &query.Binary{Op: "*", Offset: 13,
+					LHS: &query.Binary{Op: "/", Offset: 3,
+						LHS: &query.Unary{Op: "-", Expr: &query.Selector{Metric: "a", Offset: 1}},
+						RHS: &query.Paren{Offset: 5, Expr: &query.Binary{Op: "+", Offset: 8,
+							LHS: &query.Selector{Metric: "b", Offset: 6},
+							RHS: &query.Number{Value: 1, Offset: 10},
+						}},
+					},
+					RHS: &query.Number{Value: 100, Offset: 15},
+				},
+				RHS: &query.Number{Value: 0.5, Offset: 26},
+			},
+		},
+		"on": {
+			in: `a / on (host) b`,
+			wants: &query.Binary{Op: "/", Offset: 2,
+				Matching: &query.VectorMatching{Labels: []string{"host"}},
+				LHS:      &query.Selector{Metric: "a"},
+				RHS:      &query.Selector{Metric: "b", Offset: 14},
+			},
+		},
+		"ignoring with group_left": {
+			in: `a / ignoring (x) group_left b`,
+			wants: &query.Binary{Op: "/", Offset: 2,
+				Matching: &query.VectorMatching{Ignoring: true, Labels: []string{"x"}, Group: "group_left", Include: []string{}},
+				LHS:      &query.Selector{Metric: "a"},
+				RHS:      &query.Selector{Metric: "b", Offset: 28},
+			},
+		},
+		"group_right with labels": {
+			in: `a * on (host) group_right (service, role) b`,
+			wants: &query.Binary{Op: "*", Offset: 2,
+				Matching: &query.VectorMatching{Labels: []string{"host"}, Group: "group_right", Include: []string{"service", "role"}},
+				LHS:      &query.Selector{Metric: "a"},
+				RHS:      &query.Selector{Metric: "b", Offset: 42},
+			},
+		},
+		"bool with on": {
+			in: `a > bool on () b`,
+			wants: &query.Binary{Op: ">", Offset: 2,
+				Matching: &query.VectorMatching{Labels: []string{}},
+				LHS:      &query.Selector{Metric: "a"},
+				RHS:      &query.Selector{Metric: "b", Offset: 15},
+			},
+		},
+		"subquery": {
+			in: `rate(x[5m:1m])`,
+			wants: &query.Call{Name: "rate", Args: []query.Node{
+				&query.Subquery{Expr: &query.Selector{Metric: "x", Offset: 5}, Range: "5m", Step: "1m", Offset: 5},
+			}},
+		},
+		"subquery of a call with default resolution": {
+			in: `max_over_time(rate(x[5m])[1h:] offset 1d)`,
+			wants: &query.Call{Name: "max_over_time", Args: []query.Node{
+				&query.Subquery{
+					Expr: &query.Call{Name: "rate", Offset: 14, Args: []query.Node{
+						&query.Selector{Metric: "x", Range: "5m", Offset: 19},
+					}},
+					Range:    "1h",
+					OffsetBy: "1d",
+					Offset:   14,
+				},
+			}},
+		},
+		"at": {
+			in:    `x @ 1609746000`,
+			wants: &query.Selector{Metric: "x", At: "1609746000"},
+		},
+		"at with range and offset": {
+			in: `rate(x[5m] offset -1h @ end())`,
+			wants: &query.Call{Name: "rate", Args: []query.Node{
+				&query.Selector{Metric: "x", Range: "5m", OffsetBy: "-1h", At: "end()", Offset: 5},
+			}},
+		},
+		"empty": {
+			in:        " ",
+			wantErrAt: 1,
+		},
+		"unclosed selector": {
+			in:        `avg(a{b="c")`,
+			wantErrAt: 11,
+		},
+		"unclosed selector at the end": {
+			in:        `a{b="c"`,
+			wantErrAt: 1,
+		},
+		"stray brace": {
+			in:        `a{b="c"}}`,
+			wantErrAt: 8,
+		},
+		"unquoted label value": {
+			in:        `a{b=c}`,
+			wantErrAt: 4,
+		},
+		"invalid matcher": {
+			in:        `a{b=="c"}`,
+			wantErrAt: 3,
+		},
+		"unclosed parenthesis": {
+			in:        `sum(rate(a[5m])`,
+			wantErrAt: 0,
+		},
+		"unclosed range": {
+			in:        `rate(a[5m)`,
+			wantErrAt: 9,
+		},
+		"invalid duration": {
+			in:        `rate(a[5x])`,
+			wantErrAt: 7,
+		},
+		"range after a call": {
+			in:        `rate(a)[5m]`,
+			wantErrAt: 7,
+		},
+		"group_left without on": {
+			in:        `a / group_left b`,
+			wantErrAt: 4,
+		},
+		"group_left of a set operator": {
+			in:        `a and on (x) group_left b`,
+			wantErrAt: 13,
+		},
+		"unclosed subquery": {
+			in:        `rate(x[5m:1m)`,
+			wantErrAt: 12,
+		},
+		"at after a call": {
+			in:        `rate(x[5m]) @ 100`,
+			wantErrAt: 12,
+		},
+		"invalid at": {
+			in:        `x @ now()`,
+			wantErrAt: 4,
+		},
+		"unterminated string": {
+			in:        `a{b="c}`,
+			wantErrAt: 4,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			node, err := query.Parse(tt.in)
+			if tt.wants == nil {
+				var queryErr *query.Error
+				if !errors.As(err, &queryErr) {
+					t.Fatalf("expected a query error, but got: %v", err)
+				}
+				if queryErr.Offset != tt.wantErrAt {
+					t.Errorf("expected the error at %d, but got: %v (%d)", tt.wantErrAt, err, queryErr.Offset)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.wants, node); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package textutil
+
+import "strings"
+
+// Returns the candidate which differs from name only in case, or otherwise the closest one within 2 edits.
+// An empty string is returned if no candidate is close enough.
+func Suggest(name string, candidates []string) string {
+	best, bestDistance := "", 3
+	for _, candidate := range candidates {
+		if strings.EqualFold(candidate, name) {
+			return candidate
+		}
+		if d := distance(strings.ToLower(candidate), strings.ToLower(name)); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// Returns the Levenshtein distance between a and b.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
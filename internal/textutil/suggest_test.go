@@ -0,0 +1,33 @@
+package textutil_test
+
+import (
+	"testing"
+
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/textutil"
+)
+
+func Test_Suggest(t *testing.T) {
+	t.Parallel()
+
+	candidates := []string{"avg", "max", "role", "roleSlots", "timeShift"}
+	cases := map[string]struct {
+		in    string
+		wants string
+	}{
+		"case":     {in: "timeshift", wants: "timeShift"},
+		"typo":     {in: "rol", wants: "role"},
+		"swapped":  {in: "mxa", wants: "max"},
+		"too far":  {in: "average", wants: ""},
+		"no match": {in: "foo", wants: ""},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := textutil.Suggest(tt.in, candidates); got != tt.wants {
+				t.Errorf("Suggest(%q) = %q, want %q", tt.in, got, tt.wants)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package typeutil
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -12,6 +13,17 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
+var floatStringRe = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?$`)
+
+// Parses a decimal float string such as "1", "-0.5", ".1" or "1e3".
+// Unlike strconv.ParseFloat, it rejects NaN, infinities, hexadecimal forms and surrounding spaces.
+func ParseFloatString(s string) (float64, error) {
+	if !floatStringRe.MatchString(s) {
+		return 0, fmt.Errorf("%q is not a decimal number", s)
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
 type (
 	FloatStringType struct {
 		basetypes.StringType
@@ -77,7 +89,7 @@ func (t FloatStringType) Validate(ctx context.Context, in tftypes.Value, path pa
 		return diags
 	}
 
-	if _, err := strconv.ParseFloat(valueString, 64); err != nil {
+	if _, err := ParseFloatString(valueString); err != nil {
 		diags.AddAttributeError(
 			path,
 			"Invalid Float String Value",
@@ -145,7 +157,7 @@ func (v FloatString) ToFloat64Value(_ context.Context) (basetypes.Float64Value,
 
 	var diags diag.Diagnostics
 	valueString := v.ValueString()
-	f, err := strconv.ParseFloat(valueString, 64)
+	f, err := ParseFloatString(valueString)
 	if err != nil {
 		diags.AddError(
 			"Conversion Error",
@@ -171,7 +183,7 @@ func (v FloatString) ValueFloat64Pointer() *float64 {
 	if v.StringValue.IsUnknown() || v.StringValue.IsNull() {
 		return nil
 	}
-	f, err := strconv.ParseFloat(v.ValueString(), 64)
+	f, err := ParseFloatString(v.ValueString())
 	if err != nil {
 		return nil
 	}
@@ -38,6 +38,10 @@ func Test_FloatStringType_Validate(t *testing.T) {
 			in:      tftypes.NewValue(tftypes.String, "xyz"),
 			wantErr: true,
 		},
+		"NaN": {
+			in:      tftypes.NewValue(tftypes.String, "NaN"),
+			wantErr: true,
+		},
 		"wrong type": {
 			in:      tftypes.NewValue(tftypes.Number, .1),
 			wantErr: true,
@@ -58,6 +62,49 @@ func Test_FloatStringType_Validate(t *testing.T) {
 	}
 }
 
+func Test_ParseFloatString(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		in      string
+		wants   float64
+		wantErr bool
+	}{
+		"integer":          {in: "80", wants: 80},
+		"decimal":          {in: "0.7", wants: 0.7},
+		"leading dot":      {in: ".1", wants: 0.1},
+		"trailing dot":     {in: "1.", wants: 1},
+		"negative":         {in: "-1.5", wants: -1.5},
+		"plus sign":        {in: "+2", wants: 2},
+		"exponent":         {in: "1e3", wants: 1000},
+		"empty":            {in: "", wantErr: true},
+		"word":             {in: "abc", wantErr: true},
+		"two dots":         {in: "1.5.2", wantErr: true},
+		"only dot":         {in: ".", wantErr: true},
+		"leading space":    {in: " 1", wantErr: true},
+		"trailing garbage": {in: "1x", wantErr: true},
+		"NaN":              {in: "NaN", wantErr: true},
+		"infinity":         {in: "Inf", wantErr: true},
+		"hexadecimal":      {in: "0x1p-2", wantErr: true},
+		"underscore":       {in: "1_000", wantErr: true},
+		"out of range":     {in: "1e400", wantErr: true},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := typeutil.ParseFloatString(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			if err == nil && f != tt.wants {
+				t.Errorf("expected %g, but got %g", tt.wants, f)
+			}
+		})
+	}
+}
+
 func Test_FloatStringType_FromTerraform(t *testing.T) {
 	t.Parallel()
 
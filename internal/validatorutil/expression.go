@@ -0,0 +1,47 @@
+package validatorutil
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/expression"
+)
+
+type metricExpressionValidator struct{}
+
+var _ validator.String = (*metricExpressionValidator)(nil)
+
+func IsMetricExpression() validator.String {
+	return &metricExpressionValidator{}
+}
+
+func (mv *metricExpressionValidator) Description(context.Context) string {
+	return "metric expression with valid arguments of known functions"
+}
+
+func (mv *metricExpressionValidator) MarkdownDescription(ctx context.Context) string {
+	return mv.Description(ctx)
+}
+
+func (mv *metricExpressionValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	warnings, err := expression.Validate(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Metric Expression",
+			err.Error(),
+		)
+		return
+	}
+	for _, w := range warnings {
+		resp.Diagnostics.AddAttributeWarning(
+			req.Path,
+			"Unknown Function in Metric Expression",
+			w.Error(),
+		)
+	}
+}
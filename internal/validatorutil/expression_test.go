@@ -0,0 +1,73 @@
+package validatorutil_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/validatorutil"
+)
+
+func Test_Validator_MetricExpression(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		val         types.String
+		wantError   bool
+		wantWarning bool
+	}{
+		"valid": {
+			val: types.StringValue("avg(role(foo:bar, loadavg5))"),
+		},
+		"unknown function": {
+			val:         types.StringValue("avrg(role(foo:bar, loadavg5))"),
+			wantWarning: true,
+		},
+		"too many arguments": {
+			val:         types.StringValue("avg(role(foo:bar, loadavg5), role(foo:baz, loadavg5))"),
+			wantWarning: true,
+		},
+		"value for metrics": {
+			val:       types.StringValue("avg(loadavg5)"),
+			wantError: true,
+		},
+		"unbalanced": {
+			val:       types.StringValue("avg(role(foo:bar, loadavg5)"),
+			wantError: true,
+		},
+		"null": {
+			val: types.StringNull(),
+		},
+		"unknown": {
+			val: types.StringUnknown(),
+		},
+	}
+
+	ctx := context.Background()
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:           path.Root("test"),
+				PathExpression: path.MatchRoot("test"),
+				ConfigValue:    tt.val,
+			}
+			resp := validator.StringResponse{}
+			validatorutil.IsMetricExpression().ValidateString(ctx, req, &resp)
+
+			for _, d := range resp.Diagnostics {
+				assertDiagMatchPathExpr(t, d, path.MatchRoot("test"))
+			}
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("unexpected error: %+v", resp.Diagnostics.Errors())
+			}
+			if (resp.Diagnostics.WarningsCount() > 0) != tt.wantWarning {
+				t.Errorf("unexpected warnings: %+v", resp.Diagnostics.Warnings())
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package validatorutil
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+type stringParameterValidator struct {
+	name      string
+	validator validator.String
+}
+
+var _ function.StringParameterValidator = (*stringParameterValidator)(nil)
+
+// Returns the function parameter validator which runs the attribute validator v,
+// so that function arguments are checked with the same rules as attributes.
+// name is the parameter name to refer to in the errors.
+func StringParameter(name string, v validator.String) function.StringParameterValidator {
+	return &stringParameterValidator{name: name, validator: v}
+}
+
+func (pv *stringParameterValidator) ValidateParameterString(ctx context.Context, req function.StringParameterValidatorRequest, resp *function.StringParameterValidatorResponse) {
+	vreq := validator.StringRequest{
+		Path:           path.Root(pv.name),
+		PathExpression: path.MatchRoot(pv.name),
+		ConfigValue:    req.Value,
+	}
+	vresp := &validator.StringResponse{}
+	pv.validator.ValidateString(ctx, vreq, vresp)
+
+	for _, d := range vresp.Diagnostics.Errors() {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(req.ArgumentPosition, d.Detail()))
+	}
+}
@@ -0,0 +1,52 @@
+package validatorutil_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/validatorutil"
+)
+
+func Test_Validator_StringParameter(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		val       types.String
+		wantError bool
+	}{
+		"valid": {
+			val: types.StringValue("foo"),
+		},
+		"invalid": {
+			val:       types.StringValue("f"),
+			wantError: true,
+		},
+		"unknown": {
+			val: types.StringUnknown(),
+		},
+	}
+
+	ctx := context.Background()
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := function.StringParameterValidatorRequest{
+				ArgumentPosition: 1,
+				Value:            tt.val,
+			}
+			resp := function.StringParameterValidatorResponse{}
+			validatorutil.StringParameter("test", stringvalidator.LengthAtLeast(2)).ValidateParameterString(ctx, req, &resp)
+
+			if (resp.Error != nil) != tt.wantError {
+				t.Fatalf("unexpected error: %+v", resp.Error)
+			}
+			if resp.Error != nil && (resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != 1) {
+				t.Errorf("expected the error on the argument 1, but got: %+v", resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package validatorutil
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/query"
+)
+
+type metricQueryValidator struct{}
+
+var _ validator.String = (*metricQueryValidator)(nil)
+
+func IsMetricQuery() validator.String {
+	return &metricQueryValidator{}
+}
+
+func (qv *metricQueryValidator) Description(context.Context) string {
+	return "PromQL-style query with balanced selectors and valid arguments of known functions"
+}
+
+func (qv *metricQueryValidator) MarkdownDescription(ctx context.Context) string {
+	return qv.Description(ctx)
+}
+
+func (qv *metricQueryValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	warnings, err := query.Validate(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Query",
+			err.Error(),
+		)
+		return
+	}
+	for _, w := range warnings {
+		resp.Diagnostics.AddAttributeWarning(
+			req.Path,
+			"Unknown Function in Query",
+			w.Error(),
+		)
+	}
+}
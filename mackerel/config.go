@@ -1,34 +1,66 @@
 package mackerel
 
 import (
-	"net/http"
+	"context"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/mackerelio/mackerel-client-go"
+
+	mackerelinternal "github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
 )
 
 type Config struct {
-	APIKey  string
-	APIBase string
+	APIKey       string
+	APIBase      string
+	MaxRetries   int
+	RetryMaxWait int
+
+	RequestsPerMinute int
+
+	// Used if APIKey is empty.
+	CredentialProcess string
+
+	CACertFile         string
+	ClientCertFile     string
+	ClientKeyFile      string
+	ProxyURL           string
+	InsecureSkipVerify bool
+
+	ReadOnly bool
 }
 
+// Client returns the client for the configuration as is, without reading the environment variables.
 func (c *Config) Client() (client *mackerel.Client, diags diag.Diagnostics) {
-	var err error
-
-	if c.APIKey == "" {
+	if c.APIKey == "" && c.CredentialProcess == "" {
 		return nil, diag.Errorf("no API Key for Mackerel")
 	}
 
-	if c.APIBase == "" {
-		client = mackerel.NewClient(c.APIKey)
-	} else {
-		client, err = mackerel.NewClientWithOptions(c.APIKey, c.APIBase, false)
-		if err != nil {
-			return nil, diag.Errorf("failed to create mackerel client: %s", err)
-		}
-	}
+	client, err := mackerelinternal.NewClient(mackerelinternal.ClientConfig{
+		APIKey:            c.APIKey,
+		APIBase:           c.APIBase,
+		CredentialProcess: c.CredentialProcess,
+		Transport: mackerelinternal.TransportOptions{
+			MaxRetries:        c.MaxRetries,
+			RetryMaxWait:      time.Duration(c.RetryMaxWait) * time.Second,
+			RequestsPerMinute: c.RequestsPerMinute,
+
+			CACertFile:         c.CACertFile,
+			ClientCertFile:     c.ClientCertFile,
+			ClientKeyFile:      c.ClientKeyFile,
+			ProxyURL:           c.ProxyURL,
+			InsecureSkipVerify: c.InsecureSkipVerify,
 
-	client.HTTPClient.Transport = logging.NewSubsystemLoggingHTTPTransport("Mackerel", http.DefaultTransport)
+			ReadOnly: c.ReadOnly,
+		},
+	})
+	if err != nil {
+		return nil, diag.Errorf("failed to create mackerel client: %s", err)
+	}
 	return client, diags
 }
+
+// Returns the client bound to ctx, so that its requests are canceled along with ctx.
+func clientWithContext(ctx context.Context, m interface{}) *mackerel.Client {
+	return mackerelinternal.WithContext(ctx, m.(*mackerel.Client))
+}
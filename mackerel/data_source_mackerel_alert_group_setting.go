@@ -5,7 +5,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/mackerelio/mackerel-client-go"
 )
 
 func dataSourceMackerelAlertGroupSetting() *schema.Resource {
@@ -48,14 +47,14 @@ func dataSourceMackerelAlertGroupSetting() *schema.Resource {
 	}
 }
 
-func dataSourceMackerelAlertGroupSettingRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func dataSourceMackerelAlertGroupSettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	id := d.Get("id").(string)
 
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 
 	group, err := client.GetAlertGroupSetting(id)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	d.SetId(group.ID)
 	return flattenAlertGroupSetting(group, d)
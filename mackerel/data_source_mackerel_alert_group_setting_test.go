@@ -15,7 +15,7 @@ func TestAccDataSourceMackerelAlertGroupSetting(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceMackerelAlertGroupSettingConfig(rand, name),
@@ -46,7 +46,7 @@ resource "mackerel_role" "foo" {
 
 resource "mackerel_monitor" "foo" {
   name = "tf-monitor-%s"
-  connectivity {}
+  %s {}
 }
 
 resource "mackerel_alert_group_setting" "foo" {
@@ -61,5 +61,5 @@ resource "mackerel_alert_group_setting" "foo" {
 data "mackerel_alert_group_setting" "foo" {
   id = mackerel_alert_group_setting.foo.id
 }
-`, rand, rand, rand, name)
+`, rand, rand, rand, testAccNestedObject("mackerel_monitor", "connectivity"), name)
 }
@@ -118,14 +118,14 @@ func dataSourceMackerelAWSIntegration() *schema.Resource {
 	return resource
 }
 
-func dataSourceMackerelAWSIntegrationRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func dataSourceMackerelAWSIntegrationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	id := d.Get("id").(string)
 
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 
 	awsIntegrations, err := client.FindAWSIntegrations()
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	var awsIntegration *mackerel.AWSIntegration
 	for _, a := range awsIntegrations {
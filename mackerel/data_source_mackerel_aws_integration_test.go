@@ -10,6 +10,8 @@ import (
 )
 
 func TestAccDataSourceMackerelAWSIntegrationIAMRole(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_aws_integration")
+
 	dsName := "data.mackerel_aws_integration.foo"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-aws-integration-%s", rand)
@@ -18,7 +20,7 @@ func TestAccDataSourceMackerelAWSIntegrationIAMRole(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceMackerelAWSIntegrationConfigIAMRole(rand, name, awsRoleArn, externalID),
@@ -42,6 +44,8 @@ func TestAccDataSourceMackerelAWSIntegrationIAMRole(t *testing.T) {
 }
 
 func TestAccDataSourceMackerelAWSIntegrationCredential(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_aws_integration")
+
 	dsName := "data.mackerel_aws_integration.foo"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-aws-integration-%s", rand)
@@ -50,7 +54,7 @@ func TestAccDataSourceMackerelAWSIntegrationCredential(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceMackerelAWSIntegrationConfigCredential(rand, name, awsAccessKeyID, awsSecretAccessKey),
@@ -90,14 +90,14 @@ func dataSourceMackerelChannel() *schema.Resource {
 	}
 }
 
-func dataSourceMackerelChannelRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func dataSourceMackerelChannelRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	id := d.Get("id").(string)
 
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 
 	channels, err := client.FindChannels()
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	var channel *mackerel.Channel
@@ -10,12 +10,14 @@ import (
 )
 
 func TestAccDataSourceMackerelChannelEmail(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_channel")
+
 	dsName := "data.mackerel_channel.foo"
 	name := fmt.Sprintf("tf-channel-%s", acctest.RandString(5))
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceMackerelChannelConfigEmail(name),
@@ -37,12 +39,14 @@ func TestAccDataSourceMackerelChannelEmail(t *testing.T) {
 }
 
 func TestAccDataSourceMackerelChannelSlack(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_channel")
+
 	dsName := "data.mackerel_channel.foo"
 	name := fmt.Sprintf("tf-channel-%s", acctest.RandString(5))
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceMackerelChannelConfigSlack(name),
@@ -67,12 +71,14 @@ func TestAccDataSourceMackerelChannelSlack(t *testing.T) {
 }
 
 func TestAccDataSourceMackerelChannelWebhook(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_channel")
+
 	dsName := "data.mackerel_channel.foo"
 	name := fmt.Sprintf("tf-channel-%s", acctest.RandString(5))
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceMackerelChannelConfigWebhook(name),
@@ -161,7 +167,7 @@ data "mackerel_channel" "foo" {
 func TestAccDataSourceMackerelChannelNotMatchAnyChannel(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config:      `data "mackerel_channel" "foo" { id = "not-found" }`,
@@ -5,7 +5,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/mackerelio/mackerel-client-go"
 )
 
 var dashboardRangeDataResource = &schema.Resource{
@@ -337,13 +336,13 @@ func dataSourceMackerelDashboard() *schema.Resource {
 	}
 }
 
-func dataSourceMackerelDashboardRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func dataSourceMackerelDashboardRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	id := d.Get("id").(string)
 
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	dashboard, err := client.FindDashboard(id)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(dashboard.ID)
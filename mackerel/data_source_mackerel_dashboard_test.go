@@ -9,13 +9,15 @@ import (
 )
 
 func TestAccDataSourceMackerelDashboardGraph(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_dashboard")
+
 	dsName := "data.mackerel_dashboard.foo"
 	rand := acctest.RandString(5)
 	title := fmt.Sprintf("tf-dashboard-%s", rand)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceMackerelDashboardConfigGraph(rand, title),
@@ -60,13 +62,15 @@ func TestAccDataSourceMackerelDashboardGraph(t *testing.T) {
 }
 
 func TestAccDataSourceMackerelDashboardValue(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_dashboard")
+
 	dsName := "data.mackerel_dashboard.foo"
 	rand := acctest.RandString(5)
 	title := fmt.Sprintf("tf-dashboard-%s", rand)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceMackerelDashboardConfigValue(rand, title),
@@ -114,13 +118,15 @@ func TestAccDataSourceMackerelDashboardValue(t *testing.T) {
 }
 
 func TestAccDataSourceMackerelDashboardMarkdown(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_dashboard")
+
 	dsName := "data.mackerel_dashboard.foo"
 	rand := acctest.RandString(5)
 	title := fmt.Sprintf("tf-dashboard-%s", rand)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceMackerelDashboardConfigMarkdown(rand, title),
@@ -151,13 +157,15 @@ func TestAccDataSourceMackerelDashboardMarkdown(t *testing.T) {
 }
 
 func TestAccDataSourceMackerelDashboardAlertStatus(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_dashboard")
+
 	dsName := "data.mackerel_dashboard.foo"
 	rand := acctest.RandString(5)
 	title := fmt.Sprintf("tf-dashboard-%s", rand)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceMackerelDashboardConfigAlertStatus(rand, title),
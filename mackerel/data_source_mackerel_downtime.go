@@ -92,14 +92,14 @@ func dataSourceMackerelDowntime() *schema.Resource {
 	}
 }
 
-func dataSourceMackerelDowntimeRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func dataSourceMackerelDowntimeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	id := d.Get("id").(string)
 
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 
 	downtimes, err := client.FindDowntimes()
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	var downtime *mackerel.Downtime
 	for _, dt := range downtimes {
@@ -10,13 +10,15 @@ import (
 )
 
 func TestAccDataSourceMackerelDowntime(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_downtime")
+
 	dsName := "data.mackerel_downtime.foo"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-downtime-%s", rand)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceMackerelDowntimeConfig(rand, name),
@@ -101,7 +103,7 @@ data "mackerel_downtime" "foo" {
 func TestAccDataSourceMackerelDowntimeNotMatchAnyDowntime(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config:      `data "mackerel_downtime" "foo" { id = "not-found" }`,
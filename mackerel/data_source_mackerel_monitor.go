@@ -5,7 +5,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/mackerelio/mackerel-client-go"
 )
 
 func dataSourceMackerelMonitor() *schema.Resource {
@@ -293,13 +292,13 @@ func dataSourceMackerelMonitor() *schema.Resource {
 	}
 }
 
-func dataSourceMackerelMonitorRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func dataSourceMackerelMonitorRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	id := d.Get("id").(string)
 
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	monitor, err := client.GetMonitor(id)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	d.SetId(monitor.MonitorID())
 	return flattenMonitor(monitor, d)
@@ -9,13 +9,15 @@ import (
 )
 
 func TestAccDataSourceMackerelMonitorHostMetric(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_monitor")
+
 	dsName := "data.mackerel_monitor.foo"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-monitor-%s", rand)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceMackerelMonitorConfigHostMetric(rand, name),
@@ -49,13 +51,15 @@ func TestAccDataSourceMackerelMonitorHostMetric(t *testing.T) {
 }
 
 func TestAccDataSourceMackerelMonitorConnectivity(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_monitor")
+
 	dsName := "data.mackerel_monitor.foo"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-monitor-%s", rand)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceMackerelMonitorConfigConnectivity(rand, name),
@@ -84,6 +88,8 @@ func TestAccDataSourceMackerelMonitorConnectivity(t *testing.T) {
 }
 
 func TestAccDataSourceMackerelMonitorServiceMetric(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_monitor")
+
 	dsName := "mackerel_monitor.foo"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-monitor service_metric %s", rand)
@@ -91,7 +97,7 @@ func TestAccDataSourceMackerelMonitorServiceMetric(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceMackerelMonitorConfigServiceMetric(serviceName, name),
@@ -108,8 +114,8 @@ func TestAccDataSourceMackerelMonitorServiceMetric(t *testing.T) {
 						resource.TestCheckResourceAttr(dsName, "service_metric.0.service", serviceName),
 						resource.TestCheckResourceAttr(dsName, "service_metric.0.metric", "custom.access.2xx_ratio"),
 						resource.TestCheckResourceAttr(dsName, "service_metric.0.operator", "<"),
-						resource.TestCheckResourceAttr(dsName, "service_metric.0.warning", "99.9"),
-						resource.TestCheckResourceAttr(dsName, "service_metric.0.critical", "99.99"),
+						resource.TestCheckResourceAttr(dsName, "service_metric.0.warning", "99.99"),
+						resource.TestCheckResourceAttr(dsName, "service_metric.0.critical", "99.9"),
 						resource.TestCheckResourceAttr(dsName, "service_metric.0.duration", "3"),
 						resource.TestCheckResourceAttr(dsName, "service_metric.0.max_check_attempts", "5"),
 						resource.TestCheckResourceAttr(dsName, "service_metric.0.missing_duration_warning", "10"),
@@ -126,6 +132,8 @@ func TestAccDataSourceMackerelMonitorServiceMetric(t *testing.T) {
 }
 
 func TestAccDataSourceMackerelMonitorExternal(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_monitor")
+
 	dsName := "data.mackerel_monitor.foo"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-monitor-%s", rand)
@@ -133,7 +141,7 @@ func TestAccDataSourceMackerelMonitorExternal(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceMackerelMonitorConfigExternal(serviceName, name),
@@ -174,13 +182,15 @@ func TestAccDataSourceMackerelMonitorExternal(t *testing.T) {
 }
 
 func TestAccDataSourceMackerelMonitorExpression(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_monitor")
+
 	dsName := "data.mackerel_monitor.foo"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-monitor-%s", rand)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceMackerelMonitorExpression(name),
@@ -210,13 +220,15 @@ func TestAccDataSourceMackerelMonitorExpression(t *testing.T) {
 }
 
 func TestAccDataSourceMackerelMonitorAnomalyDetection(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_monitor")
+
 	dsName := "data.mackerel_monitor.foo"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-monitor-%s", rand)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceMackerelMonitorConfigAnomalyDetection(rand, name),
@@ -247,13 +259,15 @@ func TestAccDataSourceMackerelMonitorAnomalyDetection(t *testing.T) {
 }
 
 func TestAccDataSourceMackerelMonitorQuery(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_monitor")
+
 	dsName := "data.mackerel_monitor.foo"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-monitor-%s", rand)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceMackerelMonitorConfigQuery(name),
@@ -283,6 +297,40 @@ func TestAccDataSourceMackerelMonitorQuery(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceMackerelMonitorFramework_HostMetric(t *testing.T) {
+	testAccSkipSDK(t, "mackerel_monitor")
+
+	dsName := "data.mackerel_monitor.foo"
+	rand := acctest.RandString(5)
+	name := fmt.Sprintf("tf-monitor-%s", rand)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceMackerelMonitorConfigHostMetric(rand, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dsName, "id"),
+					resource.TestCheckResourceAttr(dsName, "name", name),
+					resource.TestCheckResourceAttr(dsName, "memo", "This monitor is managed by Terraform."),
+					resource.TestCheckResourceAttr(dsName, "is_mute", "true"),
+					resource.TestCheckResourceAttr(dsName, "notification_interval", "30"),
+					resource.TestCheckResourceAttr(dsName, "host_metric.metric", "disk%"),
+					resource.TestCheckResourceAttr(dsName, "host_metric.operator", ">"),
+					resource.TestCheckResourceAttr(dsName, "host_metric.warning", "70"),
+					resource.TestCheckResourceAttr(dsName, "host_metric.critical", "90"),
+					resource.TestCheckResourceAttr(dsName, "host_metric.duration", "3"),
+					resource.TestCheckResourceAttr(dsName, "host_metric.max_check_attempts", "5"),
+					resource.TestCheckResourceAttr(dsName, "host_metric.scopes.#", "2"),
+					resource.TestCheckResourceAttr(dsName, "host_metric.exclude_scopes.#", "2"),
+					resource.TestCheckNoResourceAttr(dsName, "connectivity.alert_status_on_gone"),
+				),
+			},
+		},
+	})
+}
+
 func testAccDataSourceMackerelMonitorConfigHostMetric(rand, name string) string {
 	return fmt.Sprintf(`
 resource "mackerel_service" "scoped" {
@@ -308,7 +356,7 @@ resource "mackerel_monitor" "foo" {
   memo = "This monitor is managed by Terraform."
   is_mute = true
   notification_interval = 30
-  host_metric {
+  %s {
     metric = "disk%%"
     operator = ">"
     warning = "70"
@@ -327,7 +375,7 @@ resource "mackerel_monitor" "foo" {
 data "mackerel_monitor" "foo" {
   id = mackerel_monitor.foo.id
 }
-`, rand, rand, rand, rand, name)
+`, rand, rand, rand, rand, name, testAccNestedObject("mackerel_monitor", "host_metric"))
 }
 
 func testAccDataSourceMackerelMonitorConfigConnectivity(rand, name string) string {
@@ -388,8 +436,8 @@ resource "mackerel_monitor" "foo" {
     duration = 3
     metric = "custom.access.2xx_ratio"
     operator = "<"
-    warning = "99.9"
-    critical = "99.99"
+    warning = "99.99"
+    critical = "99.9"
     max_check_attempts = 5
     missing_duration_warning = 10
     missing_duration_critical = 10080
@@ -49,14 +49,14 @@ func dataSourceMackerelNotificationGroup() *schema.Resource {
 	}
 }
 
-func dataSourceMackerelNotificationGroupRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func dataSourceMackerelNotificationGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	id := d.Get("id").(string)
 
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 
 	groups, err := client.FindNotificationGroups()
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	var group *mackerel.NotificationGroup
 	for _, g := range groups {
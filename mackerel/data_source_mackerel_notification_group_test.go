@@ -15,7 +15,7 @@ func TestAccDataSourceMackerelNotificationGroup(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceMackerelNotificationGroupConfig(rand, name),
@@ -50,7 +50,7 @@ resource "mackerel_channel" "foo" {
 
 resource "mackerel_monitor" "foo" {
   name = "tf-monitor-%s"
-  connectivity {}
+  %s {}
 }
 
 resource "mackerel_notification_group" "child" {
@@ -64,29 +64,11 @@ resource "mackerel_notification_group" "foo" {
     mackerel_notification_group.child.id]
   child_channel_ids = [
     mackerel_channel.foo.id]
-  monitor {
-    id = mackerel_monitor.foo.id
-    skip_default = false
-  }
-  // ignore duplicates
-  monitor {
-    id = mackerel_monitor.foo.id
-    skip_default = false
-  }
-  service {
-    name = mackerel_service.foo.name
-  }
-  // ignore duplicates
-  service {
-    name = mackerel_service.foo.name
-  }
-  service {
-    name = mackerel_service.bar.name
-  }
+%s
 }
 
 data "mackerel_notification_group" "foo" {
   id = mackerel_notification_group.foo.id
 }
-`, rand, rand, rand, rand, rand, name)
+`, rand, rand, rand, rand, testAccNestedObject("mackerel_monitor", "connectivity"), rand, name, testAccMackerelNotificationGroupConfigTargets())
 }
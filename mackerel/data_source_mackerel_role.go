@@ -30,14 +30,14 @@ func dataSourceMackerelRole() *schema.Resource {
 	}
 }
 
-func dataSourceMackerelRoleRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func dataSourceMackerelRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	service := d.Get("service").(string)
 	name := d.Get("name").(string)
 
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	roles, err := client.FindRoles(service)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	var role *mackerel.Role
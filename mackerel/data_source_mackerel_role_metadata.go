@@ -6,7 +6,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/mackerelio/mackerel-client-go"
 )
 
 func dataSourceMackerelRoleMetadata() *schema.Resource {
@@ -34,15 +33,15 @@ func dataSourceMackerelRoleMetadata() *schema.Resource {
 	}
 }
 
-func dataSourceMackerelRoleMetadataRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func dataSourceMackerelRoleMetadataRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	service := d.Get("service").(string)
 	role := d.Get("role").(string)
 	namespace := d.Get("namespace").(string)
 
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	resp, err := client.GetRoleMetaData(service, role, namespace)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	d.SetId(fmt.Sprintf("%s:%s/%s", service, role, namespace))
 	return flattenRoleMetadata(resp.RoleMetaData, d)
@@ -25,13 +25,13 @@ func dataSourceMackerelService() *schema.Resource {
 	}
 }
 
-func dataSourceMackerelServiceRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func dataSourceMackerelServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	name := d.Get("name").(string)
 
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	services, err := client.FindServices()
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	var service *mackerel.Service
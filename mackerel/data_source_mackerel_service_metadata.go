@@ -6,7 +6,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/mackerelio/mackerel-client-go"
 )
 
 func dataSourceMackerelServiceMetadata() *schema.Resource {
@@ -29,14 +28,14 @@ func dataSourceMackerelServiceMetadata() *schema.Resource {
 	}
 }
 
-func dataSourceMackerelServiceMetadataRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func dataSourceMackerelServiceMetadataRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	service := d.Get("service").(string)
 	namespace := d.Get("namespace").(string)
 
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	resp, err := client.GetServiceMetaData(service, namespace)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	d.SetId(strings.Join([]string{service, namespace}, "/"))
 	return flattenServiceMetadata(resp.ServiceMetaData, d)
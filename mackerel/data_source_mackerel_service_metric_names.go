@@ -6,7 +6,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/mackerelio/mackerel-client-go"
 )
 
 func dataSourceMackerelServiceMetricNames() *schema.Resource {
@@ -31,14 +30,14 @@ func dataSourceMackerelServiceMetricNames() *schema.Resource {
 	}
 }
 
-func dataSourceMackerelServiceMetricNamesRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func dataSourceMackerelServiceMetricNamesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	name := d.Get("name").(string)
 	prefix := d.Get("prefix").(string)
 
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	names, err := client.ListServiceMetricNames(name)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	metricNames := make([]string, 0, len(names))
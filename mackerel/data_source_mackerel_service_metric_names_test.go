@@ -12,7 +12,7 @@ func TestAccDataSourceMackerelServiceMetricNames(t *testing.T) {
 	name := fmt.Sprintf("tf-service-%s", acctest.RandString(5))
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceMackerelServiceMetricNamesConfig(name),
@@ -0,0 +1,42 @@
+package mackerel
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	mackerelinternal "github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
+)
+
+// Same as diag.FromErr, but explains errors of the Mackerel API.
+func diagFromErr(err error) diag.Diagnostics {
+	return diagFromErrAt(err, func(string) bool { return false })
+}
+
+// Same as diagFromErr, but points at the attribute if the API says so
+// and the resource has the attribute at the top level.
+func diagFromResourceErr(err error, d *schema.ResourceData) diag.Diagnostics {
+	config := d.GetRawConfig()
+	return diagFromErrAt(err, func(name string) bool {
+		return config.Type().IsObjectType() && config.Type().HasAttribute(name)
+	})
+}
+
+func diagFromErrAt(err error, hasAttribute func(name string) bool) diag.Diagnostics {
+	if roErr, ok := mackerelinternal.AsReadOnlyError(err); ok {
+		return diag.FromErr(roErr)
+	}
+	apiErr, ok := mackerelinternal.AsAPIError(err)
+	if !ok {
+		return diag.FromErr(err)
+	}
+	d := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  apiErr.Error(),
+		Detail:   apiErr.Hint(),
+	}
+	if field := apiErr.Field(); field != "" && hasAttribute(field) {
+		d.AttributePath = cty.GetAttrPath(field)
+	}
+	return diag.Diagnostics{d}
+}
@@ -0,0 +1,67 @@
+package mackerel
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	mackerelinternal "github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
+)
+
+func TestDiagFromErr(t *testing.T) {
+	if diags := diagFromErr(nil); diags != nil {
+		t.Errorf("expected no diagnostics, but got: %+v", diags)
+	}
+
+	diags := diagFromErr(errors.New("connection refused"))
+	if len(diags) != 1 || diags[0].Summary != "connection refused" {
+		t.Errorf("unexpected diagnostics: %+v", diags)
+	}
+
+	apiErr := &mackerelinternal.APIError{
+		StatusCode: http.StatusBadRequest,
+		Message:    "'notificationInterval' must be positive",
+		RequestID:  "req-0",
+	}
+	diags = diagFromErr(apiErr)
+	if len(diags) != 1 || !diags[0].AttributePath.Equals(cty.Path{}) {
+		t.Errorf("expected not to point at any attribute, but got: %+v", diags)
+	}
+	if diags[0].Summary != "400 Bad Request: 'notificationInterval' must be positive (request ID: req-0)" {
+		t.Errorf("unexpected summary: %s", diags[0].Summary)
+	}
+}
+
+func TestDiagFromResourceErr(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMackerelMonitor().Schema, map[string]interface{}{})
+
+	cases := map[string]struct {
+		message string
+		want    cty.Path
+	}{
+		"top level attribute": {
+			message: "'notificationInterval' must be positive",
+			want:    cty.GetAttrPath("notification_interval"),
+		},
+		"nested attribute": {
+			message: "'warning' must be a number",
+			want:    cty.Path{},
+		},
+		"unknown attribute": {
+			message: "'monitorType' is invalid",
+			want:    cty.Path{},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			diags := diagFromResourceErr(&mackerelinternal.APIError{StatusCode: http.StatusBadRequest, Message: tt.message}, d)
+			if len(diags) != 1 || !diags[0].AttributePath.Equals(tt.want) {
+				t.Errorf("expected to point at %#v, but got: %+v", tt.want, diags)
+			}
+		})
+	}
+}
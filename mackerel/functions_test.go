@@ -0,0 +1,66 @@
+package mackerel
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFunctions(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "role_fullname" {
+  value = provider::mackerel::role_fullname("foo", "bar")
+}
+
+output "parse_role_fullname" {
+  value = provider::mackerel::parse_role_fullname("foo:bar")
+}
+
+output "role_metadata_id" {
+  value = provider::mackerel::role_metadata_id("foo", "bar", "ns")
+}
+
+output "service_metadata_id" {
+  value = provider::mackerel::service_metadata_id("foo", "ns")
+}
+
+output "metric_expression" {
+  value = provider::mackerel::metric_expression("avg(role(foo:bar, loadavg5))")
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("role_fullname", "foo:bar"),
+					resource.TestCheckOutput("role_metadata_id", "foo:bar/ns"),
+					resource.TestCheckOutput("service_metadata_id", "foo/ns"),
+					resource.TestCheckOutput("metric_expression", "avg(role(foo:bar, loadavg5))"),
+				),
+			},
+			{
+				Config: `
+output "role_fullname" {
+  value = provider::mackerel::role_fullname("foo", "-bar")
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid value for "role" parameter`),
+			},
+			{
+				Config: `
+output "metric_expression" {
+  value = provider::mackerel::metric_expression("avg(loadavg5)")
+}
+`,
+				ExpectError: regexp.MustCompile(`argument 1 of avg must be a function call, but got loadavg5`),
+			},
+		},
+	})
+}
@@ -4,39 +4,114 @@ import (
 	"context"
 	"log"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
-	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
-	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
+	mackerelinternal "github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
 	mackerelfwprovider "github.com/mackerelio-labs/terraform-provider-mackerel/internal/provider"
 )
 
+const defaultTimeout = mackerelinternal.DefaultTimeout
+
 // Provider returns a *schema.Provider
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
+			// The environment variables are read by mackerelinternal.ClientConfigModel.Resolve.
 			"api_key": {
-				Type:     schema.TypeString,
-				Required: true,
-				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
-					"MACKEREL_APIKEY",
-					"MACKEREL_API_KEY",
-				}, nil),
+				Type:        schema.TypeString,
+				Optional:    true,
 				Description: "Mackerel API Key",
 				Sensitive:   true,
 			},
 			"api_base": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				DefaultFunc:  schema.EnvDefaultFunc("API_BASE", nil),
 				Description:  "Mackerel API BASE URL",
 				Sensitive:    true,
 				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
 			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      mackerelinternal.DefaultMaxRetries,
+				Description:  "Maximum number of retries for rate-limited (429) or transient 5xx responses",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(mackerelinternal.DefaultRetryMaxWait / time.Second),
+				Description:  "Maximum seconds to wait between retries",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"requests_per_minute": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum number of API requests per minute, shared by all resources",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"api_key_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to a file containing only the Mackerel API Key",
+			},
+			"config_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to a mackerel-agent.conf formatted file to read apikey and apibase from",
+			},
+			"credential_process": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Command to obtain the Mackerel API Key, which prints JSON with api_key and optional expiration",
+			},
+			"ca_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to a PEM encoded CA certificate bundle to verify the API server, in addition to the system ones",
+			},
+			"client_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to a PEM encoded client certificate to present to the API server",
+			},
+			"client_key_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to the PEM encoded private key of client_cert_file",
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "URL of the proxy to access the API through. Defaults to the HTTPS_PROXY or HTTP_PROXY environment variables",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Skip verification of the API server certificate. Do not use in production",
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Reject API requests which modify objects, for plan-only use such as drift detection",
+			},
+			"skip_credentials_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Skip validating the API key with the API when configuring the provider",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -70,50 +145,166 @@ func Provider() *schema.Provider {
 
 		ConfigureContextFunc: providerConfigure,
 	}
+	traceResources(p.ResourcesMap)
+	traceResources(p.DataSourcesMap)
+	return p
+}
+
+// MACKEREL_EXPERIMENTAL_TFFRAMEWORK selects the resources and data sources served by
+// the terraform-plugin-framework based implementation: "1" or "true" selects all of them,
+// and a comma separated list of type names (e.g. "mackerel_service,mackerel_role") selects only those.
+// A provider attribute cannot select them since schemas are served before the provider is configured.
+func ProtoV6ProviderServer() tfprotov6.ProviderServer {
+	typeNames := frameworkTypeNames(context.Background(), os.Getenv("MACKEREL_EXPERIMENTAL_TFFRAMEWORK"))
+	return protoV6ProviderServer(Provider(), typeNames)
 }
 
-func ProtoV5ProviderServer() tfprotov5.ProviderServer {
-	return protoV5ProviderServer(Provider())
+// Serves the types in typeNames by the framework based implementation and the others by the SDK provider.
+func protoV6ProviderServer(provider *schema.Provider, typeNames []string) tfprotov6.ProviderServer {
+	ctx := context.Background()
+
+	// The SDK provider only speaks protocol v5.
+	servers := []func() tfprotov6.ProviderServer{
+		func() tfprotov6.ProviderServer {
+			server, err := tf5to6server.UpgradeServer(ctx, provider.GRPCProvider)
+			if err != nil {
+				panic(err)
+			}
+			return server
+		},
+	}
+
+	if len(typeNames) > 0 {
+		log.Printf("[INFO] mackerel: use terraform-plugin-framework based implementation for %s", strings.Join(typeNames, ", "))
+
+		// Only some types have both implementations, e.g. mackerel_dashboard is a framework resource and an SDK data source.
+		for _, name := range mackerelfwprovider.ResourceTypeNames(ctx) {
+			if slices.Contains(typeNames, name) {
+				delete(provider.ResourcesMap, name)
+			}
+		}
+		for _, name := range mackerelfwprovider.DataSourceTypeNames(ctx) {
+			if slices.Contains(typeNames, name) {
+				delete(provider.DataSourcesMap, name)
+			}
+		}
+	}
+
+	// The framework provider is always served for the provider functions, even if it serves no resources.
+	servers = append(servers, providerserver.NewProtocol6(mackerelfwprovider.NewWithTypeNames(typeNames)))
+
+	mux, err := tf6muxserver.NewMuxServer(ctx, servers...)
+	if err != nil {
+		panic(err)
+	}
+	return mux.ProviderServer()
 }
 
-func protoV5ProviderServer(provider *schema.Provider) tfprotov5.ProviderServer {
-	fwFlag := os.Getenv("MACKEREL_EXPERIMENTAL_TFFRAMEWORK")
-	if fwFlag == "1" || fwFlag == "true" {
-		log.Printf("[INFO] mackerel: use terraform-plugin-framework based implementation")
-
-		// Resources
-		delete(provider.ResourcesMap, "mackerel_notification_group")
-		delete(provider.ResourcesMap, "mackerel_role")
-		delete(provider.ResourcesMap, "mackerel_role_metadata")
-		delete(provider.ResourcesMap, "mackerel_service")
-		delete(provider.ResourcesMap, "mackerel_service_metadata")
-
-		// Data Sources
-		delete(provider.DataSourcesMap, "mackerel_notification_group")
-		delete(provider.DataSourcesMap, "mackerel_role")
-		delete(provider.DataSourcesMap, "mackerel_role_metadata")
-		delete(provider.DataSourcesMap, "mackerel_service")
-		delete(provider.DataSourcesMap, "mackerel_service_metadata")
-		delete(provider.DataSourcesMap, "mackerel_service_metric_names")
-
-		mux, err := tf5muxserver.NewMuxServer(
-			context.Background(),
-			providerserver.NewProtocol5(mackerelfwprovider.New()),
-			provider.GRPCProvider,
-		)
-		if err != nil {
-			panic(err)
+// Returns the type names implemented with the framework which the flag selects.
+func frameworkTypeNames(ctx context.Context, flag string) []string {
+	implemented := append(mackerelfwprovider.ResourceTypeNames(ctx), mackerelfwprovider.DataSourceTypeNames(ctx)...)
+	slices.Sort(implemented)
+	implemented = slices.Compact(implemented)
+
+	switch strings.TrimSpace(flag) {
+	case "", "0", "false":
+		return nil
+	case "1", "true":
+		return implemented
+	}
+
+	var typeNames []string
+	for _, name := range strings.Split(flag, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, found := slices.BinarySearch(implemented, name); !found {
+			log.Printf("[WARN] mackerel: %s is not implemented with terraform-plugin-framework, ignoring", name)
+			continue
+		}
+		if !slices.Contains(typeNames, name) {
+			typeNames = append(typeNames, name)
 		}
-		return mux.ProviderServer()
+	}
+	return typeNames
+}
+
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	config, err := clientConfigModel(d).Resolve()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	client, err := mackerelinternal.NewClient(config)
+	if err != nil {
+		return nil, diag.Errorf("failed to create mackerel client: %s", err)
 	}
 
-	return provider.GRPCProvider()
+	if !d.Get("skip_credentials_validation").(bool) {
+		if _, err := mackerelinternal.ValidateCredentials(ctx, client); err != nil {
+			summary, detail := mackerelinternal.DescribeCredentialsError(err)
+			return nil, diag.Diagnostics{{Severity: diag.Error, Summary: summary, Detail: detail}}
+		}
+	}
+	return client, nil
 }
 
-func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	config := Config{
-		APIKey:  d.Get("api_key").(string),
-		APIBase: d.Get("api_base").(string),
+// Converts the provider configuration to the model shared with the framework provider.
+func clientConfigModel(d *schema.ResourceData) mackerelinternal.ClientConfigModel {
+	m := mackerelinternal.ClientConfigModel{
+		APIKey:       types.StringNull(),
+		APIBase:      types.StringNull(),
+		MaxRetries:   types.Int64Value(int64(d.Get("max_retries").(int))),
+		RetryMaxWait: types.Int64Value(int64(d.Get("retry_max_wait").(int))),
+
+		RequestsPerMinute: types.Int64Null(),
+
+		APIKeyFile: types.StringNull(),
+		ConfigFile: types.StringNull(),
+
+		CredentialProcess: types.StringNull(),
+
+		CACertFile:         types.StringNull(),
+		ClientCertFile:     types.StringNull(),
+		ClientKeyFile:      types.StringNull(),
+		ProxyURL:           types.StringNull(),
+		InsecureSkipVerify: types.BoolValue(d.Get("insecure_skip_verify").(bool)),
+
+		ReadOnly: types.BoolNull(),
+	}
+	if v, ok := d.GetOk("api_key"); ok {
+		m.APIKey = types.StringValue(v.(string))
+	}
+	if v, ok := d.GetOk("api_base"); ok {
+		m.APIBase = types.StringValue(v.(string))
+	}
+	if v, ok := d.GetOk("requests_per_minute"); ok {
+		m.RequestsPerMinute = types.Int64Value(int64(v.(int)))
+	}
+	if v, ok := d.GetOk("api_key_file"); ok {
+		m.APIKeyFile = types.StringValue(v.(string))
+	}
+	if v, ok := d.GetOk("config_file"); ok {
+		m.ConfigFile = types.StringValue(v.(string))
+	}
+	if v, ok := d.GetOk("credential_process"); ok {
+		m.CredentialProcess = types.StringValue(v.(string))
+	}
+	if v, ok := d.GetOk("ca_cert_file"); ok {
+		m.CACertFile = types.StringValue(v.(string))
+	}
+	if v, ok := d.GetOk("client_cert_file"); ok {
+		m.ClientCertFile = types.StringValue(v.(string))
+	}
+	if v, ok := d.GetOk("client_key_file"); ok {
+		m.ClientKeyFile = types.StringValue(v.(string))
+	}
+	if v, ok := d.GetOk("proxy_url"); ok {
+		m.ProxyURL = types.StringValue(v.(string))
+	}
+	// An explicit false overrides the environment variable.
+	if v, ok := d.GetOkExists("read_only"); ok {
+		m.ReadOnly = types.BoolValue(v.(bool))
 	}
-	return config.Client()
+	return m
 }
@@ -3,22 +3,30 @@ package mackerel
 import (
 	"context"
 	"os"
+	"regexp"
+	"slices"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 var testAccProvider *schema.Provider
-var testAccProtoV5ProviderFactories map[string]func() (tfprotov5.ProviderServer, error)
+var testAccProtoV6ProviderFactories map[string]func() (tfprotov6.ProviderServer, error)
 
 func init() {
 	testAccProvider = Provider()
 
-	providerServer := protoV5ProviderServer(testAccProvider)
-	testAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
-		"mackerel": func() (tfprotov5.ProviderServer, error) {
+	providerServer := protoV6ProviderServer(testAccProvider, frameworkTypeNames(context.Background(), os.Getenv("MACKEREL_EXPERIMENTAL_TFFRAMEWORK")))
+	testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+		"mackerel": func() (tfprotov6.ProviderServer, error) {
 			return providerServer, nil
 		},
 	}
@@ -31,7 +39,9 @@ func TestProvider(t *testing.T) {
 }
 
 func TestProvider_apiKeyCompat(t *testing.T) {
-	config := make(map[string]interface{})
+	config := map[string]interface{}{
+		"skip_credentials_validation": true,
+	}
 	c := terraform.NewResourceConfigRaw(config)
 	ctx := context.Background()
 	t.Run("MACKEREL_API_KEY", func(t *testing.T) {
@@ -50,6 +60,113 @@ func TestProvider_apiKeyCompat(t *testing.T) {
 	})
 }
 
+func TestProvider_muxSchema(t *testing.T) {
+	server := protoV6ProviderServer(Provider(), frameworkTypeNames(context.Background(), "1"))
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema: %v", err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Errorf("GetProviderSchema: %s: %s", d.Summary, d.Detail)
+		}
+	}
+}
+
+func TestProvider_muxSchemaSelected(t *testing.T) {
+	p := Provider()
+	server := protoV6ProviderServer(p, frameworkTypeNames(context.Background(), "mackerel_service,mackerel_dashboard"))
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema: %v", err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Errorf("GetProviderSchema: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	for _, name := range []string{"mackerel_service", "mackerel_dashboard"} {
+		if _, ok := p.ResourcesMap[name]; ok {
+			t.Errorf("resource %s should be removed from the SDK provider", name)
+		}
+	}
+	if _, ok := p.ResourcesMap["mackerel_monitor"]; !ok {
+		t.Errorf("resource mackerel_monitor should be served by the SDK provider")
+	}
+	if _, ok := p.DataSourcesMap["mackerel_service"]; ok {
+		t.Errorf("data source mackerel_service should be removed from the SDK provider")
+	}
+	// mackerel_dashboard data source is not implemented with the framework
+	if _, ok := p.DataSourcesMap["mackerel_dashboard"]; !ok {
+		t.Errorf("data source mackerel_dashboard should be served by the SDK provider")
+	}
+}
+
+func TestProvider_muxFunctions(t *testing.T) {
+	p := Provider()
+	server := protoV6ProviderServer(p, nil)
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema: %v", err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Errorf("GetProviderSchema: %s: %s", d.Summary, d.Detail)
+		}
+	}
+	for _, name := range []string{"role_fullname", "parse_role_fullname", "role_metadata_id", "service_metadata_id", "metric_expression"} {
+		if _, ok := resp.Functions[name]; !ok {
+			t.Errorf("function %s should be served", name)
+		}
+	}
+	if _, ok := p.ResourcesMap["mackerel_service"]; !ok {
+		t.Errorf("resource mackerel_service should be served by the SDK provider")
+	}
+}
+
+func Test_frameworkTypeNames(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		in    string
+		wants []string
+	}{
+		"unset": {
+			in: "",
+		},
+		"false": {
+			in: "false",
+		},
+		"list": {
+			in:    "mackerel_service, mackerel_role,,mackerel_service",
+			wants: []string{"mackerel_service", "mackerel_role"},
+		},
+		"not implemented": {
+			in:    "mackerel_host,mackerel_monitor",
+			wants: []string{"mackerel_monitor"},
+		},
+	}
+
+	ctx := context.Background()
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := frameworkTypeNames(ctx, tt.in); !slices.Equal(got, tt.wants) {
+				t.Errorf("frameworkTypeNames(%q) = %v, want %v", tt.in, got, tt.wants)
+			}
+		})
+	}
+
+	all := frameworkTypeNames(ctx, "1")
+	for _, name := range []string{"mackerel_service", "mackerel_monitor", "mackerel_service_metric_names"} {
+		if !slices.Contains(all, name) {
+			t.Errorf("%s should be selected by \"1\"", name)
+		}
+	}
+}
+
 func testSetenv(t testing.TB, name, val string) {
 	t.Helper()
 
@@ -76,6 +193,173 @@ func TestProviderImpl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
 
+// Reports whether the type is served by the framework based implementation in the acceptance tests.
+func testAccFramework(typeName string) bool {
+	return slices.Contains(frameworkTypeNames(context.Background(), os.Getenv("MACKEREL_EXPERIMENTAL_TFFRAMEWORK")), typeName)
+}
+
+// Skips the test written for the SDK based implementation of the type, which configures nested objects with blocks.
+func testAccSkipFramework(t *testing.T, typeName string) {
+	t.Helper()
+	if testAccFramework(typeName) {
+		t.Skipf("%s is served by the framework based implementation", typeName)
+	}
+}
+
+// Skips the test written for the framework based implementation of the type, which configures nested objects with attributes.
+func testAccSkipSDK(t *testing.T, typeName string) {
+	t.Helper()
+	if !testAccFramework(typeName) {
+		t.Skipf("%s is served by the SDK based implementation", typeName)
+	}
+}
+
+// Returns the beginning of a nested object, which is a block (`name {}`) in the SDK based implementation
+// and an attribute (`name = {}`) in the framework based one.
+func testAccNestedObject(typeName, name string) string {
+	if testAccFramework(typeName) {
+		return name + " ="
+	}
+	return name
+}
+
+// Returns the provider factories serving the types by the framework based implementation
+// regardless of MACKEREL_EXPERIMENTAL_TFFRAMEWORK.
+func testAccProtoV6ProviderFactoriesFramework(typeNames ...string) map[string]func() (tfprotov6.ProviderServer, error) {
+	providerServer := protoV6ProviderServer(Provider(), typeNames)
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"mackerel": func() (tfprotov6.ProviderServer, error) {
+			return providerServer, nil
+		},
+	}
+}
+
+// Returns the steps which turn MACKEREL_EXPERIMENTAL_TFFRAMEWORK on for the type and then off.
+// The framework based implementation upgrades the state to a schema version which the SDK based one cannot read,
+// so turning it off fails until the resource is removed from the state and imported again.
+// The test must be skipped when the type is served by the framework based implementation.
+func testAccSwitchImplementationSteps(typeName, sdkConfig, frameworkConfig string, frameworkCheck resource.TestCheckFunc) []resource.TestStep {
+	framework := testAccProtoV6ProviderFactoriesFramework(typeName)
+	return []resource.TestStep{
+		// Test: Create with the SDK based implementation
+		{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Config:                   sdkConfig,
+		},
+		// Test: Turn the flag on, which upgrades the state without any changes
+		{
+			ProtoV6ProviderFactories: framework,
+			Config:                   frameworkConfig,
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectEmptyPlan(),
+				},
+			},
+			Check: frameworkCheck,
+		},
+		// Test: Turn the flag off
+		{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Config:                   sdkConfig,
+			ExpectError:              regexp.MustCompile(`newer provider version`),
+		},
+		// Test: Turn the flag on again to destroy the resource
+		{
+			ProtoV6ProviderFactories: framework,
+			Config:                   frameworkConfig,
+			PlanOnly:                 true,
+		},
+	}
+}
+
+type upgradedStateCheck struct {
+	path *tftypes.AttributePath
+	// The zero value expects the value to be null.
+	want tftypes.Value
+}
+
+// Writes the state with the flattener of the SDK based implementation,
+// and checks the state which the framework based implementation upgrades it to.
+func testUpgradeSDKState(t *testing.T, typeName string, flatten func(*schema.ResourceData) diag.Diagnostics, checks []upgradedStateCheck) {
+	t.Helper()
+	ctx := context.Background()
+
+	r := Provider().ResourcesMap[typeName]
+	d := r.TestResourceData()
+	d.SetId("test")
+	if diags := flatten(d); diags.HasError() {
+		t.Fatalf("unexpected error: %+v", diags)
+	}
+	ty := r.CoreConfigSchema().ImpliedType()
+	val, err := d.State().AttrsAsObjectValue(ty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Terraform stores absent blocks as empty lists and sets, and the timeouts which are not configured as null.
+	val, err = cty.Transform(val, func(_ cty.Path, v cty.Value) (cty.Value, error) {
+		ty := v.Type()
+		switch {
+		case !v.IsNull() || !ty.IsCollectionType() || !ty.ElementType().IsObjectType():
+			return v, nil
+		case ty.IsListType():
+			return cty.ListValEmpty(ty.ElementType()), nil
+		case ty.IsSetType():
+			return cty.SetValEmpty(ty.ElementType()), nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	attrs := val.AsValueMap()
+	attrs["timeouts"] = cty.NullVal(ty.AttributeType("timeouts"))
+	val = cty.ObjectVal(attrs)
+	raw, err := ctyjson.Marshal(val, ty)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := protoV6ProviderServer(Provider(), []string{typeName})
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: typeName,
+		Version:  0,
+		RawState: &tfprotov6.RawState{JSON: raw},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected error: %s: %s\nstate: %s", d.Summary, d.Detail, raw)
+		}
+	}
+	upgraded, err := resp.UpgradedState.Unmarshal(schemaResp.ResourceSchemas[typeName].ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range checks {
+		got, _, err := tftypes.WalkAttributePath(upgraded, c.path)
+		if err != nil {
+			t.Errorf("%s: %+v", c.path, err)
+			continue
+		}
+		if c.want.Type() == nil {
+			if !got.(tftypes.Value).IsNull() {
+				t.Errorf("expected %s to be null, but got %s", c.path, got)
+			}
+			continue
+		}
+		if !c.want.Equal(got.(tftypes.Value)) {
+			t.Errorf("expected %s to be %s, but got %s", c.path, c.want, got)
+		}
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if os.Getenv("MACKEREL_API_KEY") == "" {
 		t.Fatal("MACKEREL_API_KEY must be set for acceptance tests")
@@ -2,10 +2,13 @@ package mackerel
 
 import (
 	"context"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/mackerelio/mackerel-client-go"
+
+	mackerelinternal "github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
 )
 
 func resourceMackerelAlertGroupSetting() *schema.Resource {
@@ -17,6 +20,12 @@ func resourceMackerelAlertGroupSetting() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -50,40 +59,45 @@ func resourceMackerelAlertGroupSetting() *schema.Resource {
 }
 
 func resourceMackerelAlertGroupSettingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	setting, err := client.CreateAlertGroupSetting(expandAlertGroupSetting(d))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromResourceErr(err, d)
 	}
 	d.SetId(setting.ID)
 	return resourceMackerelAlertGroupSettingRead(ctx, d, m)
 }
 
-func resourceMackerelAlertGroupSettingRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+func resourceMackerelAlertGroupSettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := clientWithContext(ctx, m)
 	setting, err := client.GetAlertGroupSetting(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		if mackerelinternal.IsNotFound(err) {
+			log.Printf("[WARN] alert group setting (%s) is not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diagFromErr(err)
 	}
 	return flattenAlertGroupSetting(setting, d)
 }
 
 func resourceMackerelAlertGroupSettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	setting, err := client.UpdateAlertGroupSetting(d.Id(), expandAlertGroupSetting(d))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromResourceErr(err, d)
 	}
 	d.SetId(setting.ID)
 	return resourceMackerelAlertGroupSettingRead(ctx, d, m)
 }
 
-func resourceMackerelAlertGroupSettingDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceMackerelAlertGroupSettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	_, err := client.DeleteAlertGroupSetting(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	return diags
 }
@@ -18,7 +18,7 @@ func TestAccMackerelAlertGroupSetting(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelAlertGroupSettingDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -57,6 +57,29 @@ func TestAccMackerelAlertGroupSetting(t *testing.T) {
 	})
 }
 
+func TestAccMackerelAlertGroupSetting_disappears(t *testing.T) {
+	resourceName := "mackerel_alert_group_setting.foo"
+	rand := acctest.RandString(5)
+	name := fmt.Sprintf("tf-alert-group-setting-%s", rand)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMackerelAlertGroupSettingDestroy,
+		Steps: []resource.TestStep{
+			// Test: Deleted outside of Terraform
+			{
+				Config: testAccMackerelAlertGroupSettingConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMackerelAlertGroupSettingExists(resourceName),
+					testAccCheckMackerelAlertGroupSettingDisappears(resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func testAccCheckMackerelAlertGroupSettingDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*mackerel.Client)
 	for _, r := range s.RootModule().Resources {
@@ -95,6 +118,19 @@ func testAccCheckMackerelAlertGroupSettingExists(n string) resource.TestCheckFun
 	}
 }
 
+func testAccCheckMackerelAlertGroupSettingDisappears(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("alert group setting not found from resources: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*mackerel.Client)
+		_, err := client.DeleteAlertGroupSetting(rs.Primary.ID)
+		return err
+	}
+}
+
 func testAccMackerelAlertGroupSettingConfig(name string) string {
 	return fmt.Sprintf(`
 resource "mackerel_alert_group_setting" "foo" {
@@ -116,7 +152,7 @@ resource "mackerel_role" "foo" {
 
 resource "mackerel_monitor" "foo" {
   name = "tf-monitor-%s"
-  connectivity {}
+  %s {}
 }
 
 resource "mackerel_alert_group_setting" "foo" {
@@ -127,5 +163,5 @@ resource "mackerel_alert_group_setting" "foo" {
   monitor_scopes = [mackerel_monitor.foo.id]
   notification_interval = 60
 }
-`, rand, rand, rand, name)
+`, rand, rand, rand, testAccNestedObject("mackerel_monitor", "connectivity"), name)
 }
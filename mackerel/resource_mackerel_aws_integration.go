@@ -2,10 +2,13 @@ package mackerel
 
 import (
 	"context"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/mackerelio/mackerel-client-go"
+
+	mackerelinternal "github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
 )
 
 var awsIntegrationServiceResourceWithRetireAutomatically = &schema.Resource{
@@ -107,6 +110,12 @@ func resourceMackerelAWSIntegration() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -161,39 +170,44 @@ func resourceMackerelAWSIntegration() *schema.Resource {
 }
 
 func resourceMackerelAWSIntegrationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	awsIntegration, err := client.CreateAWSIntegration(expandCrateAWSIntegrationParam(d))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromResourceErr(err, d)
 	}
 	d.SetId(awsIntegration.ID)
 	return resourceMackerelAWSIntegrationRead(ctx, d, m)
 }
 
 func resourceMackerelAWSIntegrationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	awsIntegration, err := client.FindAWSIntegration(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		if mackerelinternal.IsNotFound(err) {
+			log.Printf("[WARN] AWS integration (%s) is not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diagFromErr(err)
 	}
 	return flattenAWSIntegration(awsIntegration, d)
 }
 
 func resourceMackerelAWSIntegrationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	_, err := client.UpdateAWSIntegration(d.Id(), expandUpdateAWSIntegrationParam(d))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromResourceErr(err, d)
 	}
 	return resourceMackerelAWSIntegrationRead(ctx, d, m)
 }
 
 func resourceMackerelAWSIntegrationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	_, err := client.DeleteAWSIntegration(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	return diags
 }
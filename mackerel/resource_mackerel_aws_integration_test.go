@@ -5,6 +5,9 @@ import (
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -12,6 +15,8 @@ import (
 )
 
 func TestAccMackerelAWSIntegrationIAMRole(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_aws_integration")
+
 	resourceName := "mackerel_aws_integration.foo"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-aws-integration-%s", rand)
@@ -21,7 +26,7 @@ func TestAccMackerelAWSIntegrationIAMRole(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelAWSIntegrationDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -74,6 +79,8 @@ func TestAccMackerelAWSIntegrationIAMRole(t *testing.T) {
 }
 
 func TestAccMackerelAWSIntegrationCredentials(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_aws_integration")
+
 	resourceName := "mackerel_aws_integration.foo"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-aws-integration-%s", rand)
@@ -83,7 +90,7 @@ func TestAccMackerelAWSIntegrationCredentials(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelAWSIntegrationDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -135,6 +142,97 @@ func TestAccMackerelAWSIntegrationCredentials(t *testing.T) {
 	})
 }
 
+func TestAccMackerelAWSIntegration_disappears(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_aws_integration")
+
+	resourceName := "mackerel_aws_integration.foo"
+	rand := acctest.RandString(5)
+	name := fmt.Sprintf("tf-aws-integration-%s", rand)
+	externalID := os.Getenv("EXTERNAL_ID")
+	awsRoleArn := os.Getenv("AWS_ROLE_ARN")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMackerelAWSIntegrationDestroy,
+		Steps: []resource.TestStep{
+			// Test: Deleted outside of Terraform
+			{
+				Config: testAccSourceMackerelAWSIntegrationConfigIAMRole(rand, name, awsRoleArn, externalID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMackerelAWSIntegrationExists(resourceName),
+					testAccCheckMackerelAWSIntegrationDisappears(resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccMackerelAWSIntegrationFramework(t *testing.T) {
+	testAccSkipSDK(t, "mackerel_aws_integration")
+
+	resourceName := "mackerel_aws_integration.foo"
+	dsName := "data.mackerel_aws_integration.foo"
+	rand := acctest.RandString(5)
+	name := fmt.Sprintf("tf-aws-integration-%s", rand)
+	nameUpdated := fmt.Sprintf("tf-aws-integration-%s-updated", rand)
+	externalID := os.Getenv("EXTERNAL_ID")
+	awsRoleArn := os.Getenv("AWS_ROLE_ARN")
+	role := fmt.Sprintf("tf-service-%s-include: tf-role-%s-include", rand, rand)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMackerelAWSIntegrationDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccMackerelAWSIntegrationConfigFramework(rand, name, awsRoleArn, externalID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMackerelAWSIntegrationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "role_arn", awsRoleArn),
+					resource.TestCheckResourceAttr(resourceName, "external_id", externalID),
+					resource.TestCheckResourceAttr(resourceName, "services.%", "3"),
+					resource.TestCheckResourceAttr(resourceName, "services.alb.enable", "true"),
+					resource.TestCheckResourceAttr(resourceName, "services.alb.role", role),
+					resource.TestCheckResourceAttr(resourceName, "services.alb.excluded_metrics.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "services.alb.excluded_metrics.0", "alb.request.count"),
+					resource.TestCheckResourceAttr(resourceName, "services.alb.retire_automatically", "false"),
+					resource.TestCheckResourceAttr(resourceName, "services.rds.enable", "true"),
+					resource.TestCheckResourceAttr(resourceName, "services.rds.retire_automatically", "true"),
+					resource.TestCheckResourceAttr(resourceName, "services.nlb.enable", "false"),
+					resource.TestCheckResourceAttr(dsName, "alb.#", "1"),
+					resource.TestCheckResourceAttr(dsName, "rds.#", "1"),
+					resource.TestCheckResourceAttr(dsName, "nlb.#", "0"),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccMackerelAWSIntegrationConfigFrameworkUpdated(rand, nameUpdated, awsRoleArn, externalID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMackerelAWSIntegrationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", nameUpdated),
+					resource.TestCheckResourceAttr(resourceName, "services.%", "3"),
+					resource.TestCheckResourceAttr(resourceName, "services.alb.excluded_metrics.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "services.ec2.enable", "true"),
+					resource.TestCheckResourceAttr(resourceName, "services.ec2.retire_automatically", "true"),
+					resource.TestCheckResourceAttr(resourceName, "services.lambda.enable", "true"),
+					resource.TestCheckNoResourceAttr(resourceName, "services.rds.enable"),
+				),
+			},
+			// Test: Import
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret_key"},
+			},
+		},
+	})
+}
+
 func testAccCheckMackerelAWSIntegrationDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*mackerel.Client)
 	for _, r := range s.RootModule().Resources {
@@ -181,6 +279,19 @@ func testAccCheckMackerelAWSIntegrationExists(n string) resource.TestCheckFunc {
 	}
 }
 
+func testAccCheckMackerelAWSIntegrationDisappears(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("aws integration not found from resources: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*mackerel.Client)
+		_, err := client.DeleteAWSIntegration(rs.Primary.ID)
+		return err
+	}
+}
+
 func testAccSourceMackerelAWSIntegrationConfigIAMRole(rand, name, roleArn, externalID string) string {
 	return fmt.Sprintf(`
 resource "mackerel_service" "include" {
@@ -368,3 +479,123 @@ resource "mackerel_aws_integration" "foo" {
 }
 `, rand, rand, name, awsAccessKeyID, awsSecretAccessKey)
 }
+
+func testAccMackerelAWSIntegrationConfigFramework(rand, name, roleArn, externalID string) string {
+	return fmt.Sprintf(`
+resource "mackerel_service" "include" {
+  name = "tf-service-%s-include"
+}
+
+resource "mackerel_role" "include" {
+  service = mackerel_service.include.name
+  name    = "tf-role-%s-include"
+}
+
+resource "mackerel_aws_integration" "foo" {
+  name        = "%s"
+  role_arn    = "%s"
+  external_id = "%s"
+  region      = "ap-northeast-1"
+
+  services = {
+    alb = {
+      role             = "${mackerel_service.include.name}: ${mackerel_role.include.name}"
+      excluded_metrics = ["alb.request.count", "alb.bytes.processed"]
+    }
+    rds = {
+      role                 = "${mackerel_service.include.name}: ${mackerel_role.include.name}"
+      retire_automatically = true
+    }
+    nlb = {
+      enable = false
+    }
+  }
+}
+
+data "mackerel_aws_integration" "foo" {
+  id = mackerel_aws_integration.foo.id
+}
+`, rand, rand, name, roleArn, externalID)
+}
+
+func testAccMackerelAWSIntegrationConfigFrameworkUpdated(rand, name, roleArn, externalID string) string {
+	return fmt.Sprintf(`
+resource "mackerel_service" "include" {
+  name = "tf-service-%s-include"
+}
+
+resource "mackerel_role" "include" {
+  service = mackerel_service.include.name
+  name    = "tf-role-%s-include"
+}
+
+resource "mackerel_aws_integration" "foo" {
+  name        = "%s"
+  role_arn    = "%s"
+  external_id = "%s"
+  region      = "ap-northeast-1"
+
+  services = {
+    alb = {
+      role = "${mackerel_service.include.name}: ${mackerel_role.include.name}"
+    }
+    ec2 = {
+      retire_automatically = true
+    }
+    lambda = {}
+  }
+}
+`, rand, rand, name, roleArn, externalID)
+}
+
+func TestUpgradeSDKStateAWSIntegration(t *testing.T) {
+	t.Parallel()
+
+	role := "service:role"
+	awsIntegration := &mackerel.AWSIntegration{
+		ID:         "test",
+		Name:       "aws",
+		RoleArn:    "arn:aws:iam::123456789012:role/mackerel",
+		ExternalID: "external",
+		Region:     "ap-northeast-1",
+		Services: map[string]*mackerel.AWSIntegrationService{
+			"EC2": {
+				Enable:              true,
+				Role:                &role,
+				ExcludedMetrics:     []string{},
+				RetireAutomatically: true,
+			},
+			"ALB": {
+				Enable:          true,
+				ExcludedMetrics: []string{"alb.request.count", "alb.bytes.processed"},
+			},
+			"RDS": {
+				Enable: false,
+			},
+		},
+	}
+
+	services := tftypes.NewAttributePath().WithAttributeName("services")
+	excludedMetrics := func(names ...string) tftypes.Value {
+		values := make([]tftypes.Value, 0, len(names))
+		for _, name := range names {
+			values = append(values, tftypes.NewValue(tftypes.String, name))
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, values)
+	}
+	testUpgradeSDKState(t, "mackerel_aws_integration", func(d *schema.ResourceData) diag.Diagnostics {
+		return flattenAWSIntegration(awsIntegration, d)
+	}, []upgradedStateCheck{
+		{tftypes.NewAttributePath().WithAttributeName("name"), tftypes.NewValue(tftypes.String, "aws")},
+		{tftypes.NewAttributePath().WithAttributeName("role_arn"), tftypes.NewValue(tftypes.String, "arn:aws:iam::123456789012:role/mackerel")},
+		{tftypes.NewAttributePath().WithAttributeName("region"), tftypes.NewValue(tftypes.String, "ap-northeast-1")},
+		{services.WithElementKeyString("ec2").WithAttributeName("enable"), tftypes.NewValue(tftypes.Bool, true)},
+		{services.WithElementKeyString("ec2").WithAttributeName("role"), tftypes.NewValue(tftypes.String, "service:role")},
+		{services.WithElementKeyString("ec2").WithAttributeName("excluded_metrics"), excludedMetrics()},
+		{services.WithElementKeyString("ec2").WithAttributeName("retire_automatically"), tftypes.NewValue(tftypes.Bool, true)},
+		{services.WithElementKeyString("alb").WithAttributeName("role"), tftypes.Value{}},
+		{services.WithElementKeyString("alb").WithAttributeName("excluded_metrics"), excludedMetrics("alb.request.count", "alb.bytes.processed")},
+		{services.WithElementKeyString("alb").WithAttributeName("retire_automatically"), tftypes.NewValue(tftypes.Bool, false)},
+		{tftypes.NewAttributePath().WithAttributeName("timeouts"), tftypes.Value{}},
+	})
+}
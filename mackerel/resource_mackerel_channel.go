@@ -2,6 +2,7 @@ package mackerel
 
 import (
 	"context"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -17,6 +18,11 @@ func resourceMackerelChannel() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -118,20 +124,20 @@ func resourceMackerelChannel() *schema.Resource {
 }
 
 func resourceMackerelChannelCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	channel, err := client.CreateChannel(expandChannel(d))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromResourceErr(err, d)
 	}
 	d.SetId(channel.ID)
 	return resourceMackerelChannelRead(ctx, d, m)
 }
 
-func resourceMackerelChannelRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+func resourceMackerelChannelRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := clientWithContext(ctx, m)
 	channels, err := client.FindChannels()
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	var channel *mackerel.Channel
 	for _, c := range channels {
@@ -141,17 +147,19 @@ func resourceMackerelChannelRead(_ context.Context, d *schema.ResourceData, m in
 		}
 	}
 	if channel == nil {
-		return diag.Errorf("the ID '%s' does not match any channel in mackerel.io", d.Id())
+		log.Printf("[WARN] channel (%s) is not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
 	}
 	return flattenChannel(channel, d)
 }
 
-func resourceMackerelChannelDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceMackerelChannelDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	_, err := client.DeleteChannel(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	return diags
 }
@@ -11,6 +11,8 @@ import (
 )
 
 func TestAccMackerelChannel_Email(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_channel")
+
 	resourceName := "mackerel_channel.email"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-channel email %s", rand)
@@ -18,7 +20,7 @@ func TestAccMackerelChannel_Email(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelChannelDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -60,6 +62,8 @@ func TestAccMackerelChannel_Email(t *testing.T) {
 }
 
 func TestAccMackerelChannel_Slack(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_channel")
+
 	resourceName := "mackerel_channel.slack"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-channel slack %s", rand)
@@ -67,7 +71,7 @@ func TestAccMackerelChannel_Slack(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelChannelDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -111,6 +115,8 @@ func TestAccMackerelChannel_Slack(t *testing.T) {
 }
 
 func TestAccMackerelChannel_Webhook(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_channel")
+
 	resourceName := "mackerel_channel.webhook"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-channel slack %s", rand)
@@ -118,7 +124,7 @@ func TestAccMackerelChannel_Webhook(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelChannelDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -157,6 +163,54 @@ func TestAccMackerelChannel_Webhook(t *testing.T) {
 	})
 }
 
+func TestAccMackerelChannel_disappears(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_channel")
+
+	resourceName := "mackerel_channel.email"
+	rand := acctest.RandString(5)
+	name := fmt.Sprintf("tf-channel email %s", rand)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMackerelChannelDestroy,
+		Steps: []resource.TestStep{
+			// Test: Deleted outside of Terraform
+			{
+				Config: testAccMackerelChannelConfigEmail(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMackerelChannelExists(resourceName),
+					testAccCheckMackerelChannelDisappears(resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccMackerelChannel_switchImplementation(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_channel")
+
+	resourceName := "mackerel_channel.email"
+	name := fmt.Sprintf("tf-channel email %s", acctest.RandString(5))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAccCheckMackerelChannelDestroy,
+		Steps: testAccSwitchImplementationSteps(
+			"mackerel_channel",
+			testAccMackerelChannelConfigEmailUpdated(name),
+			testAccMackerelChannelConfigEmailFramework(name),
+			resource.ComposeTestCheckFunc(
+				testAccCheckMackerelChannelExists(resourceName),
+				resource.TestCheckResourceAttr(resourceName, "email.emails.#", "1"),
+				resource.TestCheckResourceAttr(resourceName, "email.events.#", "2"),
+				resource.TestCheckNoResourceAttr(resourceName, "slack.url"),
+			),
+		),
+	})
+}
+
 func testAccCheckMackerelChannelDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*mackerel.Client)
 	for _, r := range s.RootModule().Resources {
@@ -202,6 +256,19 @@ func testAccCheckMackerelChannelExists(n string) resource.TestCheckFunc {
 	}
 }
 
+func testAccCheckMackerelChannelDisappears(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("channel not found from resources: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*mackerel.Client)
+		_, err := client.DeleteChannel(rs.Primary.ID)
+		return err
+	}
+}
+
 func testAccMackerelChannelConfigEmail(name string) string {
 	return fmt.Sprintf(`
 resource "mackerel_channel" "email" {
@@ -226,6 +293,21 @@ resource "mackerel_channel" "email" {
 `, name)
 }
 
+func testAccMackerelChannelConfigEmailFramework(name string) string {
+	return fmt.Sprintf(`
+resource "mackerel_channel" "email" {
+  name = "%s"
+  email = {
+    emails = [
+      "john.doe@example.test"]
+    events = [
+      "alert",
+      "alertGroup"]
+  }
+}
+`, name)
+}
+
 func testAccMackerelChannelConfigSlack(name string) string {
 	return fmt.Sprintf(`
 resource "mackerel_channel" "slack" {
@@ -2,10 +2,13 @@ package mackerel
 
 import (
 	"context"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/mackerelio/mackerel-client-go"
+
+	mackerelinternal "github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
 )
 
 var dashboardRangeResource = &schema.Resource{
@@ -106,8 +109,9 @@ var dashboardMetricResource = &schema.Resource{
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"expression": {
-						Type:     schema.TypeString,
-						Required: true,
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: ValidateMetricExpression,
 					},
 				},
 			},
@@ -118,8 +122,9 @@ var dashboardMetricResource = &schema.Resource{
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"query": {
-						Type:     schema.TypeString,
-						Required: true,
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: ValidateMetricQuery,
 					},
 					"legend": {
 						Type:     schema.TypeString,
@@ -140,6 +145,12 @@ func resourceMackerelDashboard() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 		Schema: map[string]*schema.Schema{
 			"title": {
 				Type:     schema.TypeString,
@@ -225,8 +236,9 @@ func resourceMackerelDashboard() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"expression": {
-										Type:     schema.TypeString,
-										Required: true,
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: ValidateMetricExpression,
 									},
 								},
 							},
@@ -238,8 +250,9 @@ func resourceMackerelDashboard() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"query": {
-										Type:     schema.TypeString,
-										Required: true,
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: ValidateMetricQuery,
 									},
 									"legend": {
 										Type:     schema.TypeString,
@@ -344,39 +357,44 @@ func resourceMackerelDashboard() *schema.Resource {
 }
 
 func resourceMackerelDashboardCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	dashboard, err := client.CreateDashboard(expandDashboard(d))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromResourceErr(err, d)
 	}
 	d.SetId(dashboard.ID)
 	return resourceMackerelDashboardRead(ctx, d, m)
 }
 
 func resourceMackerelDashboardRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	dashboard, err := client.FindDashboard(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		if mackerelinternal.IsNotFound(err) {
+			log.Printf("[WARN] dashboard (%s) is not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diagFromErr(err)
 	}
 	return flattenDashboard(dashboard, d)
 }
 
 func resourceMackerelDashboardUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	_, err := client.UpdateDashboard(d.Id(), expandDashboard(d))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromResourceErr(err, d)
 	}
 	return resourceMackerelDashboardRead(ctx, d, m)
 }
 
 func resourceMackerelDashboardDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	_, err := client.DeleteDashboard(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	return diags
 }
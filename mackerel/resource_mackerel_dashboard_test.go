@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -11,6 +14,8 @@ import (
 )
 
 func TestAccMackerelDashboardGraph(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_dashboard")
+
 	resourceName := "mackerel_dashboard.graph"
 	rand := acctest.RandString(5)
 	title := fmt.Sprintf("tf-dashboard graph %s", rand)
@@ -18,7 +23,7 @@ func TestAccMackerelDashboardGraph(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelDashboardDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -87,6 +92,8 @@ func TestAccMackerelDashboardGraph(t *testing.T) {
 }
 
 func TestAccMackerelDashboardValue(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_dashboard")
+
 	resourceName := "mackerel_dashboard.value"
 	rand := acctest.RandString(5)
 	title := fmt.Sprintf("tf-dashboard value %s", rand)
@@ -94,7 +101,7 @@ func TestAccMackerelDashboardValue(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelDashboardDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -151,6 +158,8 @@ func TestAccMackerelDashboardValue(t *testing.T) {
 }
 
 func TestAccMackerelDashboardMarkdown(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_dashboard")
+
 	resourceName := "mackerel_dashboard.markdown"
 	rand := acctest.RandString(5)
 	title := fmt.Sprintf("tf-dashboard markdown %s", rand)
@@ -158,7 +167,7 @@ func TestAccMackerelDashboardMarkdown(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelDashboardDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -216,6 +225,8 @@ func TestAccMackerelDashboardMarkdown(t *testing.T) {
 }
 
 func TestAccMackerelDashboardAlertStatus(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_dashboard")
+
 	resourceName := "mackerel_dashboard.alertstatus"
 	rand := acctest.RandString(5)
 	title := fmt.Sprintf("tf-dashboard alertstatus %s", rand)
@@ -223,7 +234,7 @@ func TestAccMackerelDashboardAlertStatus(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelDashboardDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -274,6 +285,88 @@ func TestAccMackerelDashboardAlertStatus(t *testing.T) {
 	})
 }
 
+func TestAccMackerelDashboardFramework(t *testing.T) {
+	testAccSkipSDK(t, "mackerel_dashboard")
+
+	resourceName := "mackerel_dashboard.foo"
+	rand := acctest.RandString(5)
+	title := fmt.Sprintf("tf-dashboard %s", rand)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMackerelDashboardDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccMackerelDashboardConfigFramework(rand, title),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMackerelDashboardExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "title", title),
+					resource.TestCheckResourceAttr(resourceName, "url_path", rand),
+					resource.TestCheckResourceAttr(resourceName, "graph.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "graph.0.title", "test graph role"),
+					resource.TestCheckResourceAttr(resourceName, "graph.0.role.role_fullname", fmt.Sprintf("tf-service-%s-include:tf-role-%s-include", rand, rand)),
+					resource.TestCheckResourceAttr(resourceName, "graph.0.role.name", "loadavg5"),
+					resource.TestCheckResourceAttr(resourceName, "graph.0.role.is_stacked", "true"),
+					resource.TestCheckResourceAttr(resourceName, "graph.0.range.relative.period", "3600"),
+					resource.TestCheckResourceAttr(resourceName, "graph.0.range.relative.offset", "1800"),
+					resource.TestCheckResourceAttr(resourceName, "graph.0.layout.x", "2"),
+					resource.TestCheckResourceAttr(resourceName, "graph.0.layout.y", "12"),
+					resource.TestCheckResourceAttr(resourceName, "graph.0.layout.width", "10"),
+					resource.TestCheckResourceAttr(resourceName, "graph.0.layout.height", "8"),
+					resource.TestCheckResourceAttr(resourceName, "graph.1.title", "test graph query"),
+					resource.TestCheckResourceAttr(resourceName, "graph.1.query.query", "container.cpu.utilization{k8s.deployment.name=\"httpbin\"}"),
+					resource.TestCheckResourceAttr(resourceName, "graph.1.query.legend", "{{k8s.node.name}}"),
+					resource.TestCheckResourceAttr(resourceName, "graph.1.range.absolute.start", "1667275734"),
+					resource.TestCheckResourceAttr(resourceName, "graph.1.range.absolute.end", "1672546734"),
+					resource.TestCheckResourceAttr(resourceName, "value.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "value.0.title", "test value expression"),
+					resource.TestCheckResourceAttr(resourceName, "value.0.metric.expression.expression", fmt.Sprintf("role(tf-service-%s-include:tf-role-%s-include, loadavg5)", rand, rand)),
+					resource.TestCheckResourceAttr(resourceName, "value.0.fraction_size", "5"),
+					resource.TestCheckResourceAttr(resourceName, "value.0.suffix", "test suffix"),
+					resource.TestCheckResourceAttr(resourceName, "markdown.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "markdown.0.markdown", "# h1"),
+					resource.TestCheckResourceAttr(resourceName, "alert_status.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "alert_status.0.role_fullname", fmt.Sprintf("tf-service-%s-include:tf-role-%s-include", rand, rand)),
+					resource.TestCheckResourceAttr(resourceName, "alert_status.0.layout.x", "5"),
+				),
+			},
+			// Test: Import
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccMackerelDashboard_disappears(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_dashboard")
+
+	resourceName := "mackerel_dashboard.markdown"
+	rand := acctest.RandString(5)
+	title := fmt.Sprintf("tf-dashboard markdown %s", rand)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMackerelDashboardDestroy,
+		Steps: []resource.TestStep{
+			// Test: Deleted outside of Terraform
+			{
+				Config: testAccMackerelDashboardConfigMarkdown(rand, title),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMackerelDashboardExists(resourceName),
+					testAccCheckMackerelDashboardDisappears(resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func testAccCheckMackerelDashboardDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*mackerel.Client)
 	for _, r := range s.RootModule().Resources {
@@ -319,6 +412,19 @@ func testAccCheckMackerelDashboardExists(n string) resource.TestCheckFunc {
 	}
 }
 
+func testAccCheckMackerelDashboardDisappears(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("dashboard not found from resources: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*mackerel.Client)
+		_, err := client.DeleteDashboard(rs.Primary.ID)
+		return err
+	}
+}
+
 func testAccMackerelDashboardConfigGraph(rand string, title string) string {
 	return fmt.Sprintf(`
 resource "mackerel_service" "include" {
@@ -610,3 +716,172 @@ resource "mackerel_dashboard" "alertstatus" {
 }
 `, rand, rand, title, rand)
 }
+
+func testAccMackerelDashboardConfigFramework(rand, title string) string {
+	return fmt.Sprintf(`
+resource "mackerel_service" "include" {
+  name = "tf-service-%s-include"
+}
+
+resource "mackerel_role" "include" {
+  service = mackerel_service.include.name
+  name    = "tf-role-%s-include"
+}
+
+resource "mackerel_dashboard" "foo" {
+  title = "%s"
+  url_path = "%s"
+  graph = [
+    {
+      title = "test graph role"
+      role = {
+        role_fullname = "${mackerel_service.include.name}:${mackerel_role.include.name}"
+        name = "loadavg5"
+        is_stacked = true
+      }
+      range = {
+        relative = {
+          period = 3600
+          offset = 1800
+        }
+      }
+      layout = {
+        x = 2
+        y = 12
+        width = 10
+        height = 8
+      }
+    },
+    {
+      title = "test graph query"
+      query = {
+        query = "container.cpu.utilization{k8s.deployment.name=\"httpbin\"}"
+        legend = "{{k8s.node.name}}"
+      }
+      range = {
+        absolute = {
+          start = 1667275734
+          end = 1672546734
+        }
+      }
+      layout = {
+        x = 0
+        y = 20
+        width = 10
+        height = 8
+      }
+    },
+  ]
+  value = [
+    {
+      title = "test value expression"
+      metric = {
+        expression = {
+          expression = "role(${mackerel_service.include.name}:${mackerel_role.include.name}, loadavg5)"
+        }
+      }
+      fraction_size = 5
+      suffix = "test suffix"
+      layout = {
+        x = 10
+        y = 12
+        width = 3
+        height = 4
+      }
+    },
+  ]
+  markdown = [
+    {
+      title = "test markdown"
+      markdown = "# h1"
+      layout = {
+        x = 13
+        y = 12
+        width = 3
+        height = 4
+      }
+    },
+  ]
+  alert_status = [
+    {
+      title = "test alertStatus"
+      role_fullname = "${mackerel_service.include.name}:${mackerel_role.include.name}"
+      layout = {
+        x = 5
+        y = 7
+        width = 3
+        height = 4
+      }
+    },
+  ]
+}
+`, rand, rand, title, rand)
+}
+
+func TestUpgradeSDKStateDashboard(t *testing.T) {
+	t.Parallel()
+
+	fractionSize := int64(2)
+	dashboard := &mackerel.Dashboard{
+		ID:      "test",
+		Title:   "dashboard",
+		Memo:    "memo",
+		URLPath: "path",
+		Widgets: []mackerel.Widget{
+			{
+				Type:   "graph",
+				Title:  "role graph",
+				Graph:  mackerel.Graph{Type: "role", RoleFullName: "service:role", Name: "loadavg5", IsStacked: true},
+				Range:  mackerel.Range{Type: "relative", Period: 3600, Offset: 1800},
+				Layout: mackerel.Layout{X: 0, Y: 0, Width: 12, Height: 6},
+			},
+			{
+				Type:   "graph",
+				Title:  "expression graph",
+				Graph:  mackerel.Graph{Type: "expression", Expression: "role(service:role, loadavg5)"},
+				Layout: mackerel.Layout{X: 12, Y: 0, Width: 12, Height: 6},
+			},
+			{
+				Type:         "value",
+				Title:        "value",
+				Metric:       mackerel.Metric{Type: "expression", Expression: "max(role(service:role, loadavg5))"},
+				FractionSize: &fractionSize,
+				Suffix:       "%",
+				Layout:       mackerel.Layout{X: 0, Y: 6, Width: 4, Height: 4},
+			},
+			{
+				Type:     "markdown",
+				Title:    "markdown",
+				Markdown: "# title",
+				Layout:   mackerel.Layout{X: 4, Y: 6, Width: 4, Height: 4},
+			},
+		},
+	}
+
+	graph := tftypes.NewAttributePath().WithAttributeName("graph")
+	value := tftypes.NewAttributePath().WithAttributeName("value").WithElementKeyInt(0)
+	markdown := tftypes.NewAttributePath().WithAttributeName("markdown").WithElementKeyInt(0)
+	testUpgradeSDKState(t, "mackerel_dashboard", func(d *schema.ResourceData) diag.Diagnostics {
+		return flattenDashboard(dashboard, d)
+	}, []upgradedStateCheck{
+		{tftypes.NewAttributePath().WithAttributeName("title"), tftypes.NewValue(tftypes.String, "dashboard")},
+		{tftypes.NewAttributePath().WithAttributeName("url_path"), tftypes.NewValue(tftypes.String, "path")},
+		{graph.WithElementKeyInt(0).WithAttributeName("title"), tftypes.NewValue(tftypes.String, "role graph")},
+		{graph.WithElementKeyInt(0).WithAttributeName("role").WithAttributeName("role_fullname"), tftypes.NewValue(tftypes.String, "service:role")},
+		{graph.WithElementKeyInt(0).WithAttributeName("role").WithAttributeName("is_stacked"), tftypes.NewValue(tftypes.Bool, true)},
+		{graph.WithElementKeyInt(0).WithAttributeName("host"), tftypes.Value{}},
+		{graph.WithElementKeyInt(0).WithAttributeName("range").WithAttributeName("relative").WithAttributeName("period"), tftypes.NewValue(tftypes.Number, 3600)},
+		{graph.WithElementKeyInt(0).WithAttributeName("range").WithAttributeName("absolute"), tftypes.Value{}},
+		{graph.WithElementKeyInt(0).WithAttributeName("layout").WithAttributeName("width"), tftypes.NewValue(tftypes.Number, 12)},
+		{graph.WithElementKeyInt(1).WithAttributeName("expression").WithAttributeName("expression"), tftypes.NewValue(tftypes.String, "role(service:role, loadavg5)")},
+		{graph.WithElementKeyInt(1).WithAttributeName("range"), tftypes.Value{}},
+		{graph.WithElementKeyInt(1).WithAttributeName("layout").WithAttributeName("x"), tftypes.NewValue(tftypes.Number, 12)},
+		{value.WithAttributeName("metric").WithAttributeName("expression").WithAttributeName("expression"), tftypes.NewValue(tftypes.String, "max(role(service:role, loadavg5))")},
+		{value.WithAttributeName("metric").WithAttributeName("host"), tftypes.Value{}},
+		{value.WithAttributeName("fraction_size"), tftypes.NewValue(tftypes.Number, 2)},
+		{value.WithAttributeName("suffix"), tftypes.NewValue(tftypes.String, "%")},
+		{markdown.WithAttributeName("markdown"), tftypes.NewValue(tftypes.String, "# title")},
+		{markdown.WithAttributeName("layout").WithAttributeName("y"), tftypes.NewValue(tftypes.Number, 6)},
+		{tftypes.NewAttributePath().WithAttributeName("timeouts"), tftypes.Value{}},
+	})
+}
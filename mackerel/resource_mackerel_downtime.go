@@ -2,6 +2,7 @@ package mackerel
 
 import (
 	"context"
+	"log"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -19,6 +20,12 @@ func resourceMackerelDowntime() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -102,20 +109,20 @@ func resourceMackerelDowntime() *schema.Resource {
 }
 
 func resourceMackerelDowntimeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	dt, err := client.CreateDowntime(expandDowntime(d))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromResourceErr(err, d)
 	}
 	d.SetId(dt.ID)
 	return resourceMackerelDowntimeRead(ctx, d, m)
 }
 
-func resourceMackerelDowntimeRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+func resourceMackerelDowntimeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := clientWithContext(ctx, m)
 	downtimes, err := client.FindDowntimes()
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	var downtime *mackerel.Downtime
 	for _, dt := range downtimes {
@@ -125,26 +132,28 @@ func resourceMackerelDowntimeRead(_ context.Context, d *schema.ResourceData, m i
 		}
 	}
 	if downtime == nil {
-		return diag.Errorf("the ID '%s' does not match any downtime in mackerel.io", d.Id())
+		log.Printf("[WARN] downtime (%s) is not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
 	}
 	return flattenDowntime(downtime, d)
 }
 
 func resourceMackerelDowntimeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	_, err := client.UpdateDowntime(d.Id(), expandDowntime(d))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromResourceErr(err, d)
 	}
 	return resourceMackerelDowntimeRead(ctx, d, m)
 }
 
-func resourceMackerelDowntimeDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceMackerelDowntimeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := meta.(*mackerel.Client)
+	client := clientWithContext(ctx, meta)
 	_, err := client.DeleteDowntime(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	return diags
 }
@@ -11,6 +11,8 @@ import (
 )
 
 func TestAccMackerelDowntime(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_downtime")
+
 	resourceName := "mackerel_downtime.foo"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-downtime-%s", rand)
@@ -18,7 +20,7 @@ func TestAccMackerelDowntime(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelDowntimeDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -71,6 +73,54 @@ func TestAccMackerelDowntime(t *testing.T) {
 	})
 }
 
+func TestAccMackerelDowntime_disappears(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_downtime")
+
+	resourceName := "mackerel_downtime.foo"
+	rand := acctest.RandString(5)
+	name := fmt.Sprintf("tf-downtime-%s", rand)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMackerelDowntimeDestroy,
+		Steps: []resource.TestStep{
+			// Test: Deleted outside of Terraform
+			{
+				Config: testAccMackerelDowntimeConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMackerelDowntimeExists(resourceName),
+					testAccCheckMackerelDowntimeDisappears(resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccMackerelDowntime_switchImplementation(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_downtime")
+
+	resourceName := "mackerel_downtime.foo"
+	name := fmt.Sprintf("tf-downtime-%s", acctest.RandString(5))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAccCheckMackerelDowntimeDestroy,
+		Steps: testAccSwitchImplementationSteps(
+			"mackerel_downtime",
+			testAccMackerelDowntimeConfigRecurrence(name, "recurrence"),
+			testAccMackerelDowntimeConfigRecurrence(name, "recurrence ="),
+			resource.ComposeTestCheckFunc(
+				testAccCheckMackerelDowntimeExists(resourceName),
+				resource.TestCheckResourceAttr(resourceName, "recurrence.type", "weekly"),
+				resource.TestCheckResourceAttr(resourceName, "recurrence.interval", "2"),
+				resource.TestCheckResourceAttr(resourceName, "recurrence.weekdays.#", "2"),
+			),
+		),
+	})
+}
+
 func testAccCheckMackerelDowntimeDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*mackerel.Client)
 	for _, r := range s.RootModule().Resources {
@@ -116,6 +166,19 @@ func testAccCheckMackerelDowntimeExists(n string) resource.TestCheckFunc {
 	}
 }
 
+func testAccCheckMackerelDowntimeDisappears(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("downtime not found from resources: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*mackerel.Client)
+		_, err := client.DeleteDowntime(rs.Primary.ID)
+		return err
+	}
+}
+
 func testAccMackerelDowntimeConfig(name string) string {
 	return fmt.Sprintf(`
 resource "mackerel_downtime" "foo" {
@@ -177,3 +240,23 @@ resource "mackerel_downtime" "foo" {
 
 `, rand, rand, rand, rand, name)
 }
+
+// The recurrence is a block (`recurrence {}`) in the SDK based implementation
+// and an attribute (`recurrence = {}`) in the framework based one.
+func testAccMackerelDowntimeConfigRecurrence(name, recurrence string) string {
+	return fmt.Sprintf(`
+resource "mackerel_downtime" "foo" {
+  name = "%s"
+  start = 1735707600
+  duration = 3600
+
+  %s {
+    type = "weekly"
+    interval = 2
+    weekdays = [
+      "Saturday",
+      "Sunday"]
+  }
+}
+`, name, recurrence)
+}
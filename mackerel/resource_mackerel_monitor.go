@@ -2,12 +2,16 @@ package mackerel
 
 import (
 	"context"
-	"strconv"
+	"fmt"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/mackerelio/mackerel-client-go"
+
+	mackerelinternal "github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/typeutil"
 )
 
 var (
@@ -31,6 +35,13 @@ func resourceMackerelMonitor() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customizeDiffMonitorThresholds,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -277,8 +288,9 @@ func resourceMackerelMonitor() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"expression": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: ValidateMetricExpression,
 						},
 						"operator": {
 							Type:         schema.TypeString,
@@ -345,9 +357,10 @@ func resourceMackerelMonitor() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"query": {
-							Type:     schema.TypeString,
-							Required: true,
-							ForceNew: true,
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: ValidateMetricQuery,
 						},
 						"legend": {
 							Type:     schema.TypeString,
@@ -378,71 +391,85 @@ func resourceMackerelMonitor() *schema.Resource {
 }
 
 func resourceMackerelMonitorCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
-	monitor, err := client.CreateMonitor(expandMonitor(d))
+	input, err := expandMonitor(d)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromResourceErr(err, d)
+	}
+	client := clientWithContext(ctx, m)
+	monitor, err := client.CreateMonitor(input)
+	if err != nil {
+		return diagFromResourceErr(err, d)
 	}
 	d.SetId(monitor.MonitorID())
 	return resourceMackerelMonitorRead(ctx, d, m)
 }
 
-func resourceMackerelMonitorRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+func resourceMackerelMonitorRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := clientWithContext(ctx, m)
 	monitor, err := client.GetMonitor(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		if mackerelinternal.IsNotFound(err) {
+			log.Printf("[WARN] monitor (%s) is not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diagFromErr(err)
 	}
 	return flattenMonitor(monitor, d)
 }
 
 func resourceMackerelMonitorUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
-	monitor, err := client.UpdateMonitor(d.Id(), expandMonitor(d))
+	input, err := expandMonitor(d)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromResourceErr(err, d)
+	}
+	client := clientWithContext(ctx, m)
+	monitor, err := client.UpdateMonitor(d.Id(), input)
+	if err != nil {
+		return diagFromResourceErr(err, d)
 	}
 	d.SetId(monitor.MonitorID())
 	return resourceMackerelMonitorRead(ctx, d, m)
 }
 
-func resourceMackerelMonitorDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceMackerelMonitorDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	_, err := client.DeleteMonitor(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	return diags
 }
 
-func expandMonitor(d *schema.ResourceData) mackerel.Monitor {
+func expandMonitor(d *schema.ResourceData) (mackerel.Monitor, error) {
 	var monitor mackerel.Monitor
+	var err error
 	if _, ok := d.GetOk("host_metric"); ok {
-		monitor = expandMonitorHostMetric(d)
+		monitor, err = expandMonitorHostMetric(d)
 	}
 	if _, ok := d.GetOk("connectivity"); ok {
 		monitor = expandMonitorConnectivity(d)
 	}
 	if _, ok := d.GetOk("service_metric"); ok {
-		monitor = expandMonitorServiceMetric(d)
+		monitor, err = expandMonitorServiceMetric(d)
 	}
 	if _, ok := d.GetOk("external"); ok {
 		monitor = expandMonitorExternalHTTP(d)
 	}
 	if _, ok := d.GetOk("expression"); ok {
-		monitor = expandMonitorExpression(d)
+		monitor, err = expandMonitorExpression(d)
 	}
 	if _, ok := d.GetOk("anomaly_detection"); ok {
 		monitor = expandMonitorAnomalyDetection(d)
 	}
 	if _, ok := d.GetOk("query"); ok {
-		monitor = expandMonitorQuery(d)
+		monitor, err = expandMonitorQuery(d)
 	}
-	return monitor
+	return monitor, err
 }
 
-func expandMonitorHostMetric(d *schema.ResourceData) *mackerel.MonitorHostMetric {
+func expandMonitorHostMetric(d *schema.ResourceData) (*mackerel.MonitorHostMetric, error) {
 	monitor := &mackerel.MonitorHostMetric{
 		Name:                 d.Get("name").(string),
 		Memo:                 d.Get("memo").(string),
@@ -459,20 +486,27 @@ func expandMonitorHostMetric(d *schema.ResourceData) *mackerel.MonitorHostMetric
 		ExcludeScopes:        expandStringListFromSet(d.Get("host_metric.0.exclude_scopes").(*schema.Set)),
 	}
 
-	if warning, ok := d.GetOkExists("host_metric.0.warning"); ok {
-		w, err := strconv.ParseFloat(warning.(string), 64)
-		if err == nil {
-			monitor.Warning = &w
-		}
+	var err error
+	if monitor.Warning, err = expandMonitorThreshold(d, "host_metric.0.warning"); err != nil {
+		return nil, err
 	}
-	if critical, ok := d.GetOkExists("host_metric.0.critical"); ok {
-		c, err := strconv.ParseFloat(critical.(string), 64)
-		if err == nil {
-			monitor.Critical = &c
-		}
+	if monitor.Critical, err = expandMonitorThreshold(d, "host_metric.0.critical"); err != nil {
+		return nil, err
 	}
+	return monitor, nil
+}
 
-	return monitor
+// Returns the threshold at key, or nil if it is empty.
+func expandMonitorThreshold(d *schema.ResourceData, key string) (*float64, error) {
+	s := d.Get(key).(string)
+	if s == "" {
+		return nil, nil
+	}
+	f, err := typeutil.ParseFloatString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return &f, nil
 }
 
 func expandMonitorConnectivity(d *schema.ResourceData) *mackerel.MonitorConnectivity {
@@ -489,7 +523,7 @@ func expandMonitorConnectivity(d *schema.ResourceData) *mackerel.MonitorConnecti
 	return monitor
 }
 
-func expandMonitorServiceMetric(d *schema.ResourceData) *mackerel.MonitorServiceMetric {
+func expandMonitorServiceMetric(d *schema.ResourceData) (*mackerel.MonitorServiceMetric, error) {
 	monitor := &mackerel.MonitorServiceMetric{
 		Name:                    d.Get("name").(string),
 		Memo:                    d.Get("memo").(string),
@@ -507,21 +541,14 @@ func expandMonitorServiceMetric(d *schema.ResourceData) *mackerel.MonitorService
 		MissingDurationCritical: uint64(d.Get("service_metric.0.missing_duration_critical").(int)),
 	}
 
-	if warning, ok := d.GetOkExists("service_metric.0.warning"); ok {
-		w, err := strconv.ParseFloat(warning.(string), 64)
-		if err == nil {
-			monitor.Warning = &w
-		}
+	var err error
+	if monitor.Warning, err = expandMonitorThreshold(d, "service_metric.0.warning"); err != nil {
+		return nil, err
 	}
-
-	if critical, ok := d.GetOkExists("service_metric.0.critical"); ok {
-		c, err := strconv.ParseFloat(critical.(string), 64)
-		if err == nil {
-			monitor.Critical = &c
-		}
+	if monitor.Critical, err = expandMonitorThreshold(d, "service_metric.0.critical"); err != nil {
+		return nil, err
 	}
-
-	return monitor
+	return monitor, nil
 }
 
 func expandMonitorExternalHTTP(d *schema.ResourceData) *mackerel.MonitorExternalHTTP {
@@ -575,7 +602,7 @@ func expandMonitorExternalHTTP(d *schema.ResourceData) *mackerel.MonitorExternal
 	return monitor
 }
 
-func expandMonitorExpression(d *schema.ResourceData) *mackerel.MonitorExpression {
+func expandMonitorExpression(d *schema.ResourceData) (*mackerel.MonitorExpression, error) {
 	monitor := &mackerel.MonitorExpression{
 		Name:                 d.Get("name").(string),
 		Memo:                 d.Get("memo").(string),
@@ -587,19 +614,15 @@ func expandMonitorExpression(d *schema.ResourceData) *mackerel.MonitorExpression
 		Warning:              nil,
 		Critical:             nil,
 	}
-	if warning, ok := d.GetOkExists("expression.0.warning"); ok {
-		w, err := strconv.ParseFloat(warning.(string), 64)
-		if err == nil {
-			monitor.Warning = &w
-		}
+
+	var err error
+	if monitor.Warning, err = expandMonitorThreshold(d, "expression.0.warning"); err != nil {
+		return nil, err
 	}
-	if critical, ok := d.GetOkExists("expression.0.critical"); ok {
-		c, err := strconv.ParseFloat(critical.(string), 64)
-		if err == nil {
-			monitor.Critical = &c
-		}
+	if monitor.Critical, err = expandMonitorThreshold(d, "expression.0.critical"); err != nil {
+		return nil, err
 	}
-	return monitor
+	return monitor, nil
 }
 
 func expandMonitorAnomalyDetection(d *schema.ResourceData) *mackerel.MonitorAnomalyDetection {
@@ -618,7 +641,7 @@ func expandMonitorAnomalyDetection(d *schema.ResourceData) *mackerel.MonitorAnom
 	return monitor
 }
 
-func expandMonitorQuery(d *schema.ResourceData) *mackerel.MonitorQuery {
+func expandMonitorQuery(d *schema.ResourceData) (*mackerel.MonitorQuery, error) {
 	monitor := &mackerel.MonitorQuery{
 		Name:                 d.Get("name").(string),
 		Memo:                 d.Get("memo").(string),
@@ -631,15 +654,40 @@ func expandMonitorQuery(d *schema.ResourceData) *mackerel.MonitorQuery {
 		Warning:              nil,
 		Critical:             nil,
 	}
-	if warning, ok := d.GetOkExists("query.0.warning"); ok {
-		if w, err := strconv.ParseFloat(warning.(string), 64); err == nil {
-			monitor.Warning = &w
-		}
+
+	var err error
+	if monitor.Warning, err = expandMonitorThreshold(d, "query.0.warning"); err != nil {
+		return nil, err
+	}
+	if monitor.Critical, err = expandMonitorThreshold(d, "query.0.critical"); err != nil {
+		return nil, err
 	}
-	if critical, ok := d.GetOkExists("query.0.critical"); ok {
-		if c, err := strconv.ParseFloat(critical.(string), 64); err == nil {
-			monitor.Critical = &c
+	return monitor, nil
+}
+
+// Checks that the warning and critical thresholds are in the order of the operator.
+func customizeDiffMonitorThresholds(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	for _, name := range mackerelinternal.ThresholdMonitorTypes {
+		if _, ok := d.GetOk(name); !ok {
+			continue
+		}
+		operator, warning, critical := name+".0.operator", name+".0.warning", name+".0.critical"
+		if !d.NewValueKnown(operator) || !d.NewValueKnown(warning) || !d.NewValueKnown(critical) {
+			continue
+		}
+
+		// Invalid thresholds are reported by ValidateFloatString.
+		w, err := typeutil.ParseFloatString(d.Get(warning).(string))
+		if err != nil {
+			continue
+		}
+		c, err := typeutil.ParseFloatString(d.Get(critical).(string))
+		if err != nil {
+			continue
+		}
+		if err := mackerelinternal.ValidateMonitorThresholdOrder(d.Get(operator).(string), w, c); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
 		}
 	}
-	return monitor
+	return nil
 }
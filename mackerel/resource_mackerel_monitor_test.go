@@ -1,16 +1,26 @@
 package mackerel
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/mackerelio/mackerel-client-go"
+
+	mackerelinternal "github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
 )
 
 func TestAccMackerelMonitor_HostMetric(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_monitor")
+
 	resourceName := "mackerel_monitor.foo"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-monitor host_metric %s", rand)
@@ -18,7 +28,7 @@ func TestAccMackerelMonitor_HostMetric(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelMonitorDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -88,6 +98,8 @@ func TestAccMackerelMonitor_HostMetric(t *testing.T) {
 }
 
 func TestAccMackerelMonitor_Connectivity(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_monitor")
+
 	resourceName := "mackerel_monitor.foo"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-monitor connectivity %s", rand)
@@ -95,7 +107,7 @@ func TestAccMackerelMonitor_Connectivity(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelMonitorDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -155,6 +167,8 @@ func TestAccMackerelMonitor_Connectivity(t *testing.T) {
 }
 
 func TestAccMackerelMonitor_ServiceMetric(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_monitor")
+
 	resourceName := "mackerel_monitor.foo"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-monitor service_metric %s", rand)
@@ -163,7 +177,7 @@ func TestAccMackerelMonitor_ServiceMetric(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelMonitorDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -211,8 +225,8 @@ func TestAccMackerelMonitor_ServiceMetric(t *testing.T) {
 						resource.TestCheckResourceAttr(resourceName, "service_metric.0.service", serviceName),
 						resource.TestCheckResourceAttr(resourceName, "service_metric.0.metric", "custom.access.5xx_ratio"),
 						resource.TestCheckResourceAttr(resourceName, "service_metric.0.operator", "<"),
-						resource.TestCheckResourceAttr(resourceName, "service_metric.0.warning", "99.9"),
-						resource.TestCheckResourceAttr(resourceName, "service_metric.0.critical", "99.99"),
+						resource.TestCheckResourceAttr(resourceName, "service_metric.0.warning", "99.99"),
+						resource.TestCheckResourceAttr(resourceName, "service_metric.0.critical", "99.9"),
 						resource.TestCheckResourceAttr(resourceName, "service_metric.0.duration", "3"),
 						resource.TestCheckResourceAttr(resourceName, "service_metric.0.max_check_attempts", "5"),
 						resource.TestCheckResourceAttr(resourceName, "service_metric.0.missing_duration_warning", "10"),
@@ -235,6 +249,8 @@ func TestAccMackerelMonitor_ServiceMetric(t *testing.T) {
 }
 
 func TestAccMackerelMonitor_External(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_monitor")
+
 	resourceName := "mackerel_monitor.foo"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-monitor external %s", rand)
@@ -243,7 +259,7 @@ func TestAccMackerelMonitor_External(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelMonitorDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -326,6 +342,8 @@ func TestAccMackerelMonitor_External(t *testing.T) {
 }
 
 func TestAccMackerelMonitor_Expression(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_monitor")
+
 	resourceName := "mackerel_monitor.foo"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-monitor expression %s", rand)
@@ -333,7 +351,7 @@ func TestAccMackerelMonitor_Expression(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelMonitorDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -394,6 +412,8 @@ func TestAccMackerelMonitor_Expression(t *testing.T) {
 }
 
 func TestAccMackerelMonitor_AnomalyDetection(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_monitor")
+
 	resourceName := "mackerel_monitor.foo"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-monitor anomaly_detection %s", rand)
@@ -401,7 +421,7 @@ func TestAccMackerelMonitor_AnomalyDetection(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelMonitorDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -465,13 +485,15 @@ func TestAccMackerelMonitor_AnomalyDetection(t *testing.T) {
 }
 
 func TestAccMackerelMonitor_Query(t *testing.T) {
+	testAccSkipFramework(t, "mackerel_monitor")
+
 	resourceName := "mackerel_monitor.foo"
 	rand := acctest.RandString(5)
 	name := fmt.Sprintf("tf-monitor query %s", rand)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelMonitorDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -534,6 +556,159 @@ func TestAccMackerelMonitor_Query(t *testing.T) {
 	})
 }
 
+func TestAccMackerelMonitor_disappears(t *testing.T) {
+	resourceName := "mackerel_monitor.foo"
+	rand := acctest.RandString(5)
+	name := fmt.Sprintf("tf-monitor connectivity %s", rand)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMackerelMonitorDestroy,
+		Steps: []resource.TestStep{
+			// Test: Deleted outside of Terraform
+			{
+				Config: testAccMackerelMonitorConfigConnectivity(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMackerelMonitorExists(resourceName),
+					testAccCheckMackerelMonitorDisappears(resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccMackerelMonitorFramework_HostMetric(t *testing.T) {
+	testAccSkipSDK(t, "mackerel_monitor")
+
+	resourceName := "mackerel_monitor.foo"
+	rand := acctest.RandString(5)
+	name := fmt.Sprintf("tf-monitor host_metric %s", rand)
+	nameUpdated := fmt.Sprintf("tf-monitor host_metric %s updated", rand)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMackerelMonitorDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccMackerelMonitorConfigHostMetric(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMackerelMonitorExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "memo", ""),
+					resource.TestCheckResourceAttr(resourceName, "is_mute", "false"),
+					resource.TestCheckResourceAttr(resourceName, "notification_interval", "0"),
+					resource.TestCheckResourceAttr(resourceName, "host_metric.metric", "cpu.sys"),
+					resource.TestCheckResourceAttr(resourceName, "host_metric.operator", ">"),
+					resource.TestCheckResourceAttr(resourceName, "host_metric.warning", "75"),
+					resource.TestCheckNoResourceAttr(resourceName, "host_metric.critical"),
+					resource.TestCheckResourceAttr(resourceName, "host_metric.duration", "1"),
+					resource.TestCheckResourceAttr(resourceName, "host_metric.max_check_attempts", "1"),
+					resource.TestCheckResourceAttr(resourceName, "host_metric.scopes.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "host_metric.exclude_scopes.#", "0"),
+					resource.TestCheckNoResourceAttr(resourceName, "connectivity.alert_status_on_gone"),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccMackerelMonitorConfigHostMetricUpdated(rand, nameUpdated),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMackerelMonitorExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", nameUpdated),
+					resource.TestCheckResourceAttr(resourceName, "memo", "This monitor is managed by Terraform."),
+					resource.TestCheckResourceAttr(resourceName, "is_mute", "true"),
+					resource.TestCheckResourceAttr(resourceName, "notification_interval", "30"),
+					resource.TestCheckResourceAttr(resourceName, "host_metric.metric", "cpu.usr"),
+					resource.TestCheckResourceAttr(resourceName, "host_metric.operator", ">"),
+					resource.TestCheckResourceAttr(resourceName, "host_metric.warning", "70"),
+					resource.TestCheckResourceAttr(resourceName, "host_metric.critical", "90"),
+					resource.TestCheckResourceAttr(resourceName, "host_metric.duration", "3"),
+					resource.TestCheckResourceAttr(resourceName, "host_metric.max_check_attempts", "5"),
+					resource.TestCheckResourceAttr(resourceName, "host_metric.scopes.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "host_metric.exclude_scopes.#", "2"),
+				),
+			},
+			// Test: Import
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccMackerelMonitorFramework_External(t *testing.T) {
+	testAccSkipSDK(t, "mackerel_monitor")
+
+	resourceName := "mackerel_monitor.foo"
+	rand := acctest.RandString(5)
+	name := fmt.Sprintf("tf-monitor external %s", rand)
+	nameUpdated := fmt.Sprintf("tf-monitor external %s updated", rand)
+	serviceName := fmt.Sprintf("tf-service-%s", rand)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMackerelMonitorDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccMackerelMonitorConfigExternal(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMackerelMonitorExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "external.method", "GET"),
+					resource.TestCheckResourceAttr(resourceName, "external.url", "https://terraform-provider-mackerel.test/"),
+					resource.TestCheckResourceAttr(resourceName, "external.max_check_attempts", "1"),
+					resource.TestCheckResourceAttr(resourceName, "external.service", ""),
+					resource.TestCheckNoResourceAttr(resourceName, "external.response_time_critical"),
+					resource.TestCheckNoResourceAttr(resourceName, "external.response_time_warning"),
+					resource.TestCheckNoResourceAttr(resourceName, "external.response_time_duration"),
+					resource.TestCheckResourceAttr(resourceName, "external.request_body", ""),
+					resource.TestCheckResourceAttr(resourceName, "external.contains_string", ""),
+					resource.TestCheckNoResourceAttr(resourceName, "external.certification_expiration_critical"),
+					resource.TestCheckNoResourceAttr(resourceName, "external.certification_expiration_warning"),
+					resource.TestCheckResourceAttr(resourceName, "external.skip_certificate_verification", "false"),
+					resource.TestCheckResourceAttr(resourceName, "external.headers.%", "0"),
+					resource.TestCheckResourceAttr(resourceName, "external.follow_redirect", "false"),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccMackerelMonitorConfigExternalUpdated(serviceName, nameUpdated),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMackerelMonitorExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", nameUpdated),
+					resource.TestCheckResourceAttr(resourceName, "external.method", "POST"),
+					resource.TestCheckResourceAttr(resourceName, "external.max_check_attempts", "3"),
+					resource.TestCheckResourceAttr(resourceName, "external.service", serviceName),
+					resource.TestCheckResourceAttr(resourceName, "external.response_time_critical", "3000"),
+					resource.TestCheckResourceAttr(resourceName, "external.response_time_warning", "2000"),
+					resource.TestCheckResourceAttr(resourceName, "external.response_time_duration", "3"),
+					resource.TestCheckResourceAttr(resourceName, "external.request_body", "foo=bar"),
+					resource.TestCheckResourceAttr(resourceName, "external.contains_string", "blah blah blah"),
+					resource.TestCheckResourceAttr(resourceName, "external.certification_expiration_critical", "7"),
+					resource.TestCheckResourceAttr(resourceName, "external.certification_expiration_warning", "14"),
+					resource.TestCheckResourceAttr(resourceName, "external.skip_certificate_verification", "true"),
+					resource.TestCheckResourceAttr(resourceName, "external.headers.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "external.headers.Cache-Control", "no-cache"),
+					resource.TestCheckResourceAttr(resourceName, "external.follow_redirect", "true"),
+				),
+			},
+			// Test: Import
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func testAccCheckMackerelMonitorDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*mackerel.Client)
 	for _, r := range s.RootModule().Resources {
@@ -569,18 +744,31 @@ func testAccCheckMackerelMonitorExists(n string) resource.TestCheckFunc {
 	}
 }
 
+func testAccCheckMackerelMonitorDisappears(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("monitor not found from resources: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*mackerel.Client)
+		_, err := client.DeleteMonitor(rs.Primary.ID)
+		return err
+	}
+}
+
 func testAccMackerelMonitorConfigHostMetric(name string) string {
 	return fmt.Sprintf(`
 resource "mackerel_monitor" "foo" {
   name = "%s"
-  host_metric {
+  %s {
     metric = "cpu.sys"
     operator = ">"
     warning = 75
     duration = 1
   }
 }
-`, name)
+`, name, testAccNestedObject("mackerel_monitor", "host_metric"))
 }
 
 func testAccMackerelMonitorConfigHostMetricUpdated(rand, name string) string {
@@ -608,7 +796,7 @@ resource "mackerel_monitor" "foo" {
   memo = "This monitor is managed by Terraform."
   is_mute = true
   notification_interval = 30
-  host_metric {
+  %s {
     metric = "cpu.usr"
     operator = ">"
     warning = "70"
@@ -623,16 +811,16 @@ resource "mackerel_monitor" "foo" {
       mackerel_role.not_scoped.id]
   }
 }
-`, rand, rand, rand, rand, name)
+`, rand, rand, rand, rand, name, testAccNestedObject("mackerel_monitor", "host_metric"))
 }
 
 func testAccMackerelMonitorConfigConnectivity(name string) string {
 	return fmt.Sprintf(`
 resource "mackerel_monitor" "foo" {
   name = "%s"
-  connectivity {}
+  %s {}
 }
-`, name)
+`, name, testAccNestedObject("mackerel_monitor", "connectivity"))
 }
 
 func testAccMackerelMonitorConfigConnectivityUpdated(rand, name string) string {
@@ -708,8 +896,8 @@ resource "mackerel_monitor" "foo" {
     duration = 3
     metric = "custom.access.5xx_ratio"
     operator = "<"
-    warning = "99.9"
-    critical = "99.99"
+    warning = "99.99"
+    critical = "99.9"
     max_check_attempts = 5
     missing_duration_warning = 10
     missing_duration_critical = 10080
@@ -722,12 +910,12 @@ func testAccMackerelMonitorConfigExternal(name string) string {
 	return fmt.Sprintf(`
 resource "mackerel_monitor" "foo" {
   name = "%s"
-  external {
+  %s {
     method = "GET"
     url = "https://terraform-provider-mackerel.test/"
   }
 }
-`, name)
+`, name, testAccNestedObject("mackerel_monitor", "external"))
 }
 
 func testAccMackerelMonitorConfigExternalUpdated(serviceName, name string) string {
@@ -741,7 +929,7 @@ resource "mackerel_monitor" "foo" {
   memo = "This monitor is managed by Terraform."
   is_mute = true
   notification_interval = 30
-  external {
+  %s {
     method = "POST"
     url = "https://terraform-provider-mackerel.test/"
     max_check_attempts = 3
@@ -760,7 +948,7 @@ resource "mackerel_monitor" "foo" {
     follow_redirect = true
   }
 }
-`, serviceName, name)
+`, serviceName, name, testAccNestedObject("mackerel_monitor", "external"))
 }
 
 func testAccMackerelMonitorConfigExpression(name string) string {
@@ -872,3 +1060,129 @@ resource "mackerel_monitor" "foo" {
 }
 `, name)
 }
+
+func TestCustomizeDiffMonitorThresholds(t *testing.T) {
+	t.Parallel()
+
+	// The SDK represents unknown values in raw configurations with this string.
+	const unknown = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+	// Empty values are omitted from the configuration.
+	cases := map[string]struct {
+		operator string
+		warning  string
+		critical string
+		wantErr  string
+	}{
+		"greater": {
+			operator: ">", warning: "80", critical: "90",
+		},
+		"greater and equal": {
+			operator: ">", warning: "90", critical: "90",
+		},
+		"greater but reversed": {
+			operator: ">", warning: "90", critical: "80",
+			wantErr: `warning (90) must be less than or equal to critical (80) when operator is ">"`,
+		},
+		"less": {
+			operator: "<", warning: "20", critical: "10",
+		},
+		"less but reversed": {
+			operator: "<", warning: "10", critical: "20",
+			wantErr: `warning (10) must be greater than or equal to critical (20) when operator is "<"`,
+		},
+		"only warning": {
+			operator: ">", warning: "90",
+		},
+		"only critical": {
+			operator: "<", critical: "10",
+		},
+		"unknown operator": {
+			operator: unknown, warning: "90", critical: "80",
+		},
+		"unknown warning": {
+			operator: ">", warning: unknown, critical: "80",
+		},
+		"unknown critical": {
+			operator: "<", warning: "10", critical: unknown,
+		},
+		"invalid threshold": {
+			operator: ">", warning: "ninety", critical: "80",
+		},
+	}
+
+	r := resourceMackerelMonitor()
+	for _, monitorType := range mackerelinternal.ThresholdMonitorTypes {
+		for name, tt := range cases {
+			t.Run(monitorType+"/"+name, func(t *testing.T) {
+				t.Parallel()
+
+				block := map[string]interface{}{"operator": tt.operator}
+				if tt.warning != "" {
+					block["warning"] = tt.warning
+				}
+				if tt.critical != "" {
+					block["critical"] = tt.critical
+				}
+				config := sdkterraform.NewResourceConfigRaw(map[string]interface{}{
+					"name":      "monitor",
+					monitorType: []interface{}{block},
+				})
+
+				_, err := r.Diff(context.Background(), nil, config, nil)
+				if tt.wantErr == "" {
+					if err != nil {
+						t.Errorf("unexpected error: %v", err)
+					}
+					return
+				}
+				if want := monitorType + ": " + tt.wantErr; err == nil || !strings.Contains(err.Error(), want) {
+					t.Errorf("expected an error containing %q, but got: %v", want, err)
+				}
+			})
+		}
+	}
+}
+
+func TestUpgradeSDKStateMonitor(t *testing.T) {
+	t.Parallel()
+
+	warning, critical := 80.0, 90.5
+	monitor := &mackerel.MonitorHostMetric{
+		ID:                   "test",
+		Name:                 "cpu",
+		Type:                 "host",
+		NotificationInterval: 30,
+		Metric:               "cpu%",
+		Operator:             ">",
+		Warning:              &warning,
+		Critical:             &critical,
+		Duration:             3,
+		MaxCheckAttempts:     5,
+		Scopes:               []string{"service: role"},
+	}
+
+	hostMetric := tftypes.NewAttributePath().WithAttributeName("host_metric")
+	testUpgradeSDKState(t, "mackerel_monitor", func(d *schema.ResourceData) diag.Diagnostics {
+		return flattenMonitor(monitor, d)
+	}, []upgradedStateCheck{
+		{tftypes.NewAttributePath().WithAttributeName("id"), tftypes.NewValue(tftypes.String, "test")},
+		{tftypes.NewAttributePath().WithAttributeName("name"), tftypes.NewValue(tftypes.String, "cpu")},
+		{tftypes.NewAttributePath().WithAttributeName("memo"), tftypes.NewValue(tftypes.String, "")},
+		{tftypes.NewAttributePath().WithAttributeName("is_mute"), tftypes.NewValue(tftypes.Bool, false)},
+		{tftypes.NewAttributePath().WithAttributeName("notification_interval"), tftypes.NewValue(tftypes.Number, 30)},
+		{hostMetric.WithAttributeName("metric"), tftypes.NewValue(tftypes.String, "cpu%")},
+		{hostMetric.WithAttributeName("operator"), tftypes.NewValue(tftypes.String, ">")},
+		{hostMetric.WithAttributeName("warning"), tftypes.NewValue(tftypes.String, "80")},
+		{hostMetric.WithAttributeName("critical"), tftypes.NewValue(tftypes.String, "90.5")},
+		{hostMetric.WithAttributeName("duration"), tftypes.NewValue(tftypes.Number, 3)},
+		{hostMetric.WithAttributeName("max_check_attempts"), tftypes.NewValue(tftypes.Number, 5)},
+		{hostMetric.WithAttributeName("scopes"), tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "service:role"),
+		})},
+		{hostMetric.WithAttributeName("exclude_scopes"), tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{})},
+		{tftypes.NewAttributePath().WithAttributeName("connectivity"), tftypes.Value{}},
+		{tftypes.NewAttributePath().WithAttributeName("expression"), tftypes.Value{}},
+		{tftypes.NewAttributePath().WithAttributeName("timeouts"), tftypes.Value{}},
+	})
+}
@@ -2,6 +2,7 @@ package mackerel
 
 import (
 	"context"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -41,6 +42,12 @@ func resourceMackerelNotificationGroup() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -77,20 +84,20 @@ func resourceMackerelNotificationGroup() *schema.Resource {
 }
 
 func resourceMackerelNotificationGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	group, err := client.CreateNotificationGroup(expandNotificationGroup(d))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromResourceErr(err, d)
 	}
 	d.SetId(group.ID)
 	return resourceMackerelNotificationGroupRead(ctx, d, m)
 }
 
-func resourceMackerelNotificationGroupRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+func resourceMackerelNotificationGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := clientWithContext(ctx, m)
 	groups, err := client.FindNotificationGroups()
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	var group *mackerel.NotificationGroup
 	for _, g := range groups {
@@ -100,27 +107,29 @@ func resourceMackerelNotificationGroupRead(_ context.Context, d *schema.Resource
 		}
 	}
 	if group == nil {
-		return diag.Errorf("the ID '%s' does not match any notification-group in mackerel.io", d.Id())
+		log.Printf("[WARN] notification group (%s) is not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
 	}
 	return flattenNotificationGroup(group, d)
 }
 
 func resourceMackerelNotificationGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	group, err := client.UpdateNotificationGroup(d.Id(), expandNotificationGroup(d))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromResourceErr(err, d)
 	}
 	d.SetId(group.ID)
 	return resourceMackerelNotificationGroupRead(ctx, d, m)
 }
 
-func resourceMackerelNotificationGroupDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceMackerelNotificationGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	_, err := client.DeleteNotificationGroup(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	return diags
 }
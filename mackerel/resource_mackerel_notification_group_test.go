@@ -18,7 +18,7 @@ func TestAccMackerelNotificationGroup(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelNotificationGroupDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -57,6 +57,29 @@ func TestAccMackerelNotificationGroup(t *testing.T) {
 	})
 }
 
+func TestAccMackerelNotificationGroup_disappears(t *testing.T) {
+	resourceName := "mackerel_notification_group.foo"
+	rand := acctest.RandString(5)
+	name := fmt.Sprintf("tf-notification-grouup %s", rand)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMackerelNotificationGroupDestroy,
+		Steps: []resource.TestStep{
+			// Test: Deleted outside of Terraform
+			{
+				Config: testAccMackerelNotificationGroupConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMackerelNotificationGroupExists(resourceName),
+					testAccCheckMackerelNotificationGroupDisappears(resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func testAccCheckMackerelNotificationGroupDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*mackerel.Client)
 	for _, r := range s.RootModule().Resources {
@@ -102,6 +125,19 @@ func testAccCheckMackerelNotificationGroupExists(n string) resource.TestCheckFun
 	}
 }
 
+func testAccCheckMackerelNotificationGroupDisappears(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("notification group not found from resources: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*mackerel.Client)
+		_, err := client.DeleteNotificationGroup(rs.Primary.ID)
+		return err
+	}
+}
+
 func testAccMackerelNotificationGroupConfig(name string) string {
 	return fmt.Sprintf(`
 resource "mackerel_notification_group" "foo" {
@@ -127,7 +163,7 @@ resource "mackerel_channel" "foo" {
 
 resource "mackerel_monitor" "foo" {
   name = "tf-monitor-%s"
-  connectivity {}
+  %s {}
 }
 
 resource "mackerel_notification_group" "child" {
@@ -141,7 +177,24 @@ resource "mackerel_notification_group" "foo" {
     mackerel_notification_group.child.id]
   child_channel_ids = [
     mackerel_channel.foo.id]
-  monitor {
+%s
+}
+`, rand, rand, rand, rand, testAccNestedObject("mackerel_monitor", "connectivity"), rand, name, testAccMackerelNotificationGroupConfigTargets())
+}
+
+// The framework based implementation configures targets with set attributes instead of repeated blocks.
+func testAccMackerelNotificationGroupConfigTargets() string {
+	if testAccFramework("mackerel_notification_group") {
+		return `  monitor = [{
+    id = mackerel_monitor.foo.id
+    skip_default = false
+  }]
+  service = [
+    { name = mackerel_service.foo.name },
+    { name = mackerel_service.bar.name },
+  ]`
+	}
+	return `  monitor {
     id = mackerel_monitor.foo.id
     skip_default = false
   }
@@ -159,7 +212,5 @@ resource "mackerel_notification_group" "foo" {
   }
   service {
     name = mackerel_service.bar.name
-  }
-}
-`, rand, rand, rand, rand, rand, name)
+  }`
 }
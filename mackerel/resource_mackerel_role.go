@@ -3,6 +3,7 @@ package mackerel
 import (
 	"context"
 	"fmt"
+	"log"
 	"regexp"
 	"strings"
 
@@ -10,6 +11,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/mackerelio/mackerel-client-go"
+
+	mackerelinternal "github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
 )
 
 func resourceMackerelRole() *schema.Resource {
@@ -20,6 +23,11 @@ func resourceMackerelRole() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceMackerelRoleImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 		Schema: map[string]*schema.Schema{
 			"service": {
 				Type:     schema.TypeString,
@@ -47,20 +55,25 @@ func resourceMackerelRole() *schema.Resource {
 
 func resourceMackerelRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	service := d.Get("service").(string)
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	role, err := client.CreateRole(service, expandCreateRoleParam(d))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromResourceErr(err, d)
 	}
 	d.SetId(fmt.Sprintf("%s:%s", service, role.Name))
 	return resourceMackerelRoleRead(ctx, d, m)
 }
 
-func resourceMackerelRoleRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+func resourceMackerelRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := clientWithContext(ctx, m)
 	roles, err := client.FindRoles(d.Get("service").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		if mackerelinternal.IsNotFound(err) {
+			log.Printf("[WARN] role (%s) is not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diagFromErr(err)
 	}
 	var role *mackerel.Role
 	for _, r := range roles {
@@ -70,17 +83,19 @@ func resourceMackerelRoleRead(_ context.Context, d *schema.ResourceData, m inter
 		}
 	}
 	if role == nil {
-		return diag.Errorf("the name '%s' does not match any role in mackerel.io", d.Get("name").(string))
+		log.Printf("[WARN] role (%s) is not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
 	}
 	return flattenRole(role, d)
 }
 
-func resourceMackerelRoleDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceMackerelRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	_, err := client.DeleteRole(d.Get("service").(string), d.Get("name").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	return diags
 }
@@ -4,12 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/mackerelio/mackerel-client-go"
+
+	mackerelinternal "github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
 )
 
 func resourceMackerelRoleMetadata() *schema.Resource {
@@ -21,6 +24,12 @@ func resourceMackerelRoleMetadata() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceMackerelRoleMetadataImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 		Schema: map[string]*schema.Schema{
 			"service": {
 				Type:     schema.TypeString,
@@ -50,21 +59,26 @@ func resourceMackerelRoleMetadataCreate(ctx context.Context, d *schema.ResourceD
 	namespace := d.Get("namespace").(string)
 	metadata, err := expandRoleMetadata(d.Get("metadata_json").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromResourceErr(err, d)
 	}
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	if err := client.PutRoleMetaData(service, role, namespace, metadata); err != nil {
-		return diag.FromErr(err)
+		return diagFromResourceErr(err, d)
 	}
 	d.SetId(fmt.Sprintf("%s:%s/%s", service, role, namespace))
 	return resourceMackerelRoleMetadataRead(ctx, d, m)
 }
 
-func resourceMackerelRoleMetadataRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+func resourceMackerelRoleMetadataRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := clientWithContext(ctx, m)
 	resp, err := client.GetRoleMetaData(d.Get("service").(string), d.Get("role").(string), d.Get("namespace").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		if mackerelinternal.IsNotFound(err) {
+			log.Printf("[WARN] role metadata (%s) is not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diagFromErr(err)
 	}
 	return flattenRoleMetadata(resp.RoleMetaData, d)
 }
@@ -73,11 +87,11 @@ func resourceMackerelRoleMetadataUpdate(ctx context.Context, d *schema.ResourceD
 	return resourceMackerelRoleMetadataCreate(ctx, d, m)
 }
 
-func resourceMackerelRoleMetadataDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceMackerelRoleMetadataDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	if err := client.DeleteRoleMetaData(d.Get("service").(string), d.Get("role").(string), d.Get("namespace").(string)); err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	return diags
 }
@@ -19,7 +19,7 @@ func TestAccMackerelRoleMetadata(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelRoleMetadataDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -54,6 +54,31 @@ func TestAccMackerelRoleMetadata(t *testing.T) {
 	})
 }
 
+func TestAccMackerelRoleMetadata_disappears(t *testing.T) {
+	resourceName := "mackerel_role_metadata.foo"
+	rand := acctest.RandString(5)
+	rServiceName := fmt.Sprintf("tf-%s", rand)
+	rRoleName := fmt.Sprintf("tf-%s-role", rand)
+	rNamespace := fmt.Sprintf("tf-namespace-%s", rand)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMackerelRoleMetadataDestroy,
+		Steps: []resource.TestStep{
+			// Test: Deleted outside of Terraform
+			{
+				Config: testAccMackerelRoleMetadataConfig(rServiceName, rRoleName, rNamespace),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMackerelRoleMetadataExists(resourceName),
+					testAccCheckMackerelRoleMetadataDisappears(resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func testAccCheckMackerelRoleMetadataDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*mackerel.Client)
 	for _, r := range s.RootModule().Resources {
@@ -90,6 +115,18 @@ func testAccCheckMackerelRoleMetadataExists(n string) resource.TestCheckFunc {
 	}
 }
 
+func testAccCheckMackerelRoleMetadataDisappears(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("role metadata not found from resources: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*mackerel.Client)
+		return client.DeleteRoleMetaData(rs.Primary.Attributes["service"], rs.Primary.Attributes["role"], rs.Primary.Attributes["namespace"])
+	}
+}
+
 func testAccMackerelRoleMetadataConfig(serviceName, roleName, namespace string) string {
 	return fmt.Sprintf(`
 resource "mackerel_service" "foo" {
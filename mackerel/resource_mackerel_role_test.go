@@ -19,7 +19,7 @@ func TestAccMackerelRole(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelRoleDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -52,6 +52,30 @@ func TestAccMackerelRole(t *testing.T) {
 	})
 }
 
+func TestAccMackerelRole_disappears(t *testing.T) {
+	resourceName := "mackerel_role.bar"
+	rand := acctest.RandString(5)
+	serviceName := fmt.Sprintf("tf-service-%s", rand)
+	name := fmt.Sprintf("tf-role-%s", rand)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMackerelRoleDestroy,
+		Steps: []resource.TestStep{
+			// Test: Deleted outside of Terraform
+			{
+				Config: testAccMackerelRoleConfig(serviceName, name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMackerelRoleExists(resourceName),
+					testAccCheckMackerelRoleDisappears(resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func testAccCheckMackerelRoleDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*mackerel.Client)
 	for _, r := range s.RootModule().Resources {
@@ -102,6 +126,19 @@ func testAccCheckMackerelRoleExists(n string) resource.TestCheckFunc {
 	}
 }
 
+func testAccCheckMackerelRoleDisappears(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("role not found from resources: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*mackerel.Client)
+		_, err := client.DeleteRole(rs.Primary.Attributes["service"], rs.Primary.Attributes["name"])
+		return err
+	}
+}
+
 func testAccMackerelRoleConfig(serviceName, name string) string {
 	return fmt.Sprintf(`
 resource "mackerel_service" "foo" {
@@ -2,6 +2,7 @@ package mackerel
 
 import (
 	"context"
+	"log"
 	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -18,6 +19,11 @@ func resourceMackerelService() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -39,20 +45,20 @@ func resourceMackerelService() *schema.Resource {
 }
 
 func resourceMackerelServiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	service, err := client.CreateService(expandCreateServiceParam(d))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromResourceErr(err, d)
 	}
 	d.SetId(service.Name)
 	return resourceMackerelServiceRead(ctx, d, m)
 }
 
-func resourceMackerelServiceRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+func resourceMackerelServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := clientWithContext(ctx, m)
 	services, err := client.FindServices()
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	var service *mackerel.Service
@@ -63,17 +69,19 @@ func resourceMackerelServiceRead(_ context.Context, d *schema.ResourceData, m in
 		}
 	}
 	if service == nil {
-		return diag.Errorf("the name '%s' does not match any service in mackerel.io", d.Id())
+		log.Printf("[WARN] service (%s) is not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
 	}
 	return flattenService(service, d)
 }
 
-func resourceMackerelServiceDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceMackerelServiceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	_, err := client.DeleteService(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	return diags
 }
@@ -4,12 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/mackerelio/mackerel-client-go"
+
+	mackerelinternal "github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
 )
 
 func resourceMackerelServiceMetadata() *schema.Resource {
@@ -21,6 +24,12 @@ func resourceMackerelServiceMetadata() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceMackerelServiceMetadataImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 		Schema: map[string]*schema.Schema{
 			"service": {
 				Type:     schema.TypeString,
@@ -45,21 +54,26 @@ func resourceMackerelServiceMetadataCreate(ctx context.Context, d *schema.Resour
 	namespace := d.Get("namespace").(string)
 	metadata, err := expandServiceMetadata(d.Get("metadata_json").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromResourceErr(err, d)
 	}
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	if err := client.PutServiceMetaData(service, namespace, metadata); err != nil {
-		return diag.FromErr(err)
+		return diagFromResourceErr(err, d)
 	}
 	d.SetId(strings.Join([]string{service, namespace}, "/"))
 	return resourceMackerelServiceMetadataRead(ctx, d, m)
 }
 
-func resourceMackerelServiceMetadataRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*mackerel.Client)
+func resourceMackerelServiceMetadataRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := clientWithContext(ctx, m)
 	resp, err := client.GetServiceMetaData(d.Get("service").(string), d.Get("namespace").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		if mackerelinternal.IsNotFound(err) {
+			log.Printf("[WARN] service metadata (%s) is not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diagFromErr(err)
 	}
 	return flattenServiceMetadata(resp.ServiceMetaData, d)
 }
@@ -68,11 +82,11 @@ func resourceMackerelServiceMetadataUpdate(ctx context.Context, d *schema.Resour
 	return resourceMackerelServiceMetadataCreate(ctx, d, m)
 }
 
-func resourceMackerelServiceMetadataDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceMackerelServiceMetadataDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*mackerel.Client)
+	client := clientWithContext(ctx, m)
 	if err := client.DeleteServiceMetaData(d.Get("service").(string), d.Get("namespace").(string)); err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	return diags
 }
@@ -18,7 +18,7 @@ func TestAccMackerelServiceMetadata(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckMackerelServiceMetadataDestroy,
 		Steps: []resource.TestStep{
 			// Test: Create
@@ -51,6 +51,30 @@ func TestAccMackerelServiceMetadata(t *testing.T) {
 	})
 }
 
+func TestAccMackerelServiceMetadata_disappears(t *testing.T) {
+	resourceName := "mackerel_service_metadata.foo"
+	rand := acctest.RandString(5)
+	serviceName := fmt.Sprintf("tf-%s", rand)
+	namespace := fmt.Sprintf("tf-namespace-%s", rand)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMackerelServiceMetadataDestroy,
+		Steps: []resource.TestStep{
+			// Test: Deleted outside of Terraform
+			{
+				Config: testAccMackerelServiceMetadataConfig(serviceName, namespace),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMackerelServiceMetadataExists(resourceName),
+					testAccCheckMackerelServiceMetadataDisappears(resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func testAccCheckMackerelServiceMetadataDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*mackerel.Client)
 	for _, r := range s.RootModule().Resources {
@@ -86,6 +110,18 @@ func testAccCheckMackerelServiceMetadataExists(n string) resource.TestCheckFunc
 	}
 }
 
+func testAccCheckMackerelServiceMetadataDisappears(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("service metadata not found from resources: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*mackerel.Client)
+		return client.DeleteServiceMetaData(rs.Primary.Attributes["service"], rs.Primary.Attributes["namespace"])
+	}
+}
+
 func testAccMackerelServiceMetadataConfig(serviceName, namespace string) string {
 	return fmt.Sprintf(`
 resource "mackerel_service" "foo" {
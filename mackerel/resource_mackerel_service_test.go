@@ -102,7 +102,7 @@ resource "mackerel_service" "foo" {
 		t.Run(name, func(t *testing.T) {
 			resource.ParallelTest(t, resource.TestCase{
 				PreCheck:                 func() { testAccPreCheck(t) },
-				ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 				CheckDestroy:             testAccCheckMackerelServiceDestroy,
 				Steps:                    f(),
 			})
@@ -110,6 +110,31 @@ resource "mackerel_service" "foo" {
 	}
 }
 
+func TestAccMackerelService_disappears(t *testing.T) {
+	resourceName := "mackerel_service.foo"
+	name := fmt.Sprintf("tf-%s", acctest.RandString(5))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMackerelServiceDestroy,
+		Steps: []resource.TestStep{
+			// Test: Deleted outside of Terraform
+			{
+				Config: `
+resource "mackerel_service" "foo" {
+  name = "` + name + `"
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMackerelServiceExists(resourceName),
+					testAccCheckMackerelServiceDisappears(resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func testAccCheckMackerelServiceDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*mackerel.Client)
 	for _, r := range s.RootModule().Resources {
@@ -156,3 +181,16 @@ func testAccCheckMackerelServiceExists(n string) resource.TestCheckFunc {
 		return fmt.Errorf("service not found from mackerel: %s", rs.Primary.ID)
 	}
 }
+
+func testAccCheckMackerelServiceDisappears(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("service not found from resources: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*mackerel.Client)
+		_, err := client.DeleteService(rs.Primary.ID)
+		return err
+	}
+}
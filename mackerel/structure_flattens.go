@@ -18,7 +18,7 @@ func flattenService(service *mackerel.Service, d *schema.ResourceData) (diags di
 func flattenServiceMetadata(metadata mackerel.ServiceMetaData, d *schema.ResourceData) (diags diag.Diagnostics) {
 	metadataJSON, err := structure.FlattenJsonToString(metadata.(map[string]interface{}))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	d.Set("metadata_json", metadataJSON)
 	return diags
@@ -39,7 +39,7 @@ func flattenRole(role *mackerel.Role, d *schema.ResourceData) (diags diag.Diagno
 func flattenRoleMetadata(metadata mackerel.RoleMetaData, d *schema.ResourceData) (diags diag.Diagnostics) {
 	metadataJSON, err := structure.FlattenJsonToString(metadata.(map[string]interface{}))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	d.Set("metadata_json", metadataJSON)
 	return diags
@@ -0,0 +1,41 @@
+package mackerel
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	mackerelinternal "github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
+)
+
+type crudFunc = func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+
+// Wraps the CRUD functions of the resources and the data sources, so that each operation is a span.
+func traceResources(resources map[string]*schema.Resource) {
+	for typeName, r := range resources {
+		r.CreateContext = traceCRUDFunc(typeName, "Create", r.CreateContext)
+		r.ReadContext = traceCRUDFunc(typeName, "Read", r.ReadContext)
+		r.UpdateContext = traceCRUDFunc(typeName, "Update", r.UpdateContext)
+		r.DeleteContext = traceCRUDFunc(typeName, "Delete", r.DeleteContext)
+	}
+}
+
+func traceCRUDFunc(typeName, operation string, f crudFunc) crudFunc {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		ctx, span := mackerelinternal.StartSpan(ctx, typeName, operation)
+		diags := f(ctx, d, m)
+		var errMessage string
+		for _, d := range diags {
+			if d.Severity == diag.Error {
+				errMessage = d.Summary
+				break
+			}
+		}
+		mackerelinternal.EndSpan(span, errMessage)
+		return diags
+	}
+}
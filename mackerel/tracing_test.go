@@ -0,0 +1,46 @@
+package mackerel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTraceResources(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	resources := map[string]*schema.Resource{
+		"mackerel_service": {
+			ReadContext: func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+				return diag.Errorf("not found")
+			},
+		},
+	}
+	traceResources(resources)
+
+	r := resources["mackerel_service"]
+	if r.CreateContext != nil {
+		t.Error("expected CreateContext to remain nil")
+	}
+	_ = r.ReadContext(context.Background(), nil, nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected a span, but got: %d", len(spans))
+	}
+	if spans[0].Name() != "mackerel_service.Read" {
+		t.Errorf("unexpected span name: %s", spans[0].Name())
+	}
+	if status := spans[0].Status(); status.Code != codes.Error || status.Description != "not found" {
+		t.Errorf("unexpected span status: %+v", status)
+	}
+}
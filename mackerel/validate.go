@@ -1,13 +1,59 @@
 package mackerel
 
 import (
-	"regexp"
+	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/expression"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/query"
+	"github.com/mackerelio-labs/terraform-provider-mackerel/internal/typeutil"
 )
 
-// https://github.com/DataDog/terraform-provider-datadog/blob/master/datadog/internal/validators/validators.go#L16
-// ValidateFloatString makes sure a string can be parsed into a float
+// ValidateFloatString makes sure a string is empty or can be parsed into a float
 func ValidateFloatString(v interface{}, k string) (ws []string, errors []error) {
-	return validation.StringMatch(regexp.MustCompile(`\d*(\.\d*)?`), "value must be a float")(v, k)
+	s, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	// For legacy reasons, empty strings are treated as a kind of empty value.
+	if s == "" {
+		return nil, nil
+	}
+	if _, err := typeutil.ParseFloatString(s); err != nil {
+		return nil, []error{fmt.Errorf("invalid float of %s: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// ValidateMetricExpression makes sure a string is a metric expression with valid arguments of known functions
+// and warns about unknown functions and unexpected numbers of arguments
+func ValidateMetricExpression(v interface{}, k string) (ws []string, errors []error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	warnings, err := expression.Validate(s)
+	if err != nil {
+		return nil, []error{fmt.Errorf("invalid metric expression of %s: %w", k, err)}
+	}
+	for _, w := range warnings {
+		ws = append(ws, fmt.Sprintf("metric expression of %s: %s", k, w))
+	}
+	return ws, nil
+}
+
+// ValidateMetricQuery makes sure a string is a PromQL-style query with balanced selectors and valid arguments of known functions
+// and warns about unknown functions
+func ValidateMetricQuery(v interface{}, k string) (ws []string, errors []error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	warnings, err := query.Validate(s)
+	if err != nil {
+		return nil, []error{fmt.Errorf("invalid query of %s: %w", k, err)}
+	}
+	for _, w := range warnings {
+		ws = append(ws, fmt.Sprintf("query of %s: %s", k, w))
+	}
+	return ws, nil
 }
@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
 
-	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 
+	mackerelinternal "github.com/mackerelio-labs/terraform-provider-mackerel/internal/mackerel"
 	"github.com/mackerelio-labs/terraform-provider-mackerel/mackerel"
 )
 
@@ -23,14 +25,25 @@ func main() {
 
 	flag.Parse()
 
-	var serveOpts []tf5server.ServeOpt
+	var serveOpts []tf6server.ServeOpt
 	if debug {
-		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
+		serveOpts = append(serveOpts, tf6server.WithManagedDebug())
 	}
 
-	if err := tf5server.Serve(
+	shutdownTracing, err := mackerelinternal.SetupTracing(context.Background())
+	if err != nil {
+		log.Printf("[WARN] failed to set up tracing: %v", err)
+	} else {
+		defer func() {
+			if err := shutdownTracing(context.Background()); err != nil {
+				log.Printf("[WARN] failed to flush traces: %v", err)
+			}
+		}()
+	}
+
+	if err := tf6server.Serve(
 		providerAddr,
-		mackerel.ProtoV5ProviderServer,
+		mackerel.ProtoV6ProviderServer,
 		serveOpts...,
 	); err != nil {
 		log.Printf("[ERROR] failed to start server: %v", err)